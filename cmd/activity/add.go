@@ -21,6 +21,7 @@ import (
 
 	jctx "github.com/roma-glushko/frens/internal/context"
 
+	"github.com/roma-glushko/frens/cmd/location"
 	"github.com/roma-glushko/frens/internal/friend"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -96,6 +97,8 @@ var AddCommand = &cli.Command{
 		appCtx := jctx.FromCtx(ctx)
 
 		return appCtx.Store.Tx(ctx, func(j *journal.Journal) error {
+			location.ConfirmUnknown(j, e.LocationIDs)
+
 			e, err = j.AddEvent(e)
 			if err != nil {
 				return fmt.Errorf("failed to add a new event: %v", err)
@@ -0,0 +1,57 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activity
+
+import (
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/history"
+	"github.com/roma-glushko/frens/internal/sync"
+	"github.com/urfave/cli/v2"
+)
+
+var HistoryCommand = &cli.Command{
+	Name:      "history",
+	Aliases:   []string{"hist"},
+	Usage:     "Show how the activity changed over time",
+	UsageText: "frens activity history <ACTIVITY_ID>",
+	Description: `Show changes of the activity committed to the journal git repository, newest first.
+Each change lists fields it set or changed. Changes appear once committed by "frens journal sync" or auto-sync.
+
+Examples:
+  frens activity history 2zpWoEiUYn6vrSl9w03NAVkWxMn
+`,
+	Args:      true,
+	ArgsUsage: `<ACTIVITY_ID>`,
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return cli.Exit("Please provide an activity ID. Execute `frens activity ls` to find out.", 1)
+		}
+
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+		actID := c.Args().First()
+
+		entries, err := history.Entity(ctx, sync.New(appCtx.JournalDir), "activity", actID)
+		if err != nil {
+			return err
+		}
+
+		if len(entries) == 0 {
+			return cli.Exit("No committed changes of the activity "+actID+" found.", 1)
+		}
+
+		return appCtx.Printer.PrintList(entries)
+	},
+}
@@ -41,6 +41,7 @@ Examples:
   frens activity list                        # list all activities
   frens activity ls -q "dinner"              # search by keyword
   frens activity ls -t meetup -t conference  # filter by tags
+  frens activity ls --with "Jim" --at Scranton  # activities with Jim in Scranton
   frens activity ls --from 2024/01/01        # activities since a date
   frens activity ls --since yesterday        # activities since yesterday
   frens activity ls --from 2024/01 --to 2024/03  # activities in a date range
@@ -57,6 +58,16 @@ Examples:
 			Aliases: []string{"t"},
 			Usage:   "Filter by tag(s)",
 		},
+		&cli.StringSliceFlag{
+			Name:    "with",
+			Aliases: []string{"w"},
+			Usage:   "Filter by friend(s) involved (name, nickname or ID)",
+		},
+		&cli.StringSliceFlag{
+			Name:    "at",
+			Aliases: []string{"l"},
+			Usage:   "Filter by location(s) (name, alias or ID)",
+		},
 		&cli.StringFlag{
 			Name:    "from",
 			Aliases: []string{"since"},
@@ -98,6 +109,8 @@ Examples:
 			activity, err := j.ListEvents(friend.ListEventQuery{
 				Type:      friend.EventTypeActivity,
 				Keyword:   strings.TrimSpace(c.String("search")),
+				Friends:   c.StringSlice("with"),
+				Locations: c.StringSlice("at"),
 				Tags:      c.StringSlice("tag"),
 				Since:     lang.ExtractDate(c.String("from")),
 				Until:     lang.ExtractDate(c.String("to")),
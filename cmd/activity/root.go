@@ -29,5 +29,8 @@ var Commands = &cli.Command{
 		ListCommand,
 		GraphCommand,
 		DeleteCommand,
+		HistoryCommand,
+		ImportCommand,
+		ExportCommand,
 	},
 }
@@ -0,0 +1,170 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package csvcmd shares the CSV import and export between commands of all entities
+package csvcmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/csvio"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/urfave/cli/v2"
+)
+
+// FormatFlag selects the CSV format
+func FormatFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "csv",
+		Usage: "Use the CSV format",
+	}
+}
+
+// OutputFlag sets the file to export to
+func OutputFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:      "output",
+		Aliases:   []string{"o"},
+		Usage:     "Write to the file instead of the standard output",
+		TakesFile: true,
+	}
+}
+
+// ImportFlags configure CSV imports
+func ImportFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:    "map",
+			Aliases: []string{"m"},
+			Usage:   "Read the field from a column with another name (e.g. --map 'name=Full Name')",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Preview changes without saving them",
+		},
+	}
+}
+
+// Export writes records to the file set by --output or to the standard output
+func Export[T any](c *cli.Context, e csvio.Entity[T], records []T) error {
+	var w io.Writer = os.Stdout
+
+	out := c.String("output")
+
+	if out != "" {
+		f, err := os.OpenFile(out, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", out, err)
+		}
+
+		defer f.Close()
+
+		w = f
+	}
+
+	if err := csvio.Encode(w, e, records); err != nil {
+		return err
+	}
+
+	if out != "" {
+		log.Successf("%d %s exported to %s", len(records), e.Name, out)
+	}
+
+	return nil
+}
+
+// Import applies the CSV file given as the command argument to the journal
+func Import[T any](c *cli.Context, e csvio.Entity[T]) error {
+	if c.NArg() < 1 {
+		return cli.Exit("Please provide the CSV file to import", 1)
+	}
+
+	m, err := csvio.ParseMapping(c.StringSlice("map"))
+	if err != nil {
+		return err
+	}
+
+	path := c.Args().First()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	defer f.Close()
+
+	ctx := c.Context
+	appCtx := jctx.FromCtx(ctx)
+	dryRun := c.Bool("dry-run")
+
+	var res csvio.Result
+
+	err = appCtx.Store.Tx(ctx, func(jr *journal.Journal) error {
+		res, err = csvio.Import(jr, e, f, m)
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", path, err)
+		}
+
+		if dryRun {
+			// the store saves dirty journals only
+			jr.SetDirty(false)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(res.Ignored) > 0 {
+		log.Warnf(
+			"Ignored columns: %s (supported: %s)",
+			strings.Join(res.Ignored, ", "),
+			strings.Join(e.Fields(), ", "),
+		)
+	}
+
+	if dryRun {
+		log.Info("Dry run, nothing is saved. Importing the file would:")
+
+		for _, ch := range res.Changes {
+			if ch.Err == nil && ch.Action != csvio.ActionKeep {
+				fmt.Printf("  line %d: %s %s\n", ch.Line, ch.Action, ch.Label)
+			}
+		}
+	} else {
+		log.Successf(
+			"Imported %s: %d added, %d updated, %d unchanged",
+			e.Name,
+			res.Count(csvio.ActionAdd),
+			res.Count(csvio.ActionUpdate),
+			res.Count(csvio.ActionKeep),
+		)
+	}
+
+	if failed := res.Failed(); len(failed) > 0 {
+		log.Warnf("%d row(s) couldn't be imported:", len(failed))
+
+		for _, ch := range failed {
+			fmt.Printf("  line %d: %s\n", ch.Line, ch.Err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contact
+
+import (
+	"github.com/roma-glushko/frens/cmd/csvcmd"
+	"github.com/roma-glushko/frens/internal/csvio"
+	"github.com/urfave/cli/v2"
+)
+
+var ImportCommand = &cli.Command{
+	Name:      "import",
+	Usage:     "Import contacts from a CSV table",
+	UsageText: "frens friend contact import --csv [OPTIONS] <FILE>",
+	Args:      true,
+	ArgsUsage: "<FILE>",
+	Description: `Import contacts from a CSV table like the one "frens friend contact export --csv" writes.
+Rows with IDs of existing contacts update them, other rows add new contacts.
+The friend column refers to friends by their IDs or names. Contact types are detected when left empty.
+Columns with other names can be mapped to fields via --map.
+
+Examples:
+  frens friend contact import --csv --dry-run contacts.csv   # preview changes of the edited table
+  frens friend contact import --csv contacts.csv
+`,
+	Flags: append([]cli.Flag{csvcmd.FormatFlag()}, csvcmd.ImportFlags()...),
+	Action: func(c *cli.Context) error {
+		if !c.Bool("csv") {
+			return cli.Exit("Choose the import format: --csv", 1)
+		}
+
+		return csvcmd.Import(c, csvio.Contacts)
+	},
+}
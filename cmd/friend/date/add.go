@@ -55,7 +55,7 @@ var AddCommand = &cli.Command{
 		&cli.StringFlag{
 			Name:    "date",
 			Aliases: []string{"d"},
-			Usage:   "Date in a free-form format (e.g., 'May 13th', '1996-7-30', '1985')",
+			Usage:   "Date in a free-form format (e.g., 'May 13th', '1996-7-30', or '15 Nisan 5750' for the Hebrew calendar)",
 		},
 		&cli.StringFlag{
 			Name:    "calendar",
@@ -0,0 +1,142 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package date
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/roma-glushko/frens/cmd/csvcmd"
+	"github.com/roma-glushko/frens/internal/calendar"
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/csvio"
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/urfave/cli/v2"
+)
+
+var ExportCommand = &cli.Command{
+	Name:  "export",
+	Usage: "Export dates to a calendar file or a CSV table",
+	Description: `Export friend dates, so calendar apps can remind you about them.
+
+	Gregorian dates repeat yearly. Hebrew dates are resolved for the next --years years,
+	as they fall on a different Gregorian day every year.
+	Re-importing the file updates the events instead of duplicating them.
+
+	The CSV table can be edited in a spreadsheet and imported back with "frens friend date import --csv".
+
+	Examples:
+		frens friend date export --ics > frens.ics
+		frens friend date export --ics -o ~/frens.ics --with jim --tag birthday
+		frens friend date export --csv -o dates.csv
+	`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "ics",
+			Usage: "Export in the iCalendar format",
+		},
+		csvcmd.FormatFlag(),
+		&cli.StringFlag{
+			Name:      "output",
+			Aliases:   []string{"o"},
+			Usage:     "Write to the file instead of the standard output",
+			TakesFile: true,
+		},
+		&cli.StringSliceFlag{
+			Name:    "with",
+			Aliases: []string{"w"},
+			Usage:   "Filter by friend(s)",
+		},
+		&cli.StringSliceFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "Filter by tag(s)",
+		},
+		&cli.IntFlag{
+			Name:  "years",
+			Value: 10,
+			Usage: "How many years ahead to resolve non-Gregorian dates for",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.Bool("ics") == c.Bool("csv") {
+			return cli.Exit("Choose the export format: --ics or --csv", 1)
+		}
+
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+
+		var (
+			dates []friend.Date
+			data  []byte
+		)
+
+		err := appCtx.Store.Tx(ctx, func(jr *journal.Journal) error {
+			var err error
+
+			dates, err = jr.ListFriendDates(friend.ListDateQuery{
+				Friends: c.StringSlice("with"),
+				Tags:    c.StringSlice("tag"),
+			})
+			if err != nil {
+				return err
+			}
+
+			if c.Bool("csv") {
+				return nil
+			}
+
+			names := make(map[string]string, len(jr.Friends))
+
+			for _, f := range jr.Friends {
+				names[f.ID] = f.Name
+			}
+
+			data, err = calendar.EncodeICS(dates, func(d friend.Date) string {
+				return calendar.DateTitle(names[d.Person], d)
+			}, calendar.WithYears(c.Int("years")))
+			if err != nil {
+				log.Warnf("some dates could not be exported: %v", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if c.Bool("csv") {
+			return csvcmd.Export(c, csvio.Dates, dates)
+		}
+
+		out := c.String("output")
+
+		if out == "" {
+			_, err = os.Stdout.Write(data)
+
+			return err
+		}
+
+		if err := os.WriteFile(out, data, 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", out, err)
+		}
+
+		log.Successf("Dates exported to %s", out)
+
+		return nil
+	},
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package date
+
+import (
+	"github.com/roma-glushko/frens/cmd/csvcmd"
+	"github.com/roma-glushko/frens/internal/csvio"
+	"github.com/urfave/cli/v2"
+)
+
+var ImportCommand = &cli.Command{
+	Name:      "import",
+	Usage:     "Import dates from a CSV table",
+	UsageText: "frens friend date import --csv [OPTIONS] <FILE>",
+	Args:      true,
+	ArgsUsage: "<FILE>",
+	Description: `Import dates from a CSV table like the one "frens friend date export --csv" writes.
+Rows with IDs of existing dates update them, other rows add new dates.
+The friend column refers to friends by their IDs or names.
+Columns with other names can be mapped to fields via --map.
+
+Examples:
+  frens friend date import --csv --dry-run dates.csv   # preview changes of the edited table
+  frens friend date import --csv dates.csv
+`,
+	Flags: append([]cli.Flag{csvcmd.FormatFlag()}, csvcmd.ImportFlags()...),
+	Action: func(c *cli.Context) error {
+		if !c.Bool("csv") {
+			return cli.Exit("Choose the import format: --csv", 1)
+		}
+
+		return csvcmd.Import(c, csvio.Dates)
+	},
+}
@@ -15,7 +15,12 @@
 package date
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/calendar"
 	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/lang"
 
 	jctx "github.com/roma-glushko/frens/internal/context"
 
@@ -29,6 +34,11 @@ var ListCommand = &cli.Command{
 	Name:    "list",
 	Aliases: []string{"l", "ls"},
 	Usage:   "List all dates for all friends",
+	Description: `List friend dates along with their next occurrence.
+	Examples:
+		frens friend date list
+		frens friend date list --upcoming 30d
+	`,
 	Flags: []cli.Flag{
 		&cli.StringFlag{
 			Name:    "search",
@@ -45,12 +55,28 @@ var ListCommand = &cli.Command{
 			Aliases: []string{"t"},
 			Usage:   "Filter by tag(s)",
 		},
+		&cli.StringFlag{
+			Name:    "upcoming",
+			Aliases: []string{"u"},
+			Usage:   "Show only dates happening within the given period sorted by the next occurrence (e.g., '30d', '2w')",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		ctx := c.Context
 		appCtx := jctx.FromCtx(ctx)
 		s := appCtx.Store
 
+		var upcoming time.Duration
+
+		if upcomingExpr := c.String("upcoming"); upcomingExpr != "" {
+			d, err := lang.ExtractDuration(upcomingExpr)
+			if err != nil {
+				return fmt.Errorf("invalid upcoming period '%s': %w", upcomingExpr, err)
+			}
+
+			upcoming = d
+		}
+
 		return s.Tx(ctx, func(jr *journal.Journal) error {
 			dates, err := jr.ListFriendDates(friend.ListDateQuery{
 				Keyword: c.String("search"),
@@ -61,6 +87,18 @@ var ListCommand = &cli.Command{
 				return err
 			}
 
+			if upcoming > 0 {
+				dates, err = calendar.Upcoming(dates, time.Now(), upcoming)
+				if err != nil {
+					log.Warnf("some dates could not be resolved: %v", err)
+				}
+			} else {
+				dates, err = calendar.Resolve(dates, time.Now())
+				if err != nil {
+					log.Debugf("some dates could not be resolved: %v", err)
+				}
+			}
+
 			if len(dates) == 0 {
 				log.Empty("dates")
 				return nil
@@ -29,5 +29,7 @@ var Commands = &cli.Command{
 		EditCommand,
 		ListCommand,
 		DeleteCommand,
+		ImportCommand,
+		ExportCommand,
 	},
 }
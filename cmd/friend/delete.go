@@ -15,6 +15,8 @@
 package friend
 
 import (
+	"fmt"
+
 	jctx "github.com/roma-glushko/frens/internal/context"
 	"github.com/roma-glushko/frens/internal/friend"
 	"github.com/roma-glushko/frens/internal/journal"
@@ -33,6 +35,7 @@ var DeleteCommand = &cli.Command{
 	Examples:
 		frens friend delete "Toby Flenderson"
 		frens friend d -f "Toby Flenderson"
+		frens friend delete --cascade "Toby Flenderson"   # also delete activities and notes only about Toby
 	`,
 	Args:      true,
 	ArgsUsage: `<FRIEND_NAME, FRIEND_NICKNAME, FRIEND_ID> [...]`,
@@ -43,6 +46,14 @@ var DeleteCommand = &cli.Command{
 			Value:   false,
 			Usage:   "Force delete without confirmation",
 		},
+		&cli.BoolFlag{
+			Name:  "cascade",
+			Usage: "Also delete activities and notes left without any friend or location once the friends are deleted",
+		},
+		&cli.BoolFlag{
+			Name:  "detach",
+			Usage: "Keep activities and notes, only remove the deleted friends from them (default)",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		ctx := c.Context
@@ -53,6 +64,11 @@ var DeleteCommand = &cli.Command{
 
 		friends := make([]friend.Person, 0, c.NArg())
 
+		mode, err := journal.NewRemoveMode(c.Bool("cascade"), c.Bool("detach"))
+		if err != nil {
+			return err
+		}
+
 		appCtx := jctx.FromCtx(ctx)
 
 		return appCtx.Store.Tx(ctx, func(j *journal.Journal) error {
@@ -72,10 +88,31 @@ var DeleteCommand = &cli.Command{
 				log.Bulletf("%s [%s]", log.LabelStyle.Render(f.String()), f.ID)
 			}
 
+			refs := j.FriendRefs(friends)
+
+			if refs.Total() > 0 {
+				log.Infof(
+					"\nAffected events: %d %s and %d %s.\n",
+					refs.Activities,
+					utils.P(refs.Activities, "activity", "activities"),
+					refs.Notes,
+					utils.P(refs.Notes, "note", "notes"),
+				)
+
+				if mode == journal.RemoveModeCascade {
+					log.Infof(
+						"%d of them will be deleted, as they involve no other friend or location. The rest will be detached.\n",
+						refs.Exclusive,
+					)
+				} else {
+					log.Info("They will be kept, but detached from the " + frenWord + ".\n")
+				}
+			}
+
 			// TODO: check if interactive mode
 			log.Info(
 				"\n" + log.WarnPrompt(
-					"You're about to permanently delete the "+frenWord+".",
+					"You're about to permanently delete the "+frenWord+deletedEvents(mode, refs)+".",
 				) + "\n",
 			)
 
@@ -84,7 +121,7 @@ var DeleteCommand = &cli.Command{
 				return nil
 			}
 
-			j.RemoveFriends(friends)
+			j.RemoveFriends(friends, mode)
 
 			log.Deleted(utils.TitleCaser.String(frenWord))
 
@@ -92,3 +129,12 @@ var DeleteCommand = &cli.Command{
 		})
 	},
 }
+
+// deletedEvents names the events cascade removal deletes along with the friends
+func deletedEvents(mode journal.RemoveMode, refs journal.EventRefs) string {
+	if mode != journal.RemoveModeCascade || refs.Exclusive == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(" and %d %s", refs.Exclusive, utils.P(refs.Exclusive, "event", "events"))
+}
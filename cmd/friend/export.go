@@ -0,0 +1,126 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package friend
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/roma-glushko/frens/cmd/csvcmd"
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/csvio"
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/vcard"
+	"github.com/urfave/cli/v2"
+)
+
+var ExportCommand = &cli.Command{
+	Name:      "export",
+	Usage:     "Export friends to a contacts file or a CSV table",
+	UsageText: "frens friend export --vcf|--csv [OPTIONS]",
+	Description: `Export friends with their nicknames, contacts, birthdays, anniversaries and locations,
+so they can be imported into address books.
+
+The CSV table lists friends only, their contacts, dates and wishlist items are exported by their own commands.
+Edit it in a spreadsheet and bring the changes back with "frens friend import --csv".
+
+Examples:
+  frens friend export --vcf > frens.vcf               # export all friends
+  frens friend export --vcf -o office.vcf -t office   # export friends with the tag to the file
+  frens friend export --csv -o friends.csv            # export friends as a table
+`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "vcf",
+			Usage: "Export in the vCard format",
+		},
+		csvcmd.FormatFlag(),
+		&cli.StringFlag{
+			Name:      "output",
+			Aliases:   []string{"o"},
+			Usage:     "Write to the file instead of the standard output",
+			TakesFile: true,
+		},
+		&cli.StringSliceFlag{
+			Name:    "location",
+			Aliases: []string{"l", "loc", "in"},
+			Usage:   "Filter by location(s)",
+		},
+		&cli.StringSliceFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "Filter by tag(s)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.Bool("vcf") == c.Bool("csv") {
+			return cli.Exit("Choose the export format: --vcf or --csv", 1)
+		}
+
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+
+		var (
+			friends []friend.Person
+			cards   []vcard.Card
+		)
+
+		err := appCtx.Store.Tx(ctx, func(jr *journal.Journal) error {
+			friends = jr.ListFriends(friend.ListFriendQuery{
+				Locations: c.StringSlice("location"),
+				Tags:      c.StringSlice("tag"),
+				SortBy:    friend.SortAlpha,
+				SortOrder: friend.SortOrderDirect,
+			})
+
+			for _, f := range friends {
+				cards = append(cards, vcard.FromFriend(jr, f))
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if c.Bool("csv") {
+			return csvcmd.Export(c, csvio.Friends, friends)
+		}
+
+		w := os.Stdout
+		out := c.String("output")
+
+		if out != "" {
+			w, err = os.OpenFile(out, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", out, err)
+			}
+
+			defer w.Close()
+		}
+
+		if err := vcard.Encode(w, cards); err != nil {
+			return err
+		}
+
+		if out != "" {
+			log.Successf("%d friends exported to %s", len(cards), out)
+		}
+
+		return nil
+	},
+}
@@ -29,6 +29,12 @@ var GetCommand = &cli.Command{
 	Usage:     "Get and display friend information",
 	Args:      true,
 	ArgsUsage: `<FRIEND_NAME, FRIEND_NICKNAME, FRIEND_ID>`,
+	Description: `Show the friend found by their name, nickname, or ID.
+
+Names with typos (e.g. "Micheal") are looked up too once fuzzy matching is on in the journal settings:
+  [match]
+  fuzzy = true
+`,
 	Action: func(c *cli.Context) error {
 		if c.NArg() < 1 {
 			return cli.Exit(
@@ -0,0 +1,76 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package friend
+
+import (
+	"strings"
+
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/history"
+	"github.com/roma-glushko/frens/internal/sync"
+	"github.com/urfave/cli/v2"
+)
+
+var HistoryCommand = &cli.Command{
+	Name:      "history",
+	Aliases:   []string{"hist"},
+	Usage:     "Show how the friend changed over time",
+	UsageText: "frens friend history <FRIEND_NAME, FRIEND_NICKNAME, FRIEND_ID>",
+	Description: `Show changes of the friend committed to the journal git repository, newest first.
+Each change lists fields it set or changed. Changes appear once committed by "frens journal sync" or auto-sync.
+Removed friends can be looked up by their IDs.
+
+Examples:
+  frens friend history jim                 # show how Jim changed
+  frens -o json friend history jim         # show changes of Jim as JSON
+`,
+	Args:      true,
+	ArgsUsage: `<FRIEND_NAME, FRIEND_NICKNAME, FRIEND_ID>`,
+	Action: func(c *cli.Context) error {
+		if c.NArg() < 1 {
+			return cli.Exit(
+				"You must provide a friend name, nickname, or ID. Execute `frens friend ls` to find out.",
+				1,
+			)
+		}
+
+		q := strings.Join(c.Args().Slice(), " ")
+
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+
+		jr, err := appCtx.Store.Load(ctx)
+		if err != nil {
+			return err
+		}
+
+		fID := q
+
+		if p, err := jr.GetFriend(q); err == nil {
+			fID = p.ID
+		}
+
+		entries, err := history.Entity(ctx, sync.New(appCtx.JournalDir), "friend", fID)
+		if err != nil {
+			return err
+		}
+
+		if len(entries) == 0 {
+			return cli.Exit("No committed changes of "+q+" found.", 1)
+		}
+
+		return appCtx.Printer.PrintList(entries)
+	},
+}
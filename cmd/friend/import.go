@@ -0,0 +1,282 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package friend
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gosimple/slug"
+	"github.com/roma-glushko/frens/cmd/csvcmd"
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/csvio"
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/tui"
+	"github.com/roma-glushko/frens/internal/vcard"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	onDuplicateAsk   = "ask"
+	onDuplicateMerge = "merge"
+	onDuplicateAdd   = "add"
+	onDuplicateSkip  = "skip"
+)
+
+var ImportCommand = &cli.Command{
+	Name:      "import",
+	Usage:     "Import friends from a contacts file or a CSV table",
+	UsageText: "frens friend import [--csv] [OPTIONS] <FILE>",
+	Args:      true,
+	ArgsUsage: "<FILE>",
+	Description: `Import friends with their nicknames, contacts, birthdays, anniversaries and addresses
+from vCard (.vcf) files exported by address books.
+
+Contacts that look like friends you already have (by their name, nickname, email or phone)
+are merged into them. You'll be asked what to do with every such contact, unless --duplicates is set.
+Addresses are linked to known locations by their city, region or country.
+
+With --csv, friends are imported from a table like the one "frens friend export --csv" writes.
+Rows with IDs of existing friends update them, other rows add new friends.
+Columns with other names can be mapped to fields via --map.
+
+Examples:
+  frens friend import contacts.vcf                      # ask what to do with duplicates
+  frens friend import --duplicates merge contacts.vcf   # merge duplicates without asking
+  frens friend import -t imported contacts.vcf          # tag imported friends
+  frens friend import --csv --dry-run friends.csv       # preview changes of the edited table
+  frens friend import --csv --map 'name=Full Name' people.csv
+`,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "duplicates",
+			Value: onDuplicateAsk,
+			Usage: "What to do with contacts of existing friends: ask, merge, add, skip",
+			Action: func(_ *cli.Context, s string) error {
+				switch s {
+				case onDuplicateAsk, onDuplicateMerge, onDuplicateAdd, onDuplicateSkip:
+					return nil
+				default:
+					return fmt.Errorf("invalid duplicates value '%s' (supported: ask, merge, add, skip)", s)
+				}
+			},
+		},
+		&cli.StringSliceFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "Add tags to imported friends",
+		},
+		csvcmd.FormatFlag(),
+	}, csvcmd.ImportFlags()...),
+	Action: func(c *cli.Context) error {
+		if c.Bool("csv") {
+			return csvcmd.Import(c, csvio.Friends)
+		}
+
+		if c.NArg() < 1 {
+			return cli.Exit("Please provide the file to import, e.g. frens friend import contacts.vcf", 1)
+		}
+
+		path := c.Args().First()
+
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+
+		defer f.Close()
+
+		cards, err := vcard.Decode(f)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+
+		tags := c.StringSlice("tag")
+
+		// duplicates are resolved before the journal is locked, as prompts may wait for answers for long
+		preview, err := appCtx.Store.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load journal: %w", err)
+		}
+
+		decisions := make([]importDecision, 0, len(cards))
+
+		for _, card := range cards {
+			p := importedPerson(preview, card, tags)
+
+			// friends exported by frens keep their IDs as UIDs
+			similar := preview.SimilarFriends(friend.Person{
+				ID:        card.UID,
+				Name:      p.Name,
+				Nicknames: p.Nicknames,
+				Contacts:  p.Contacts,
+			})
+
+			into, add := resolveDuplicate(p, similar, c.String("duplicates"))
+
+			var d importDecision
+
+			// decisions are applied to the loaded journal too, so later contacts can be merged into earlier ones
+			switch {
+			case into != nil:
+				d.into = into.ID
+				preview.MergeFriend(*into, p)
+			case add:
+				d.id = uniqueFriendID(preview, slug.Make(p.Name))
+				p.ID = d.id
+				preview.AddFriend(p)
+			}
+
+			decisions = append(decisions, d)
+		}
+
+		var added, merged, skipped int
+
+		err = appCtx.Store.Tx(ctx, func(jr *journal.Journal) error {
+			added, merged, skipped = 0, 0, 0
+
+			// IDs of added friends may be taken by the time the journal is locked
+			ids := make(map[string]string)
+
+			for i, card := range cards {
+				d := decisions[i]
+				p := importedPerson(jr, card, tags)
+
+				if d.into != "" {
+					into := cmp.Or(ids[d.into], d.into)
+
+					if k := slices.IndexFunc(jr.Friends, func(f *friend.Person) bool { return f.ID == into }); k >= 0 {
+						jr.MergeFriend(*jr.Friends[k], p)
+						merged++
+
+						continue
+					}
+
+					log.Warnf("Friend %s is gone, so %s is added as a new friend", into, p.Name)
+				} else if d.id == "" {
+					skipped++
+					continue
+				}
+
+				p.ID = uniqueFriendID(jr, slug.Make(p.Name))
+				jr.AddFriend(p)
+				added++
+
+				if d.id != "" {
+					ids[d.id] = p.ID
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		log.Successf("Imported %d contacts: %d added, %d merged, %d skipped", len(cards), added, merged, skipped)
+
+		return nil
+	},
+}
+
+// importDecision tells what to do with the imported contact: merge it into the friend, add it or skip it
+type importDecision struct {
+	into string // ID of the friend to merge the contact into
+	id   string // ID the contact was added with when resolving duplicates
+}
+
+func importedPerson(jr *journal.Journal, card vcard.Card, tags []string) friend.Person {
+	p := card.Person
+
+	p.Tags = append(p.Tags, tags...)
+	p.Locations = card.Locations(jr)
+
+	return p
+}
+
+// resolveDuplicate decides whether the contact should be merged into one of similar friends or added as a new one
+func resolveDuplicate(p friend.Person, similar []*friend.Person, mode string) (*friend.Person, bool) {
+	if len(similar) == 0 {
+		return nil, true
+	}
+
+	switch mode {
+	case onDuplicateMerge:
+		return similar[0], false
+	case onDuplicateAdd:
+		return nil, true
+	case onDuplicateSkip:
+		return nil, false
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s looks like a friend you already have:\n", p.Name)
+
+	for i, f := range similar {
+		fmt.Fprintf(&sb, "  %d) %s [%s]\n", i+1, f.String(), f.ID)
+	}
+
+	sb.WriteString("Merge into the friend [1")
+
+	if len(similar) > 1 {
+		fmt.Fprintf(&sb, "-%d", len(similar))
+	}
+
+	sb.WriteString("], add as a new friend [a] or skip [s, default]")
+
+	for {
+		answer := tui.Prompt(sb.String())
+
+		switch answer {
+		case "", "s", "skip":
+			return nil, false
+		case "a", "add":
+			return nil, true
+		}
+
+		if i, err := strconv.Atoi(answer); err == nil && i >= 1 && i <= len(similar) {
+			return similar[i-1], false
+		}
+
+		log.Warnf("Unknown answer '%s'", answer)
+	}
+}
+
+// uniqueFriendID adds a number to the ID if it's taken by another friend
+func uniqueFriendID(jr *journal.Journal, id string) string {
+	taken := make(map[string]bool, len(jr.Friends))
+
+	for _, f := range jr.Friends {
+		taken[f.ID] = true
+	}
+
+	unique := id
+
+	for i := 2; taken[unique]; i++ {
+		unique = id + "-" + strconv.Itoa(i)
+	}
+
+	return unique
+}
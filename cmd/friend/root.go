@@ -33,6 +33,9 @@ var Commands = &cli.Command{
 		EditCommand,
 		ListCommand,
 		DeleteCommand,
+		HistoryCommand,
+		ImportCommand,
+		ExportCommand,
 		date.Commands,
 		contact.Commands,
 		wishlist.Commands,
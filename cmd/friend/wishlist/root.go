@@ -29,5 +29,7 @@ var Commands = &cli.Command{
 		ListCommand,
 		EditCommand,
 		DeleteCommand,
+		ImportCommand,
+		ExportCommand,
 	},
 }
@@ -15,15 +15,81 @@
 package journal
 
 import (
+	"fmt"
+
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/log"
 	"github.com/urfave/cli/v2"
 )
 
 var CleanCommand = &cli.Command{
-	Name:    "clean",
-	Aliases: []string{"c", "cln"},
-	Usage:   "Clean up journal data in case it has been corrupted or stale",
-	Action: func(_ *cli.Context) error {
-		// TODO: implement
+	Name:      "clean",
+	Aliases:   []string{"c", "cln"},
+	Usage:     "Clean up journal data in case it has been corrupted or stale",
+	UsageText: "frens journal clean [--dry-run]",
+	Description: `Repair the journal consistency:
+  - fix duplicated IDs
+  - drop references to deleted friends and locations from activities and notes
+  - deduplicate journal tags
+  - recount activities, notes and the most recent activity of friends and locations
+
+Examples:
+  frens journal clean             # repair the journal
+  frens journal clean --dry-run   # only show what would be repaired
+`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Show the changes without saving them",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+		dryRun := c.Bool("dry-run")
+
+		var report journal.RepairReport
+
+		if dryRun {
+			j, err := appCtx.Store.Load(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to load journal: %w", err)
+			}
+
+			report = j.Repair()
+		} else {
+			err := appCtx.Store.Tx(ctx, func(j *journal.Journal) error {
+				report = j.Repair()
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if report.Empty() {
+			log.Success("The journal is consistent, nothing to clean up")
+			return nil
+		}
+
+		for _, change := range report.Changes {
+			style := log.WarnStyle
+
+			if change.Kind == journal.ChangeRemoved {
+				style = log.ErrorStyle
+			}
+
+			log.Info(style.Render(change.String()) + "\n")
+		}
+
+		if dryRun {
+			log.Infof("\n%d change(s) would be applied. Run without --dry-run to apply them.\n", len(report.Changes))
+			return nil
+		}
+
+		log.Successf("Journal cleaned up (%d changes applied)", len(report.Changes))
 
 		return nil
 	},
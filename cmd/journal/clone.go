@@ -19,7 +19,6 @@ import (
 
 	jctx "github.com/roma-glushko/frens/internal/context"
 	"github.com/roma-glushko/frens/internal/log"
-	"github.com/roma-glushko/frens/internal/sync"
 	"github.com/roma-glushko/frens/internal/tui"
 	"github.com/urfave/cli/v2"
 )
@@ -37,11 +36,7 @@ var CloneCommand = &cli.Command{
 
 		repoURL := c.Args().First()
 
-		git := sync.NewGit(jDir)
-
-		if err := git.Installed(); err != nil {
-			return err
-		}
+		git := NewRepository(jDir)
 
 		if err := git.Inited(); err == nil {
 			// TODO: check if interactive mode is enabled
@@ -64,6 +59,14 @@ var CloneCommand = &cli.Command{
 			return err
 		}
 
+		if err := registerMergeDriver(ctx, git); err != nil {
+			log.Warnf("Failed to register the journal merge driver: %v", err)
+		}
+
+		if err := ignoreLocalFiles(jDir); err != nil {
+			return err
+		}
+
 		log.Successf("Journal cloned to %s", jDir)
 
 		return nil
@@ -18,6 +18,8 @@ import (
 	"fmt"
 
 	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/store/file"
 	"github.com/roma-glushko/frens/internal/sync"
 	"github.com/urfave/cli/v2"
 )
@@ -33,11 +35,7 @@ var ConnectCommand = &cli.Command{
 		jCtx := jctx.FromCtx(ctx)
 		jDir := jCtx.JournalDir
 
-		git := sync.NewGit(jDir)
-
-		if err := git.Installed(); err != nil {
-			return fmt.Errorf("git is not installed or not found in PATH: %w", err)
-		}
+		git := NewRepository(jDir)
 
 		repoURL := c.Args().First()
 
@@ -51,6 +49,19 @@ var ConnectCommand = &cli.Command{
 
 		fmt.Println("Connecting to git repository", repoURL)
 
-		return git.AddRemote(ctx, "origin", repoURL)
+		if err := git.AddRemote(ctx, "origin", repoURL); err != nil {
+			return err
+		}
+
+		if err := registerMergeDriver(ctx, git); err != nil {
+			log.Warnf("Failed to register the journal merge driver: %v", err)
+		}
+
+		return ignoreLocalFiles(jDir)
 	},
 }
+
+// ignoreLocalFiles keeps files of the journal that only make sense on this device (e.g. locks) out of commits
+func ignoreLocalFiles(dir string) error {
+	return sync.IgnoreFiles(dir, []string{file.FileNameLock, "*.tmp"})
+}
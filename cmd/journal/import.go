@@ -0,0 +1,111 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"fmt"
+	"os"
+
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/friendsmd"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/urfave/cli/v2"
+)
+
+const importFromFriendsMD = "friends-md"
+
+var ImportCommand = &cli.Command{
+	Name:      "import",
+	Usage:     "Import records kept by another app",
+	UsageText: "frens journal import --from friends-md <FILE>",
+	Description: `Import friends, locations, activities and notes from another app into the journal.
+
+Supported formats:
+  friends-md   friends.md of JacobEvelyn/friends
+
+Records that are already in the journal are skipped, so the same file can be imported again.
+Lines that couldn't be imported and mentions that couldn't be resolved are listed at the end.
+
+Examples:
+  frens journal import --from friends-md ~/friends.md
+`,
+	Args: true,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "from",
+			Usage:    "Format of the imported file (friends-md)",
+			Required: true,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if from := c.String("from"); from != importFromFriendsMD {
+			return fmt.Errorf("unsupported import format: %s (supported: %s)", from, importFromFriendsMD)
+		}
+
+		if c.NArg() != 1 {
+			return cli.Exit("You must provide exactly one file to import", 1)
+		}
+
+		path := c.Args().First()
+
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer f.Close()
+
+		doc, err := friendsmd.Parse(f)
+		if err != nil {
+			return err
+		}
+
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+
+		var report friendsmd.Report
+
+		err = appCtx.Store.Tx(ctx, func(j *journal.Journal) error {
+			report = friendsmd.Import(j, doc)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		log.Successf(
+			"Imported friends: %d, locations: %d, activities: %d, notes: %d",
+			report.Friends,
+			report.Locations,
+			report.Activities,
+			report.Notes,
+		)
+
+		if report.Skipped > 0 {
+			log.Infof("Skipped %d record(s) already in the journal", report.Skipped)
+		}
+
+		if len(report.Issues) > 0 {
+			log.Warnf("Couldn't map %d item(s):", len(report.Issues))
+
+			for _, issue := range report.Issues {
+				fmt.Println("  • " + issue.String())
+			}
+		}
+
+		return nil
+	},
+}
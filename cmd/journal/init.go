@@ -17,8 +17,10 @@ package journal
 import (
 	"fmt"
 
+	"github.com/roma-glushko/frens/internal/config"
 	jctx "github.com/roma-glushko/frens/internal/context"
 	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/store/backend"
 	"github.com/roma-glushko/frens/internal/tui"
 	"github.com/urfave/cli/v2"
 )
@@ -35,8 +37,14 @@ Use the global --journal flag to initialize in a custom location.
 Examples:
   frens journal init                         # init in default location
   frens --journal ~/my-frens journal init    # init in custom location
+  frens journal init --store sqlite          # keep the journal in a SQLite database
 `,
-	Flags: []cli.Flag{},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "store",
+			Usage: "Storage to keep the journal in (toml, sqlite)",
+		},
+	},
 	Action: func(c *cli.Context) error {
 		ctx := c.Context
 		jCtx := jctx.FromCtx(ctx)
@@ -44,6 +52,21 @@ Examples:
 		jDir := jCtx.JournalDir
 		s := jCtx.Store
 
+		storeType := c.String("store")
+
+		if storeType != "" {
+			if err := config.ValidateStoreType(storeType); err != nil {
+				return err
+			}
+
+			var err error
+
+			s, err = backend.New(jDir, config.Config{Store: config.StoreConfig{Type: storeType}})
+			if err != nil {
+				return err
+			}
+		}
+
 		if s.Exist(ctx) {
 			// TODO: check if interactive mode is enabled
 			log.Infof("A journal already exists at %s\n", jDir)
@@ -59,6 +82,19 @@ Examples:
 			return fmt.Errorf("failed to initialize the journal at %s: %w", jDir, err)
 		}
 
+		if storeType != "" {
+			cfg, err := config.Load(jDir)
+			if err != nil {
+				return err
+			}
+
+			cfg.Store.Type = storeType
+
+			if err := config.Save(jDir, cfg); err != nil {
+				return err
+			}
+		}
+
 		log.Successf("Journal initialized at %s", jDir)
 
 		return nil
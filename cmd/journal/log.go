@@ -0,0 +1,57 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"slices"
+
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/store"
+	"github.com/urfave/cli/v2"
+)
+
+var LogCommand = &cli.Command{
+	Name:  "log",
+	Usage: "Show recent changes of the journal that can be undone",
+	Description: `Show changes made to the journal on this device, newest first.
+Undone changes are marked, they can be applied again by "frens redo".
+
+Examples:
+  frens journal log             # show changes that can be undone
+  frens -o json journal log     # show them as JSON
+`,
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+
+		u, ok := appCtx.Store.(store.Undoer)
+		if !ok {
+			return store.ErrUndoUnsupported
+		}
+
+		ops, err := u.Operations(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(ops) == 0 {
+			return cli.Exit("No changes to undo.", 1)
+		}
+
+		slices.Reverse(ops)
+
+		return appCtx.Printer.PrintList(ops)
+	},
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/roma-glushko/frens/internal/sync"
+	"github.com/urfave/cli/v2"
+)
+
+var MergeDriverCommand = &cli.Command{
+	Name:      "merge-driver",
+	Usage:     "Merge journal files changed on different devices (called by git)",
+	UsageText: "frens journal merge-driver <BASE> <OURS> <THEIRS> <PATH>",
+	Args:      true,
+	ArgsUsage: "<BASE> <OURS> <THEIRS> <PATH>",
+	Description: `Merge two versions of a journal file by entities instead of lines.
+Git calls it when pulling changes of friends.toml or activities.toml, once "frens journal connect" or
+"frens journal clone" have registered it.
+
+Friends, locations and events are matched by IDs. Entities added on any side are kept,
+edited entities are merged field by field. Fields changed on both sides in different ways keep our value
+and are reported as conflicts, so git stops for you to review them.
+`,
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 4 {
+			return cli.Exit("Expected the base, ours, theirs and path arguments passed by git", 1)
+		}
+
+		args := c.Args().Slice()
+
+		conflicts, err := file.MergeFile(c.Context, args[3], args[0], args[1], args[2])
+		if err != nil {
+			return err
+		}
+
+		if len(conflicts) == 0 {
+			return nil
+		}
+
+		log.Warnf("%d conflict(s) in %s kept our changes:", len(conflicts), args[3])
+
+		for _, conflict := range conflicts {
+			fmt.Fprintln(os.Stderr, "  • "+conflict.String())
+		}
+
+		return cli.Exit("Review the conflicts in "+args[3]+", then mark it as resolved with git add", 1)
+	},
+}
+
+// NewRepository opens the journal repository.
+// Without the git binary, journal files are merged in-process, as git config can't run the merge driver there.
+func NewRepository(dir string, opts ...sync.Option) sync.Repository {
+	return sync.New(dir, append(opts, sync.WithMergeDriver(sync.MergeDriver, mergeJournalFile))...)
+}
+
+func mergeJournalFile(ctx context.Context, path, basePath, oursPath, theirsPath string) ([]string, error) {
+	conflicts, err := file.MergeFile(ctx, path, basePath, oursPath, theirsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	descs := make([]string, 0, len(conflicts))
+
+	for _, conflict := range conflicts {
+		descs = append(descs, conflict.String())
+	}
+
+	return descs, nil
+}
+
+// registerMergeDriver lets git merge journal files with this binary
+func registerMergeDriver(ctx context.Context, git sync.Repository) error {
+	exe, err := exec.LookPath("frens")
+	if err != nil {
+		if exe, err = os.Executable(); err != nil {
+			return fmt.Errorf("failed to find the frens binary: %w", err)
+		}
+	}
+
+	// git runs drivers with the shell at the root of the repository, which is the journal directory
+	command := shellQuote(exe) + " -j . journal merge-driver %O %A %B %P"
+
+	return git.RegisterMergeDriver(ctx, command, []string{file.FileNameFriends, file.FileNameActivities})
+}
+
+func shellQuote(s string) string {
+	if !strings.ContainsAny(s, " '\"\\$`") {
+		return s
+	}
+
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/roma-glushko/frens/internal/config"
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/store/backend"
+	"github.com/roma-glushko/frens/internal/tui"
+	"github.com/urfave/cli/v2"
+)
+
+// ErrMigrationMismatch means the target storage doesn't keep the journal the way the source does
+var ErrMigrationMismatch = errors.New("migrated journal doesn't match the original one")
+
+// mismatchesShown is how many mismatched entities are named in the error
+const mismatchesShown = 5
+
+var MigrateCommand = &cli.Command{
+	Name:      "migrate",
+	Usage:     "Move the journal to another storage",
+	UsageText: "frens journal migrate --to sqlite|toml",
+	Description: `Copy the journal into another storage and switch the journal to it.
+The previous storage files are kept untouched, so you can switch back anytime.
+
+Examples:
+  frens journal migrate --to sqlite   # keep the journal in a SQLite database
+  frens journal migrate --to toml     # keep the journal in TOML files
+`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "to",
+			Usage:    "Storage to migrate the journal to (toml, sqlite)",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:    "force",
+			Aliases: []string{"f"},
+			Usage:   "Overwrite existing data in the target storage without confirmation",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+		jDir := appCtx.JournalDir
+
+		to := c.String("to")
+
+		if err := config.ValidateStoreType(to); err != nil {
+			return err
+		}
+
+		cfg, err := config.Load(jDir)
+		if err != nil {
+			return err
+		}
+
+		from := cfg.StoreType()
+
+		if from == to {
+			log.Infof("The journal is already kept in %s storage", to)
+			return nil
+		}
+
+		src := appCtx.Store
+
+		dstCfg := cfg
+		dstCfg.Store.Type = to
+
+		dst, err := backend.New(jDir, dstCfg)
+		if err != nil {
+			return err
+		}
+
+		if dst.Exist(ctx) && !c.Bool("force") {
+			// TODO: check if interactive mode is enabled
+			if !tui.ConfirmAction(log.WarnPrompt(
+				fmt.Sprintf("The journal already has %s data. Do you want to overwrite it?", to),
+			)) {
+				log.Canceled("Migration canceled.")
+				return nil
+			}
+		}
+
+		// the source is held for the whole migration, so no change made meanwhile is left behind
+		err = src.Tx(ctx, func(j *journal.Journal) error {
+			if err := dst.Init(ctx); err != nil {
+				return fmt.Errorf("failed to init %s storage: %w", to, err)
+			}
+
+			if err := dst.Save(ctx, j); err != nil {
+				return fmt.Errorf("failed to save the journal to %s storage: %w", to, err)
+			}
+
+			migrated, err := dst.Load(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to verify the migrated journal: %w", err)
+			}
+
+			if diff := j.Snapshot().Changes(migrated.Snapshot()); len(diff) > 0 {
+				return fmt.Errorf("%w: %s", ErrMigrationMismatch, describeChanges(diff))
+			}
+
+			cfg.Store.Type = to
+
+			return config.Save(jDir, cfg)
+		})
+		if err != nil {
+			return err
+		}
+
+		log.Successf("Journal migrated from %s to %s storage", from, to)
+		log.Infof("The %s data is kept in %s, feel free to remove it once you are happy with the migration", from, jDir)
+
+		return nil
+	},
+}
+
+func describeChanges(changes []journal.EntityChange) string {
+	descs := make([]string, 0, mismatchesShown)
+
+	for _, c := range changes[:min(len(changes), mismatchesShown)] {
+		descs = append(descs, c.String())
+	}
+
+	if more := len(changes) - mismatchesShown; more > 0 {
+		descs = append(descs, fmt.Sprintf("and %d more", more))
+	}
+
+	return strings.Join(descs, ", ")
+}
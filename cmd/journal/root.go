@@ -30,5 +30,9 @@ var Commands = &cli.Command{
 		StatsCommand,
 		CleanCommand,
 		SyncCommand,
+		LogCommand,
+		MigrateCommand,
+		ImportCommand,
+		MergeDriverCommand,
 	},
 }
@@ -16,10 +16,13 @@ package journal
 
 import (
 	"fmt"
+	"io"
 	"os"
 
+	"github.com/roma-glushko/frens/internal/config"
 	jctx "github.com/roma-glushko/frens/internal/context"
 	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/store"
 	"github.com/roma-glushko/frens/internal/sync"
 	"github.com/roma-glushko/frens/internal/tui"
 	"github.com/urfave/cli/v2"
@@ -29,70 +32,107 @@ var SyncCommand = &cli.Command{
 	Name:      "sync",
 	Aliases:   []string{"s"},
 	Usage:     "Synchronize your journal with a remote git repository",
-	UsageText: "frens journal sync",
-	Description: `Pull latest changes, commit local changes, and push to remote.
+	UsageText: "frens journal sync [--auto[=false]]",
+	Description: `Commit local changes, pull latest changes, and push to remote.
 
-Requires git to be installed and a remote repository configured.
-Use 'frens journal connect' to set up the remote repository first.
+Requires a remote repository configured, use 'frens journal connect' to set it up first.
+The git binary is used when installed, otherwise the built-in git implementation merges pulled changes.
+
+Auto-sync commits every change of the journal describing it (e.g. "activity added: Dinner with Jim").
+Remote changes are pulled before the journal is read. Commands push their changes once done,
+while "frens serve" and "frens telegram bot" push them in the background once no changes come for a while.
+The setting is kept in the journal, so it's synchronized to other devices too.
 
 Examples:
   frens journal sync                         # sync with remote
+  frens journal sync --auto                  # turn auto-sync on and sync with remote
+  frens journal sync --auto=false            # turn auto-sync off and sync with remote
   frens --journal ~/my-frens journal sync    # sync a specific journal
 `,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "auto",
+			Usage: "Turn auto-sync on (or off with --auto=false) before syncing",
+		},
+	},
 	Action: func(c *cli.Context) error {
 		ctx := c.Context
 		jCtx := jctx.FromCtx(ctx)
 		jDir := jCtx.JournalDir
 
-		git := sync.NewGit(jDir)
+		git := NewRepository(jDir)
 
-		if err := git.Installed(); err != nil {
-			return fmt.Errorf("git is not installed or not found in PATH: %w", err)
+		if c.IsSet("auto") {
+			if err := setAutoSync(jDir, c.Bool("auto")); err != nil {
+				return err
+			}
 		}
 
 		if err := git.Inited(); err != nil {
 			return err
 		}
 
+		if err := ignoreLocalFiles(jDir); err != nil {
+			return err
+		}
+
 		origin := "origin"
 		branch, err := git.GetBranchName(ctx)
+		// nothing has been committed yet, so there is nothing to pull either
+		unborn := err != nil
 
-		if err == nil {
-			if err := tui.RunWithProgress("Pulling latest changes from remote...", func() error {
-				return git.Pull(ctx, origin, branch)
-			}); err != nil {
-				return fmt.Errorf("git pull failed: %w", err)
-			}
-		} else {
+		if unborn {
 			log.Warn("Failed to get current branch name, assuming no branch is set yet\n")
-		}
 
-		if branch == "" {
-			branch = "main"
+			branch = sync.DefaultBranch
 		}
 
-		status, err := git.GetStatus(ctx)
+		changed := false
+
+		// commits and merges hold the journal, so they don't pick up or overwrite half-written changes
+		err = store.Locked(ctx, jCtx.Store, func() error {
+			status, err := git.GetStatus(ctx)
+			if err != nil {
+				return err
+			}
+
+			changed = len(status) > 0
+
+			// local changes are committed before pulling, as the built-in git can merge committed changes only
+			if changed {
+				hostname, _ := os.Hostname()
+				commit := "🔄 sync: synchronize journal @ " + hostname
+
+				if err := tui.RunWithProgress("Committing changes...", func() error {
+					if err := git.Commit(ctx, commit); err != nil {
+						return err
+					}
+					return git.Branch(ctx, branch)
+				}); err != nil {
+					return err
+				}
+			}
+
+			if !unborn {
+				if err := tui.RunWithProgress("Pulling latest changes from remote...", func() error {
+					return git.Pull(ctx, origin, branch)
+				}); err != nil {
+					return fmt.Errorf("git pull failed: %w", err)
+				}
+			}
+
+			return nil
+		})
 		if err != nil {
 			return err
 		}
 
-		if len(status) == 0 {
+		// auto-sync commits changes on its own, so they may be waiting for a push anyway
+		if !changed && unborn {
 			log.Info("No changes to commit.\n")
 			return nil
 		}
 
-		hostname, _ := os.Hostname()
-		commit := "🔄 sync: synchronize journal @ " + hostname
-
-		if err := tui.RunWithProgress("Committing changes...", func() error {
-			if err := git.Commit(ctx, commit); err != nil {
-				return err
-			}
-			return git.Branch(ctx, branch)
-		}); err != nil {
-			return err
-		}
-
 		if err := tui.RunWithProgress("Pushing changes to remote...", func() error {
 			return git.Push(ctx, origin, branch)
 		}); err != nil {
@@ -104,3 +144,50 @@ Examples:
 		return nil
 	},
 }
+
+func setAutoSync(jDir string, auto bool) error {
+	cfg, err := config.Load(jDir)
+	if err != nil {
+		return err
+	}
+
+	cfg.Sync.Auto = auto
+
+	if err := config.Save(jDir, cfg); err != nil {
+		return err
+	}
+
+	if auto {
+		log.Success("Auto-sync is turned on")
+	} else {
+		log.Success("Auto-sync is turned off")
+	}
+
+	return nil
+}
+
+// NewAutoSync commits changes of the journal store made by commands.
+// Git output would get in the way of command output, so it's shown in the debug mode only.
+func NewAutoSync(jDir string, s store.Store, cfg config.SyncConfig, debug bool) (*sync.AutoSync, error) {
+	pushDelay, err := cfg.PushDelayDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	var output io.Writer = io.Discard
+
+	if debug {
+		output = os.Stderr
+	}
+
+	repo := NewRepository(jDir, sync.WithOutput(output))
+
+	// commits are made holding the journal lock, so journals connected before it was ignored get it ignored now
+	if repo.Inited() == nil {
+		if err := ignoreLocalFiles(jDir); err != nil {
+			log.Warnf("Failed to keep local files out of journal commits: %v", err)
+		}
+	}
+
+	return sync.NewAutoSync(s, repo, pushDelay), nil
+}
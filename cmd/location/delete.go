@@ -15,6 +15,8 @@
 package location
 
 import (
+	"fmt"
+
 	jctx "github.com/roma-glushko/frens/internal/context"
 	"github.com/roma-glushko/frens/internal/friend"
 	"github.com/roma-glushko/frens/internal/journal"
@@ -34,6 +36,7 @@ var DeleteCommand = &cli.Command{
 	Examples:
 		frens friend delete "Nashua"
 		frens friend d -f "Utica"
+		frens location delete --cascade "Utica"   # also delete activities and notes only about Utica
 	`,
 	Flags: []cli.Flag{
 		&cli.BoolFlag{
@@ -42,6 +45,14 @@ var DeleteCommand = &cli.Command{
 			Value:   false,
 			Usage:   "Force delete without confirmation",
 		},
+		&cli.BoolFlag{
+			Name:  "cascade",
+			Usage: "Also delete activities and notes left without any friend or location once the locations are deleted",
+		},
+		&cli.BoolFlag{
+			Name:  "detach",
+			Usage: "Keep activities and notes, only remove the deleted locations from them (default)",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		ctx := c.Context
@@ -52,6 +63,11 @@ var DeleteCommand = &cli.Command{
 
 		locations := make([]friend.Location, 0, c.NArg())
 
+		mode, err := journal.NewRemoveMode(c.Bool("cascade"), c.Bool("detach"))
+		if err != nil {
+			return err
+		}
+
 		appCtx := jctx.FromCtx(ctx)
 
 		return appCtx.Store.Tx(ctx, func(j *journal.Journal) error {
@@ -71,9 +87,30 @@ var DeleteCommand = &cli.Command{
 				log.Bullet(l.String())
 			}
 
+			refs := j.LocationRefs(locations)
+
+			if refs.Total() > 0 {
+				log.Infof(
+					"\nAffected events: %d %s and %d %s.\n",
+					refs.Activities,
+					utils.P(refs.Activities, "activity", "activities"),
+					refs.Notes,
+					utils.P(refs.Notes, "note", "notes"),
+				)
+
+				if mode == journal.RemoveModeCascade {
+					log.Infof(
+						"%d of them will be deleted, as they involve no other friend or location. The rest will be detached.\n",
+						refs.Exclusive,
+					)
+				} else {
+					log.Info("They will be kept, but detached from the " + locWord + ".\n")
+				}
+			}
+
 			// TODO: check if interactive mode
 			log.Info(
-				"\n" + log.WarnPrompt("You're about to permanently delete the "+locWord+".") + "\n",
+				"\n" + log.WarnPrompt("You're about to permanently delete the "+locWord+deletedEvents(mode, refs)+".") + "\n",
 			)
 
 			if !c.Bool("force") && !tui.ConfirmAction(log.WarnPrompt("Are you sure?")) {
@@ -81,7 +118,7 @@ var DeleteCommand = &cli.Command{
 				return nil
 			}
 
-			j.RemoveLocations(locations)
+			j.RemoveLocations(locations, mode)
 
 			log.Deleted(utils.TitleCaser.String(locWord))
 
@@ -89,3 +126,12 @@ var DeleteCommand = &cli.Command{
 		})
 	},
 }
+
+// deletedEvents names the events cascade removal deletes along with the locations
+func deletedEvents(mode journal.RemoveMode, refs journal.EventRefs) string {
+	if mode != journal.RemoveModeCascade || refs.Exclusive == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(" and %d %s", refs.Exclusive, utils.P(refs.Exclusive, "event", "events"))
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package location
+
+import (
+	"github.com/roma-glushko/frens/cmd/csvcmd"
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/csvio"
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/urfave/cli/v2"
+)
+
+var ExportCommand = &cli.Command{
+	Name:      "export",
+	Usage:     "Export locations to a CSV table",
+	UsageText: "frens location export --csv [OPTIONS]",
+	Description: `Export locations to a CSV table to review or edit them in a spreadsheet.
+Edited tables can be imported back with "frens location import --csv".
+
+Examples:
+  frens location export --csv > locations.csv
+  frens location export --csv -o locations.csv -t family
+`,
+	Flags: []cli.Flag{
+		csvcmd.FormatFlag(),
+		csvcmd.OutputFlag(),
+		&cli.StringSliceFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "Filter by tag(s)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if !c.Bool("csv") {
+			return cli.Exit("Choose the export format: --csv", 1)
+		}
+
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+
+		var locations []friend.Location
+
+		err := appCtx.Store.Tx(ctx, func(jr *journal.Journal) error {
+			locations = jr.ListLocations(friend.ListLocationQuery{
+				Tags:      c.StringSlice("tag"),
+				SortBy:    friend.SortAlpha,
+				SortOrder: friend.SortOrderDirect,
+			})
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return csvcmd.Export(c, csvio.Locations, locations)
+	},
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package location
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/tui"
+	"github.com/roma-glushko/frens/internal/utils"
+)
+
+// ConfirmUnknown offers to create locations for markers that don't refer to any known location.
+// Declined markers are not recorded in the event.
+func ConfirmUnknown(j *journal.Journal, markers []string) {
+	for _, marker := range j.UnknownLocations(markers) {
+		// TODO: check if interactive mode is enabled
+		if !tui.ConfirmAction(log.WarnPrompt(
+			fmt.Sprintf("Location '@%s' is not in your journal yet. Do you want to add it?", marker),
+		)) {
+			log.Warnf("Unknown location '@%s' is skipped\n", marker)
+			continue
+		}
+
+		j.AddLocation(friend.Location{
+			ID:   marker,
+			Name: utils.TitleCaser.String(strings.NewReplacer("_", " ", "-", " ").Replace(marker)),
+		})
+
+		log.Successf("Location '@%s' added", marker)
+	}
+}
@@ -30,5 +30,7 @@ var Commands = &cli.Command{
 		EditCommand,
 		ListCommand,
 		DeleteCommand,
+		ImportCommand,
+		ExportCommand,
 	},
 }
@@ -22,6 +22,7 @@ import (
 	jctx "github.com/roma-glushko/frens/internal/context"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/roma-glushko/frens/cmd/location"
 	"github.com/roma-glushko/frens/internal/friend"
 	"github.com/roma-glushko/frens/internal/journal"
 	"github.com/roma-glushko/frens/internal/lang"
@@ -97,6 +98,8 @@ var AddCommand = &cli.Command{
 		appCtx := jctx.FromCtx(ctx)
 
 		return appCtx.Store.Tx(ctx, func(j *journal.Journal) error {
+			location.ConfirmUnknown(j, e.LocationIDs)
+
 			e, err = j.AddEvent(e)
 			if err != nil {
 				return fmt.Errorf("failed to add note: %w", err)
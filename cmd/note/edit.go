@@ -20,6 +20,7 @@ import (
 
 	jctx "github.com/roma-glushko/frens/internal/context"
 
+	"github.com/roma-glushko/frens/cmd/location"
 	"github.com/roma-glushko/frens/internal/friend"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -93,6 +94,8 @@ var EditCommand = &cli.Command{
 				return err
 			}
 
+			location.ConfirmUnknown(j, actNew.LocationIDs)
+
 			actNew, err = j.UpdateEvent(actOld, actNew)
 			if err != nil {
 				return fmt.Errorf("failed to update note: %v", err)
@@ -42,6 +42,7 @@ Examples:
   frens note list                            # list all notes
   frens note ls -q "allergic"                # search by keyword
   frens note ls -t health -t travel          # filter by tags
+  frens note ls --with "Pam"                 # notes about Pam
   frens note ls --from 2024/01/01            # notes since a date
   frens note ls --since "last week"          # notes from last week
   frens note ls -s alpha                     # sort alphabetically
@@ -57,6 +58,16 @@ Examples:
 			Aliases: []string{"t"},
 			Usage:   "Filter by tag(s)",
 		},
+		&cli.StringSliceFlag{
+			Name:    "with",
+			Aliases: []string{"w"},
+			Usage:   "Filter by friend(s) involved (name, nickname or ID)",
+		},
+		&cli.StringSliceFlag{
+			Name:    "at",
+			Aliases: []string{"l"},
+			Usage:   "Filter by location(s) (name, alias or ID)",
+		},
 		&cli.StringFlag{
 			Name:    "from",
 			Aliases: []string{"since"},
@@ -98,6 +109,8 @@ Examples:
 			notes, err := j.ListEvents(friend.ListEventQuery{
 				Type:      friend.EventTypeNote,
 				Keyword:   strings.TrimSpace(c.String("search")),
+				Friends:   c.StringSlice("with"),
+				Locations: c.StringSlice("at"),
 				Tags:      c.StringSlice("tag"),
 				Since:     lang.ExtractDate(c.String("from")),
 				Until:     lang.ExtractDate(c.String("to")),
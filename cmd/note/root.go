@@ -27,5 +27,7 @@ var Commands = &cli.Command{
 		EditCommand,
 		ListCommand,
 		DeleteCommand,
+		ImportCommand,
+		ExportCommand,
 	},
 }
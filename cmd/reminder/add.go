@@ -0,0 +1,173 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reminder
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/lang"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/reminder"
+	"github.com/roma-glushko/frens/internal/tui"
+	"github.com/urfave/cli/v2"
+)
+
+var AddCommand = &cli.Command{
+	Name:      "add",
+	Aliases:   []string{"a", "new", "create"},
+	Usage:     "Add a new reminder",
+	UsageText: "frens reminder add [OPTIONS] [INFO]",
+	Description: `Add a reminder to a friend date or a standalone reminder with its own date.
+	Examples:
+		frens reminder add --date 2zpWoEiUYn6vrSl9w03NAVkWxMn "buy a gift \$before:1w"
+		frens reminder add "2025-12-01 :: renew passport \$before:30d"
+		frens reminder add "1st :: pay the rent \$every:monthly"
+	`,
+	Args: true,
+	ArgsUsage: `<INFO>
+		If no arguments are provided, a textarea will be shown to fill in the details interactively.
+		Otherwise, the information will be parsed from the command options.
+
+		<INFO> format:
+			` + lang.FormatReminderInfo + `
+
+		For example:
+			"buy a gift #gifts $before:1w"
+			"2025-12-01 :: renew passport $before:30d"
+	`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "date",
+			Aliases: []string{"d"},
+			Usage:   "ID of the friend date to attach the reminder to",
+		},
+		&cli.StringFlag{
+			Name:    "every",
+			Aliases: []string{"e"},
+			Usage:   "How often the reminder should fire (once, monthly, yearly)",
+		},
+		&cli.StringFlag{
+			Name:  "before",
+			Usage: "Fire the reminder ahead of the date (e.g., '3d', '1w')",
+		},
+		&cli.StringFlag{
+			Name:  "after",
+			Usage: "Fire the reminder after the date (e.g., '1d')",
+		},
+		&cli.StringSliceFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "Add tags to the reminder",
+		},
+	},
+	Action: func(c *cli.Context) error { //nolint:cyclop
+		var info string
+
+		if c.NArg() == 0 && c.String("date") == "" {
+			// TODO: also check if we are in the interactive mode
+			inputForm := tui.NewEditorForm(tui.EditorOptions{
+				Title:      "Add a new reminder:",
+				SyntaxHint: lang.FormatReminderInfo,
+			})
+			teaUI := tea.NewProgram(inputForm, tea.WithMouseAllMotion())
+
+			if _, err := teaUI.Run(); err != nil {
+				log.Errorf("uh oh: %v", err)
+				return err
+			}
+
+			info = inputForm.Textarea.Value()
+		} else {
+			info = strings.Join(c.Args().Slice(), " ")
+		}
+
+		var (
+			r   friend.Reminder
+			err error
+		)
+
+		if info != "" {
+			r, err = lang.ExtractReminder(info)
+
+			if err != nil && !errors.Is(err, lang.ErrNoInfo) {
+				log.Errorf("failed to parse reminder info: %v", err)
+				return err
+			}
+		}
+
+		r.DateID = c.String("date")
+
+		if every := c.String("every"); every != "" {
+			if err := friend.ValidateRecurrence(every); err != nil {
+				return err
+			}
+
+			r.Recurrence = strings.ToLower(every)
+		}
+
+		if before, after := c.String("before"), c.String("after"); before != "" || after != "" {
+			if before != "" && after != "" {
+				return cli.Exit("A reminder can fire either before or after the date, not both.", 1)
+			}
+
+			r.OffsetDirection = friend.OffsetDirectionBefore
+			offsetExpr := before
+
+			if after != "" {
+				r.OffsetDirection = friend.OffsetDirectionAfter
+				offsetExpr = after
+			}
+
+			offset, err := lang.ExtractDuration(offsetExpr)
+			if err != nil {
+				return err
+			}
+
+			r.Offset = &offset
+		}
+
+		if tags := c.StringSlice("tag"); len(tags) > 0 {
+			r.Tags = tags
+		}
+
+		if err := r.Validate(); err != nil {
+			return err
+		}
+
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+
+		return appCtx.Store.Tx(ctx, func(j *journal.Journal) error {
+			r, err = j.AddReminder(r)
+			if err != nil {
+				return err
+			}
+
+			if nextAt, err := reminder.Next(r, time.Now()); err == nil {
+				r.NextAt = nextAt
+			}
+
+			log.Success("Reminder added")
+
+			return appCtx.Printer.Print(r)
+		})
+	},
+}
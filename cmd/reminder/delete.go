@@ -0,0 +1,91 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reminder
+
+import (
+	"fmt"
+
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/tui"
+	"github.com/roma-glushko/frens/internal/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var DeleteCommand = &cli.Command{
+	Name:      "delete",
+	Aliases:   []string{"del", "rm", "d"},
+	Usage:     `Delete reminders`,
+	UsageText: `frens reminder delete [OPTIONS] <REMINDER_ID> [, <REMINDER_ID>...]`,
+	Description: `Delete reminders from your journal by reminder IDs.
+	Examples:
+		frens reminder delete 2zpWoEiUYn6vrSl9w03NAVkWxMn 2zpWoEiUYn6vrSl9w03NAVkWxMx
+		frens reminder d -f 2zpWoEiUYn6vrSl9w03NAVkWxMn
+	`,
+	Args:      true,
+	ArgsUsage: `<REMINDER_ID> [, <REMINDER_ID>...]`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "force",
+			Aliases: []string{"f"},
+			Value:   false,
+			Usage:   "Force delete without confirmation",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if len(c.Args().Slice()) == 0 {
+			return cli.Exit("Please provide a reminder ID to delete.", 1)
+		}
+
+		reminders := make([]friend.Reminder, 0, len(c.Args().Slice()))
+
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+
+		return appCtx.Store.Tx(ctx, func(j *journal.Journal) error {
+			for _, rID := range c.Args().Slice() {
+				r, err := j.GetReminder(rID)
+				if err != nil {
+					return err
+				}
+
+				reminders = append(reminders, r)
+			}
+
+			rWord := utils.P(len(reminders), "reminder", "reminders")
+			fmt.Printf("🔍 Found %d %s:\n", len(reminders), rWord)
+
+			for _, r := range reminders {
+				fmt.Printf("   • %s %s\n", r.ID, r.Label())
+			}
+
+			// TODO: check if interactive mode
+			fmt.Println("\n⚠️  You're about to permanently delete the " + rWord + ".")
+			if !c.Bool("force") && !tui.ConfirmAction("Are you sure?") {
+				fmt.Println("\n↩️  Deletion canceled.")
+				return nil
+			}
+
+			if err := j.RemoveReminders(reminders); err != nil {
+				return err
+			}
+
+			fmt.Printf("\n🗑️  %s deleted.\n", utils.TitleCaser.String(rWord))
+
+			return nil
+		})
+	},
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reminder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/markusmobius/go-dateparser"
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/reminder"
+	"github.com/urfave/cli/v2"
+)
+
+var DueCommand = &cli.Command{
+	Name:      "due",
+	Usage:     "Show reminders that fire today",
+	UsageText: "frens reminder due [OPTIONS]",
+	Description: `Show reminders that fire today (or on the given day).
+	Examples:
+		frens reminder due
+		frens reminder due --on tomorrow
+	`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "on",
+			Usage: "Show reminders that fire on the given day instead of today",
+		},
+		&cli.StringSliceFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "Filter by tag(s)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+
+		on := time.Now()
+
+		if onExpr := c.String("on"); onExpr != "" {
+			dt, err := dateparser.Parse(&dateparser.Configuration{CurrentTime: on}, onExpr)
+			if err != nil {
+				return fmt.Errorf("failed to parse date '%s': %w", onExpr, err)
+			}
+
+			on = dt.Time
+		}
+
+		return appCtx.Store.Tx(ctx, func(j *journal.Journal) error {
+			reminders, err := j.ListReminders(friend.ListReminderQuery{
+				Tags: c.StringSlice("tag"),
+			})
+			if err != nil {
+				return err
+			}
+
+			due, err := reminder.Due(reminders, on)
+			if err != nil {
+				log.Warnf("some reminders could not be scheduled: %v", err)
+			}
+
+			if len(due) == 0 {
+				log.Info(log.MutedStyle.Render("No reminders due.") + "\n")
+				return nil
+			}
+
+			return appCtx.Printer.PrintList(due)
+		})
+	},
+}
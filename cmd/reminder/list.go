@@ -0,0 +1,76 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reminder
+
+import (
+	"time"
+
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/reminder"
+	"github.com/urfave/cli/v2"
+)
+
+var ListCommand = &cli.Command{
+	Name:    "list",
+	Aliases: []string{"l", "ls"},
+	Usage:   "List upcoming reminders",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "search",
+			Aliases: []string{"q"},
+			Usage:   "Search by description",
+		},
+		&cli.StringSliceFlag{
+			Name:    "with",
+			Aliases: []string{"w"},
+			Usage:   "Filter by friend(s)",
+		},
+		&cli.StringSliceFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "Filter by tag(s)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		appCtx := jctx.FromCtx(ctx)
+
+		return appCtx.Store.Tx(ctx, func(j *journal.Journal) error {
+			reminders, err := j.ListReminders(friend.ListReminderQuery{
+				Keyword: c.String("search"),
+				Friends: c.StringSlice("with"),
+				Tags:    c.StringSlice("tag"),
+			})
+			if err != nil {
+				return err
+			}
+
+			reminders, err = reminder.Schedule(reminders, time.Now())
+			if err != nil {
+				log.Warnf("some reminders could not be scheduled: %v", err)
+			}
+
+			if len(reminders) == 0 {
+				log.Empty("reminders")
+				return nil
+			}
+
+			return appCtx.Printer.PrintList(reminders)
+		})
+	},
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reminder
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+var Commands = &cli.Command{
+	Name:        "reminder",
+	Aliases:     []string{"rem", "r"},
+	Usage:       "Manage reminders for friend dates and other things",
+	UsageText:   "frens reminder [command] [options]",
+	Description: `Reminders tell you ahead of time about birthdays, anniversaries, and anything else you don't want to miss.`,
+	Subcommands: []*cli.Command{
+		AddCommand,
+		ListCommand,
+		DueCommand,
+		DeleteCommand,
+	},
+}
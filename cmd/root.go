@@ -15,11 +15,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/roma-glushko/frens/internal/config"
-	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/roma-glushko/frens/internal/store/backend"
 
 	"github.com/roma-glushko/frens/cmd/telegram"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/roma-glushko/frens/cmd/journal"
 	"github.com/roma-glushko/frens/cmd/location"
 	"github.com/roma-glushko/frens/cmd/note"
+	"github.com/roma-glushko/frens/cmd/reminder"
 	jctx "github.com/roma-glushko/frens/internal/context"
 	"github.com/roma-glushko/frens/internal/log"
 	"github.com/roma-glushko/frens/internal/version"
@@ -131,23 +133,59 @@ func NewApp() cli.App {
 				density = log.DensityCompact
 			}
 
+			cfg, err := config.Load(jDir)
+			if err != nil {
+				return err
+			}
+
+			s, err := backend.New(jDir, cfg)
+			if err != nil {
+				return err
+			}
+
 			appCtx := jctx.AppContext{
 				JournalDir: jDir,
-				Store:      file.NewTOMLFileStore(jDir),
+				Store:      s,
 				Printer:    log.NewPrinterWithDensity(format, density, os.Stdout),
 			}
 
+			if cfg.Sync.Auto {
+				autoSync, err := journal.NewAutoSync(jDir, s, cfg.Sync, debugLevel)
+				if err != nil {
+					return err
+				}
+
+				appCtx.Store = autoSync
+				appCtx.AutoSync = autoSync
+			}
+
 			c.Context = jctx.WithCtx(ctx, &appCtx)
 
 			return nil
 		},
+		After: func(c *cli.Context) error {
+			appCtx := jctx.FromCtx(c.Context)
+			if appCtx == nil || appCtx.AutoSync == nil {
+				return nil
+			}
+
+			// commands may be stopped by signals, but their changes are still pushed
+			if err := appCtx.AutoSync.Flush(context.WithoutCancel(c.Context)); err != nil {
+				log.Warnf("Failed to push journal changes, run `frens journal sync` to push them: %v", err)
+			}
+
+			return nil
+		},
 		Commands: []*cli.Command{
 			journal.Commands,
 			friend.Commands,
 			location.Commands,
 			note.Commands,
 			activity.Commands,
+			reminder.Commands,
 			telegram.Commands,
+			UndoCommand,
+			RedoCommand,
 			ServeCommand,
 			ZenCommand,
 		},
@@ -23,6 +23,7 @@ import (
 	"syscall"
 
 	"github.com/charmbracelet/log"
+	"github.com/roma-glushko/frens/internal/carddav"
 	jctx "github.com/roma-glushko/frens/internal/context"
 	"github.com/roma-glushko/frens/internal/ui"
 	"github.com/urfave/cli/v2"
@@ -31,6 +32,27 @@ import (
 var ServeCommand = &cli.Command{
 	Name:  "serve",
 	Usage: "Start the web UI server",
+	Description: `Serve the journal in the web UI.
+
+Every request requires an access token. A new token is generated on each start
+and printed as a part of the UI URL, unless it's read from a file via --token-file.
+Scripts can pass the token in the "Authorization: Bearer <TOKEN>" header.
+
+Friend dates are served as an iCalendar feed calendar apps can subscribe to.
+Its URL carries a read-only key derived from the token, so it outlives restarts with --token-file.
+
+Friends are served as a CardDAV address book, so phones and desktop address books can sync them.
+Add a CardDAV account with the printed URL, any user name and the access token as the password.
+Edits made in address books are saved to the journal.
+
+With auto-sync on (see "frens journal sync --auto"), changes are pushed in the background
+once no more changes come for the push delay (the sync.push_delay setting, 30s by default).
+
+Examples:
+  frens serve --open                          # serve on the loopback address and open the browser
+  frens serve --token-file ~/.frens-token     # reuse the same token across restarts
+  frens serve --addr 0.0.0.0:8080             # serve to the local network (the token is still required)
+`,
 	Flags: []cli.Flag{
 		&cli.StringFlag{
 			Name:    "addr",
@@ -44,6 +66,19 @@ var ServeCommand = &cli.Command{
 			Value:   false,
 			Usage:   "Open the UI in the default browser",
 		},
+		&cli.StringFlag{
+			Name:      "token-file",
+			Usage:     "Read the access token from the file instead of generating a new one",
+			TakesFile: true,
+		},
+		&cli.BoolFlag{
+			Name:  "no-auth",
+			Usage: "Disable authentication (allowed on loopback addresses only, unless --insecure is set)",
+		},
+		&cli.BoolFlag{
+			Name:  "insecure",
+			Usage: "Allow serving without authentication on non-loopback addresses",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		addr := c.String("addr")
@@ -56,7 +91,36 @@ var ServeCommand = &cli.Command{
 		ctx := c.Context
 		appCtx := jctx.FromCtx(ctx)
 
-		server := ui.NewServer(addr, logger, appCtx.Store)
+		var opts []ui.Option
+
+		token := ""
+
+		if !c.Bool("no-auth") {
+			var err error
+
+			if tokenFile := c.String("token-file"); tokenFile != "" {
+				token, err = ui.ReadTokenFile(tokenFile)
+			} else {
+				token, err = ui.GenerateToken()
+			}
+
+			if err != nil {
+				return err
+			}
+
+			opts = append(opts, ui.WithToken(token))
+		}
+
+		if c.Bool("insecure") {
+			opts = append(opts, ui.WithInsecureBind())
+		}
+
+		if appCtx.AutoSync != nil {
+			appCtx.AutoSync.Pull(ctx)
+			appCtx.AutoSync.PushInBackground()
+		}
+
+		server := ui.NewServer(addr, logger, appCtx.Store, opts...)
 
 		actualAddr, err := server.Start(ctx)
 		if err != nil {
@@ -64,7 +128,18 @@ var ServeCommand = &cli.Command{
 		}
 
 		url := "http://" + actualAddr
+		calURL := url + ui.CalendarPath
+
+		if token != "" {
+			url += "/?" + ui.TokenParam + "=" + token
+			calURL += "?" + ui.FeedKeyParam + "=" + ui.FeedKey(token)
+		} else {
+			logger.Warn("Authentication is disabled, anyone who can reach the server can read and change the journal")
+		}
+
 		logger.Info("Frens UI is running", "url", url)
+		logger.Info("Calendar feed", "url", calURL)
+		logger.Info("CardDAV address book", "url", "http://"+actualAddr+carddav.Prefix)
 
 		if openBrowser {
 			if err := openURL(ctx, url); err != nil {
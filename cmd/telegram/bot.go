@@ -84,6 +84,11 @@ var BotCommand = &cli.Command{
 		appCtx := jctx.FromCtx(ctx)
 		s := appCtx.Store
 
+		if appCtx.AutoSync != nil {
+			appCtx.AutoSync.Pull(ctx)
+			appCtx.AutoSync.PushInBackground()
+		}
+
 		if len(userList) > 0 {
 			bot.Use(middleware.Whitelist(userList...))
 		} else {
@@ -393,6 +398,8 @@ var BotCommand = &cli.Command{
 			ctx := context.Background()
 
 			return s.Tx(ctx, func(j *journal.Journal) error {
+				unknownLocs := j.UnknownLocations(e.LocationIDs)
+
 				e, err = j.AddEvent(e)
 				if err != nil {
 					return c.Send(fmt.Sprintf("failed to add note: %v", err))
@@ -409,6 +416,10 @@ var BotCommand = &cli.Command{
 					sb.WriteString("\n🏷️ Tags: " + strings.Join(e.Tags, ", "))
 				}
 
+				if len(unknownLocs) > 0 {
+					sb.WriteString("\n⚠️ Unknown locations skipped: " + lang.RenderLocMarkers(unknownLocs))
+				}
+
 				return c.Send(sb.String())
 			})
 		})
@@ -431,6 +442,8 @@ var BotCommand = &cli.Command{
 			ctx := context.Background()
 
 			return s.Tx(ctx, func(j *journal.Journal) error {
+				unknownLocs := j.UnknownLocations(e.LocationIDs)
+
 				e, err = j.AddEvent(e)
 				if err != nil {
 					return c.Send(fmt.Sprintf("failed to add activity: %v", err))
@@ -447,6 +460,10 @@ var BotCommand = &cli.Command{
 					sb.WriteString("\n🏷️ Tags: " + strings.Join(e.Tags, ", "))
 				}
 
+				if len(unknownLocs) > 0 {
+					sb.WriteString("\n⚠️ Unknown locations skipped: " + lang.RenderLocMarkers(unknownLocs))
+				}
+
 				return c.Send(sb.String())
 			})
 		})
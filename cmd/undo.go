@@ -0,0 +1,93 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	jctx "github.com/roma-glushko/frens/internal/context"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/store"
+	"github.com/urfave/cli/v2"
+)
+
+var UndoCommand = &cli.Command{
+	Name:  "undo",
+	Usage: "Revert the last change of the journal",
+	Description: `Revert the last change made to the journal on this device (e.g. a friend added by mistake).
+
+Changes are kept to undo on this device only, the last 50 by default (the store.undo_depth setting,
+0 turns undo off). Changes made since then (e.g. synced from other devices) are kept.
+Run "frens journal log" to see changes that can be undone.
+
+Examples:
+  frens undo      # revert the last change
+  frens redo      # apply the reverted change again
+`,
+	Action: func(c *cli.Context) error {
+		return undoStep(c, "Undone", store.Undoer.Undo)
+	},
+}
+
+var RedoCommand = &cli.Command{
+	Name:  "redo",
+	Usage: "Apply the last undone change of the journal again",
+	Description: `Apply the change reverted by "frens undo" again.
+Undone changes can't be redone once the journal is changed in another way.
+`,
+	Action: func(c *cli.Context) error {
+		return undoStep(c, "Redone", store.Undoer.Redo)
+	},
+}
+
+func undoStep(
+	c *cli.Context,
+	action string,
+	fn func(u store.Undoer, ctx context.Context) (store.Operation, error),
+) error {
+	ctx := c.Context
+	appCtx := jctx.FromCtx(ctx)
+
+	u, ok := appCtx.Store.(store.Undoer)
+	if !ok {
+		return store.ErrUndoUnsupported
+	}
+
+	op, err := fn(u, ctx)
+
+	switch {
+	case errors.Is(err, store.ErrNothingToUndo):
+		return cli.Exit("Nothing to undo.", 1)
+	case errors.Is(err, store.ErrNothingToRedo):
+		return cli.Exit("Nothing to redo.", 1)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	log.Success(action)
+
+	if err := appCtx.Printer.Print(op); err != nil {
+		return err
+	}
+
+	for _, conflict := range op.Conflicts {
+		log.Warnf("Kept the current version of %s", conflict)
+	}
+
+	return nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/markusmobius/go-dateparser"
+	"github.com/markusmobius/go-dateparser/date"
+	"github.com/roma-glushko/frens/internal/friend"
+)
+
+var (
+	ErrUnsupportedCalendar = errors.New("unsupported calendar")
+	ErrIncompleteDate      = errors.New("date expression must contain at least a day and a month")
+	ErrUnknownMonth        = errors.New("unknown month")
+)
+
+var hebrewMonths = map[string]int{
+	"nisan":       Nisan,
+	"nissan":      Nisan,
+	"iyar":        Iyar,
+	"iyyar":       Iyar,
+	"sivan":       Sivan,
+	"tamuz":       Tamuz,
+	"tammuz":      Tamuz,
+	"av":          Av,
+	"menachem av": Av,
+	"elul":        Elul,
+	"tishrei":     Tishrei,
+	"tishri":      Tishrei,
+	"cheshvan":    Cheshvan,
+	"heshvan":     Cheshvan,
+	"marcheshvan": Cheshvan,
+	"marheshvan":  Cheshvan,
+	"kislev":      Kislev,
+	"tevet":       Tevet,
+	"teves":       Tevet,
+	"shevat":      Shevat,
+	"shvat":       Shevat,
+	"adar":        Adar,
+	"adar i":      AdarI,
+	"adar a":      AdarI,
+	"adar aleph":  AdarI,
+	"adar rishon": AdarI,
+	"adar ii":     AdarII,
+	"adar b":      AdarII,
+	"adar bet":    AdarII,
+	"adar beit":   AdarII,
+	"adar sheni":  AdarII,
+}
+
+// Expr is a date expression parsed in its own calendar.
+// The year is zero when the expression doesn't mention it (e.g. "May 13th" or "15 Nisan").
+type Expr struct {
+	Calendar friend.Calendar
+	Year     int
+	Month    int
+	Day      int
+	// adarI marks dates that explicitly fall on Adar I, so they are not moved to Adar II in leap years
+	adarI bool
+}
+
+// HasYear tells if the year of the date is known
+func (e Expr) HasYear() bool {
+	return e.Year != 0
+}
+
+// Parse parses the date expression according to the date calendar
+func Parse(d friend.Date) (Expr, error) {
+	switch strings.ToLower(d.Calendar) {
+	case "", friend.CalendarGregorian:
+		return parseGregorian(d.DateExpr)
+	case friend.CalendarHebrew:
+		return parseHebrew(d.DateExpr)
+	default:
+		return Expr{}, fmt.Errorf("%w: %s", ErrUnsupportedCalendar, d.Calendar)
+	}
+}
+
+// Date returns the Gregorian date of the expression. It only makes sense when the year is known.
+func (e Expr) Date(loc *time.Location) time.Time {
+	return e.in(e.Year, loc)
+}
+
+// Next resolves the first occurrence of the date on or after the `from` day.
+// Dates with a known year don't occur before they happened for the first time.
+func (e Expr) Next(from time.Time) friend.Occurrence {
+	loc := from.Location()
+	today := truncateDay(from)
+
+	if e.HasYear() {
+		if orig := e.Date(loc); !orig.Before(today) {
+			return friend.Occurrence{Date: orig, DaysLeft: daysBetween(today, orig)}
+		}
+	}
+
+	start := today.Year() - 1
+
+	if e.Calendar == friend.CalendarHebrew {
+		start = ToHebrew(today).Year - 1
+	}
+
+	for y := start; ; y++ {
+		occ := e.in(y, loc)
+
+		if occ.Before(today) {
+			continue
+		}
+
+		o := friend.Occurrence{Date: occ, DaysLeft: daysBetween(today, occ)}
+
+		if e.HasYear() {
+			o.Years = y - e.Year
+		}
+
+		return o
+	}
+}
+
+// in returns the Gregorian date the expression falls on in the given year of its calendar
+func (e Expr) in(year int, loc *time.Location) time.Time {
+	if e.Calendar != friend.CalendarHebrew {
+		lastDay := time.Date(year, time.Month(e.Month)+1, 0, 0, 0, 0, 0, loc).Day()
+
+		return time.Date(year, time.Month(e.Month), min(e.Day, lastDay), 0, 0, 0, 0, loc)
+	}
+
+	month, day := e.Month, e.Day
+	leap := IsHebrewLeapYear(year)
+
+	switch {
+	case month == AdarII && !leap:
+		month = Adar
+	case month == Adar && leap && !e.adarI:
+		// dates in Adar of a common year are observed in Adar II during leap years
+		month = AdarII
+	}
+
+	if day > HebrewMonthDays(year, month) {
+		// the 30th of a month that only has 29 days this year is observed on the first day of the next month
+		month = nextHebrewMonth(year, month)
+		day = 1
+	}
+
+	return FromHebrew(HebrewDate{Year: year, Month: month, Day: day}, loc)
+}
+
+// parseGregorian parses free-form Gregorian dates and finds out if the year was specified
+// by parsing the expression relative to two different years.
+func parseGregorian(s string) (Expr, error) {
+	s = strings.TrimSpace(s)
+
+	parse := func(year int) (date.Date, error) {
+		return dateparser.Parse(&dateparser.Configuration{
+			CurrentTime: time.Date(year, time.January, 15, 0, 0, 0, 0, time.UTC),
+		}, s)
+	}
+
+	dt, err := parse(2000)
+	if err != nil {
+		return Expr{}, fmt.Errorf("failed to parse date '%s': %w", s, err)
+	}
+
+	if dt.Period == date.Month || dt.Period == date.Year {
+		return Expr{}, fmt.Errorf("%w: '%s'", ErrIncompleteDate, s)
+	}
+
+	e := Expr{
+		Calendar: friend.CalendarGregorian,
+		Year:     dt.Time.Year(),
+		Month:    int(dt.Time.Month()),
+		Day:      dt.Time.Day(),
+	}
+
+	if other, err := parse(2004); err == nil && other.Time.Year() != dt.Time.Year() {
+		e.Year = 0
+	}
+
+	return e, nil
+}
+
+// parseHebrew parses expressions like "15 Nisan", "Adar II 14" or "1 Tishrei 5750".
+// Gregorian dates with a year are converted to the matching Hebrew date.
+func parseHebrew(s string) (Expr, error) { //nolint:cyclop
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == '.' || r == '-' || r == '/'
+	})
+
+	var (
+		nums  []int
+		words []string
+	)
+
+	for _, f := range fields {
+		if f == "of" {
+			continue
+		}
+
+		if n, err := strconv.Atoi(strings.TrimRight(f, "stndrh")); err == nil && startsWithDigit(f) {
+			nums = append(nums, n)
+			continue
+		}
+
+		words = append(words, strings.ReplaceAll(f, "'", ""))
+	}
+
+	month, ok := hebrewMonths[strings.Join(words, " ")]
+	if !ok {
+		// the expression may be a Gregorian date that should be observed by the Hebrew calendar
+		e, err := hebrewFromGregorian(s)
+		if err != nil && len(words) > 0 {
+			return Expr{}, fmt.Errorf("%w: '%s'", ErrUnknownMonth, strings.Join(words, " "))
+		}
+
+		return e, err
+	}
+
+	e := Expr{Calendar: friend.CalendarHebrew, Month: month}
+
+	for _, n := range nums {
+		switch {
+		case n >= 1 && n <= 30 && e.Day == 0:
+			e.Day = n
+		case n > 30 && e.Year == 0:
+			e.Year = n
+		default:
+			return Expr{}, fmt.Errorf("failed to parse Hebrew date '%s'", s)
+		}
+	}
+
+	if e.Day == 0 {
+		return Expr{}, fmt.Errorf("%w: '%s'", ErrIncompleteDate, s)
+	}
+
+	e.adarI = month == AdarI && (len(words) > 1 || e.HasYear() && IsHebrewLeapYear(e.Year))
+
+	return e, nil
+}
+
+func hebrewFromGregorian(s string) (Expr, error) {
+	g, err := parseGregorian(s)
+	if err != nil {
+		return Expr{}, err
+	}
+
+	if !g.HasYear() {
+		return Expr{}, fmt.Errorf(
+			"gregorian date '%s' must have a year to be converted to the Hebrew calendar",
+			s,
+		)
+	}
+
+	h := ToHebrew(g.Date(time.UTC))
+
+	return Expr{
+		Calendar: friend.CalendarHebrew,
+		Year:     h.Year,
+		Month:    h.Month,
+		Day:      h.Day,
+		adarI:    h.Month == AdarI && IsHebrewLeapYear(h.Year),
+	}, nil
+}
+
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+func daysBetween(from, to time.Time) int {
+	return toFixed(to) - toFixed(from)
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
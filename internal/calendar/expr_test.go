@@ -0,0 +1,167 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNext(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, time.October, 16, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		date     friend.Date
+		expected friend.Occurrence
+	}{
+		{
+			name: "gregorian without year",
+			date: friend.Date{Calendar: friend.CalendarGregorian, DateExpr: "January 15"},
+			expected: friend.Occurrence{
+				Date:     time.Date(2027, time.January, 15, 0, 0, 0, 0, time.UTC),
+				DaysLeft: 91,
+			},
+		},
+		{
+			name: "gregorian with year",
+			date: friend.Date{Calendar: friend.CalendarGregorian, DateExpr: "1990-10-20"},
+			expected: friend.Occurrence{
+				Date:     time.Date(2026, time.October, 20, 0, 0, 0, 0, time.UTC),
+				DaysLeft: 4,
+				Years:    36,
+			},
+		},
+		{
+			name: "gregorian today",
+			date: friend.Date{DateExpr: "Oct 16"},
+			expected: friend.Occurrence{
+				Date:     time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC),
+				DaysLeft: 0,
+			},
+		},
+		{
+			name: "gregorian leap day",
+			date: friend.Date{Calendar: friend.CalendarGregorian, DateExpr: "2000-02-29"},
+			expected: friend.Occurrence{
+				Date:     time.Date(2027, time.February, 28, 0, 0, 0, 0, time.UTC),
+				DaysLeft: 135,
+				Years:    27,
+			},
+		},
+		{
+			name: "gregorian in the future",
+			date: friend.Date{Calendar: friend.CalendarGregorian, DateExpr: "2030-01-01"},
+			expected: friend.Occurrence{
+				Date:     time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+				DaysLeft: 1173,
+			},
+		},
+		{
+			name: "hebrew without year",
+			date: friend.Date{Calendar: friend.CalendarHebrew, DateExpr: "15 Nisan"},
+			expected: friend.Occurrence{
+				Date:     time.Date(2027, time.April, 22, 0, 0, 0, 0, time.UTC),
+				DaysLeft: 188,
+			},
+		},
+		{
+			name: "hebrew with year",
+			date: friend.Date{Calendar: friend.CalendarHebrew, DateExpr: "1 Tishrei 5750"},
+			expected: friend.Occurrence{
+				Date:     time.Date(2027, time.October, 2, 0, 0, 0, 0, time.UTC),
+				DaysLeft: 351,
+				Years:    38,
+			},
+		},
+		{
+			name: "hebrew adar in a leap year",
+			date: friend.Date{Calendar: friend.CalendarHebrew, DateExpr: "Adar 14"},
+			expected: friend.Occurrence{
+				Date:     time.Date(2027, time.March, 23, 0, 0, 0, 0, time.UTC),
+				DaysLeft: 158,
+			},
+		},
+		{
+			name: "hebrew adar i in a leap year",
+			date: friend.Date{Calendar: friend.CalendarHebrew, DateExpr: "14th of Adar I"},
+			expected: friend.Occurrence{
+				Date:     time.Date(2027, time.February, 21, 0, 0, 0, 0, time.UTC),
+				DaysLeft: 128,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			occ, err := Next(tt.date, from)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, occ)
+		})
+	}
+}
+
+func TestParse_HebrewFromGregorian(t *testing.T) {
+	t.Parallel()
+
+	e, err := Parse(friend.Date{Calendar: friend.CalendarHebrew, DateExpr: "2025-04-13"})
+	require.NoError(t, err)
+	require.Equal(t, friend.CalendarHebrew, e.Calendar)
+	require.Equal(t, 5785, e.Year)
+	require.Equal(t, Nisan, e.Month)
+	require.Equal(t, 15, e.Day)
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		date friend.Date
+		err  error
+	}{
+		{
+			name: "no day",
+			date: friend.Date{Calendar: friend.CalendarGregorian, DateExpr: "1985"},
+			err:  ErrIncompleteDate,
+		},
+		{
+			name: "unknown hebrew month",
+			date: friend.Date{Calendar: friend.CalendarHebrew, DateExpr: "15 Foo"},
+			err:  ErrUnknownMonth,
+		},
+		{
+			name: "unknown calendar",
+			date: friend.Date{Calendar: "julian", DateExpr: "May 13"},
+			err:  ErrUnsupportedCalendar,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse(tt.date)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
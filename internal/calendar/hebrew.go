@@ -0,0 +1,216 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"time"
+)
+
+// Hebrew months are numbered starting from Nisan, while the year starts on Tishrei.
+// In leap years, Adar becomes Adar I and the extra Adar II month is added.
+const (
+	Nisan = iota + 1
+	Iyar
+	Sivan
+	Tamuz
+	Av
+	Elul
+	Tishrei
+	Cheshvan
+	Kislev
+	Tevet
+	Shevat
+	Adar
+	AdarII
+)
+
+// AdarI is the first Adar month of a leap year
+const AdarI = Adar
+
+// hebrewEpoch is the fixed day number (R.D.) of 1 Tishrei AM 1
+const hebrewEpoch = -1373427
+
+// unixEpochFixed is the fixed day number (R.D.) of 1970-01-01
+const unixEpochFixed = 719163
+
+// HebrewDate is a date in the Hebrew calendar
+type HebrewDate struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// IsHebrewLeapYear tells if the year has 13 months (Adar I and Adar II)
+func IsHebrewLeapYear(year int) bool {
+	return mod(7*year+1, 19) < 7
+}
+
+// HebrewMonthDays returns the number of days in the given Hebrew month
+func HebrewMonthDays(year, month int) int {
+	switch {
+	case month == Iyar || month == Tamuz || month == Elul || month == Tevet || month == AdarII:
+		return 29
+	case month == Adar && !IsHebrewLeapYear(year):
+		return 29
+	case month == Cheshvan && !longCheshvan(year):
+		return 29
+	case month == Kislev && shortKislev(year):
+		return 29
+	default:
+		return 30
+	}
+}
+
+// FromHebrew converts a Hebrew date into the Gregorian date in the given location
+func FromHebrew(d HebrewDate, loc *time.Location) time.Time {
+	return fromFixed(fixedFromHebrew(d.Year, d.Month, d.Day), loc)
+}
+
+// ToHebrew converts a Gregorian date into the Hebrew one
+func ToHebrew(t time.Time) HebrewDate {
+	fixed := toFixed(t)
+
+	approx := int(float64(fixed-hebrewEpoch)/(35975351.0/98496.0)) + 1
+
+	year := approx - 1
+	for hebrewNewYear(year+1) <= fixed {
+		year++
+	}
+
+	month := Nisan
+	if fixed < fixedFromHebrew(year, Nisan, 1) {
+		month = Tishrei
+	}
+
+	for fixed > fixedFromHebrew(year, month, HebrewMonthDays(year, month)) {
+		month = nextHebrewMonth(year, month)
+	}
+
+	return HebrewDate{
+		Year:  year,
+		Month: month,
+		Day:   fixed - fixedFromHebrew(year, month, 1) + 1,
+	}
+}
+
+func lastHebrewMonth(year int) int {
+	if IsHebrewLeapYear(year) {
+		return AdarII
+	}
+
+	return Adar
+}
+
+func nextHebrewMonth(year, month int) int {
+	if month == lastHebrewMonth(year) {
+		return Nisan
+	}
+
+	return month + 1
+}
+
+func hebrewElapsedDays(year int) int {
+	monthsElapsed := floorDiv(235*year-234, 19)
+	partsElapsed := 12084 + 13753*monthsElapsed
+	days := 29*monthsElapsed + floorDiv(partsElapsed, 25920)
+
+	if mod(3*(days+1), 7) < 3 {
+		return days + 1
+	}
+
+	return days
+}
+
+func hebrewYearLengthCorrection(year int) int {
+	ny0 := hebrewElapsedDays(year - 1)
+	ny1 := hebrewElapsedDays(year)
+	ny2 := hebrewElapsedDays(year + 1)
+
+	switch {
+	case ny2-ny1 == 356:
+		return 2
+	case ny1-ny0 == 382:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func hebrewNewYear(year int) int {
+	return hebrewEpoch + hebrewElapsedDays(year) + hebrewYearLengthCorrection(year)
+}
+
+func hebrewYearDays(year int) int {
+	return hebrewNewYear(year+1) - hebrewNewYear(year)
+}
+
+func longCheshvan(year int) bool {
+	days := hebrewYearDays(year)
+
+	return days == 355 || days == 385
+}
+
+func shortKislev(year int) bool {
+	days := hebrewYearDays(year)
+
+	return days == 353 || days == 383
+}
+
+func fixedFromHebrew(year, month, day int) int {
+	fixed := hebrewNewYear(year) + day - 1
+
+	if month < Tishrei {
+		for m := Tishrei; m <= lastHebrewMonth(year); m++ {
+			fixed += HebrewMonthDays(year, m)
+		}
+
+		for m := Nisan; m < month; m++ {
+			fixed += HebrewMonthDays(year, m)
+		}
+
+		return fixed
+	}
+
+	for m := Tishrei; m < month; m++ {
+		fixed += HebrewMonthDays(year, m)
+	}
+
+	return fixed
+}
+
+func toFixed(t time.Time) int {
+	y, m, d := t.Date()
+	unixDays := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+
+	return int(unixDays) + unixEpochFixed
+}
+
+func fromFixed(fixed int, loc *time.Location) time.Time {
+	return time.Date(1970, time.January, 1+fixed-unixEpochFixed, 0, 0, 0, 0, loc)
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+
+	return q
+}
+
+func mod(a, b int) int {
+	return a - b*floorDiv(a, b)
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHebrewConversion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		hebrew    HebrewDate
+		gregorian time.Time
+	}{
+		{
+			name:      "passover 5785",
+			hebrew:    HebrewDate{Year: 5785, Month: Nisan, Day: 15},
+			gregorian: time.Date(2025, time.April, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "rosh hashanah 5786",
+			hebrew:    HebrewDate{Year: 5786, Month: Tishrei, Day: 1},
+			gregorian: time.Date(2025, time.September, 23, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "hanukkah 5786",
+			hebrew:    HebrewDate{Year: 5786, Month: Kislev, Day: 25},
+			gregorian: time.Date(2025, time.December, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "purim in a leap year",
+			hebrew:    HebrewDate{Year: 5784, Month: AdarII, Day: 14},
+			gregorian: time.Date(2024, time.March, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "purim in a common year",
+			hebrew:    HebrewDate{Year: 5785, Month: Adar, Day: 14},
+			gregorian: time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.gregorian, FromHebrew(tt.hebrew, time.UTC))
+			require.Equal(t, tt.hebrew, ToHebrew(tt.gregorian))
+		})
+	}
+}
+
+func TestHebrewRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := range 365 * 50 {
+		d := start.AddDate(0, 0, i)
+
+		require.Equal(t, d, FromHebrew(ToHebrew(d), time.UTC), d.String())
+	}
+}
+
+func TestIsHebrewLeapYear(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, IsHebrewLeapYear(5784))
+	require.False(t, IsHebrewLeapYear(5785))
+	require.False(t, IsHebrewLeapYear(5786))
+	require.True(t, IsHebrewLeapYear(5787))
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/version"
+)
+
+const (
+	icsDateFormat  = "20060102"
+	icsStampFormat = "20060102T150405Z"
+	icsLineLimit   = 75
+	icsUIDDomain   = "frens"
+)
+
+type icsConfig struct {
+	name  string
+	now   time.Time
+	years int
+}
+
+type ICSOption func(*icsConfig)
+
+// WithCalendarName sets the name calendar apps show for the calendar
+func WithCalendarName(name string) ICSOption {
+	return func(c *icsConfig) {
+		c.name = name
+	}
+}
+
+// WithYears sets how many years ahead to list dates that can't be expressed as yearly Gregorian rules
+// (e.g. Hebrew dates)
+func WithYears(years int) ICSOption {
+	return func(c *icsConfig) {
+		c.years = years
+	}
+}
+
+// WithNow sets the current time used for timestamps and as the start of listed dates
+func WithNow(now time.Time) ICSOption {
+	return func(c *icsConfig) {
+		c.now = now
+	}
+}
+
+// EncodeICS renders dates as an iCalendar (RFC 5545) with all-day yearly events.
+// Gregorian dates recur by RRULE. Dates in other calendars drift against the Gregorian one,
+// so each of their occurrences is listed as a separate event.
+// UIDs are derived from date IDs, so calendar apps recognize the same events across exports.
+// Dates that could not be parsed are left out and reported in the returned error.
+func EncodeICS(dates []friend.Date, title func(friend.Date) string, opts ...ICSOption) ([]byte, error) {
+	cfg := icsConfig{
+		name:  "frens",
+		now:   time.Now(),
+		years: 10,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	w := &icsWriter{}
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:-//frens//frens " + version.Version + "//EN")
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.prop("X-WR-CALNAME", escapeText(cfg.name))
+
+	var errs []error
+
+	for _, d := range dates {
+		e, err := Parse(d)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to export date %s: %w", d.ID, err))
+			continue
+		}
+
+		ev := icsEvent{date: d, expr: e, title: title(d), stamp: cfg.now.UTC()}
+
+		if e.Calendar != friend.CalendarHebrew {
+			ev.write(w, d.ID, e.gregorianStart(cfg.now.Year()), e.yearlyRule())
+			continue
+		}
+
+		// Hebrew years start in autumn, so begin a year earlier to cover the whole current Gregorian year
+		from := ToHebrew(cfg.now).Year - 1
+
+		for y := from; y <= from+cfg.years; y++ {
+			if e.HasYear() && y < e.Year {
+				continue
+			}
+
+			ev.write(w, d.ID+"-"+strconv.Itoa(y), e.in(y, time.UTC), "")
+		}
+	}
+
+	w.line("END:VCALENDAR")
+
+	return []byte(w.String()), errors.Join(errs...)
+}
+
+// gregorianStart is the first day of the recurring event.
+// Dates without a year start in the given one, as the year they first happened is unknown.
+func (e Expr) gregorianStart(year int) time.Time {
+	if e.HasYear() {
+		return e.Date(time.UTC)
+	}
+
+	return e.in(year, time.UTC)
+}
+
+func (e Expr) yearlyRule() string {
+	if e.Month == int(time.February) && e.Day == 29 {
+		// keep leap day dates on the last day of February in common years
+		return "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1"
+	}
+
+	return "FREQ=YEARLY"
+}
+
+type icsEvent struct {
+	date  friend.Date
+	expr  Expr
+	title string
+	stamp time.Time
+}
+
+func (ev icsEvent) write(w *icsWriter, uid string, day time.Time, rrule string) {
+	w.line("BEGIN:VEVENT")
+	w.prop("UID", uid+"@"+icsUIDDomain)
+	w.prop("DTSTAMP", ev.stamp.Format(icsStampFormat))
+	w.prop("DTSTART;VALUE=DATE", day.Format(icsDateFormat))
+	w.prop("DTEND;VALUE=DATE", day.AddDate(0, 0, 1).Format(icsDateFormat))
+
+	if rrule != "" {
+		w.prop("RRULE", rrule)
+	}
+
+	w.prop("SUMMARY", escapeText(ev.title))
+
+	desc := ev.date.DateExpr
+
+	if ev.expr.Calendar == friend.CalendarHebrew {
+		desc += " (Hebrew calendar)"
+	}
+
+	w.prop("DESCRIPTION", escapeText(desc))
+
+	if len(ev.date.Tags) > 0 {
+		tags := make([]string, 0, len(ev.date.Tags))
+
+		for _, t := range ev.date.Tags {
+			tags = append(tags, escapeText(t))
+		}
+
+		w.prop("CATEGORIES", strings.Join(tags, ","))
+	}
+
+	w.prop("TRANSP", "TRANSPARENT")
+	w.line("END:VEVENT")
+}
+
+// icsWriter writes content lines folded at 75 octets and terminated with CRLF
+type icsWriter struct {
+	strings.Builder
+}
+
+func (w *icsWriter) prop(name, value string) {
+	w.line(name + ":" + value)
+}
+
+func (w *icsWriter) line(s string) {
+	limit := icsLineLimit
+
+	for len(s) > limit {
+		cut := limit
+
+		// don't split multibyte characters
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+
+		s = s[cut:]
+		// the leading space of continuation lines counts towards the limit
+		limit = icsLineLimit - 1
+	}
+
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
+
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// DateTitle names the event of a friend's date (e.g. "Jim Halpert: Birthday")
+func DateTitle(person string, d friend.Date) string {
+	if d.Desc == "" {
+		return person
+	}
+
+	return person + ": " + d.Desc
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/stretchr/testify/require"
+)
+
+// icsEvents unfolds the calendar and returns its events by UID
+func icsEvents(t *testing.T, data []byte) map[string]map[string]string {
+	t.Helper()
+
+	s := string(data)
+
+	require.True(t, strings.HasPrefix(s, "BEGIN:VCALENDAR\r\n"))
+	require.True(t, strings.HasSuffix(s, "END:VCALENDAR\r\n"))
+
+	for _, line := range strings.Split(strings.TrimSuffix(s, "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), icsLineLimit, line)
+	}
+
+	events := make(map[string]map[string]string)
+
+	var ev map[string]string
+
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\r\n ", ""), "\r\n") {
+		switch line {
+		case "BEGIN:VEVENT":
+			ev = make(map[string]string)
+		case "END:VEVENT":
+			events[ev["UID"]] = ev
+		default:
+			if name, value, ok := strings.Cut(line, ":"); ok && ev != nil {
+				ev[name] = value
+			}
+		}
+	}
+
+	return events
+}
+
+func TestEncodeICS(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+
+	dates := []friend.Date{
+		{ID: "bday", Calendar: friend.CalendarGregorian, DateExpr: "1985-03-14", Desc: "Birthday", Tags: []string{"family"}},
+		{ID: "anniversary", Calendar: friend.CalendarGregorian, DateExpr: "May 13", Desc: "Wedding; the big one"},
+		{ID: "leap", Calendar: friend.CalendarGregorian, DateExpr: "Feb 29 1996"},
+		{ID: "broken", Calendar: friend.CalendarGregorian, DateExpr: "someday"},
+		{ID: "hebrew", Calendar: friend.CalendarHebrew, DateExpr: "15 Nisan", Desc: "Hebrew birthday"},
+	}
+
+	title := func(d friend.Date) string {
+		return "Jim Halpert, " + d.Desc + " with a rather long title that has to be folded by the encoder"
+	}
+
+	data, err := EncodeICS(dates, title, WithNow(now), WithYears(2))
+	require.ErrorContains(t, err, "broken")
+
+	events := icsEvents(t, data)
+	require.Len(t, events, 6)
+
+	bday := events["bday@frens"]
+	require.Equal(t, "19850314", bday["DTSTART;VALUE=DATE"])
+	require.Equal(t, "19850315", bday["DTEND;VALUE=DATE"])
+	require.Equal(t, "FREQ=YEARLY", bday["RRULE"])
+	require.Equal(t, "family", bday["CATEGORIES"])
+	require.Equal(t, "20261016T090000Z", bday["DTSTAMP"])
+	require.Equal(t,
+		`Jim Halpert\, Birthday with a rather long title that has to be folded by the encoder`,
+		bday["SUMMARY"],
+	)
+
+	anniversary := events["anniversary@frens"]
+	require.Equal(t, "20260513", anniversary["DTSTART;VALUE=DATE"])
+	require.Contains(t, anniversary["SUMMARY"], `Wedding\; the big one`)
+
+	require.Equal(t, "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1", events["leap@frens"]["RRULE"])
+
+	// Passover falls on 2026-04-02, 2027-04-22 and 2028-04-11
+	for uid, day := range map[string]string{
+		"hebrew-5786@frens": "20260402",
+		"hebrew-5787@frens": "20270422",
+		"hebrew-5788@frens": "20280411",
+	} {
+		require.Contains(t, events, uid)
+		require.Equal(t, day, events[uid]["DTSTART;VALUE=DATE"])
+		require.NotContains(t, events[uid], "RRULE")
+	}
+}
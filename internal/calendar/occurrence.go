@@ -0,0 +1,77 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+)
+
+// Next resolves the first occurrence of the date on or after the `from` day
+func Next(d friend.Date, from time.Time) (friend.Occurrence, error) {
+	e, err := Parse(d)
+	if err != nil {
+		return friend.Occurrence{}, err
+	}
+
+	return e.Next(from), nil
+}
+
+// Resolve fills in the next occurrence of each date.
+// Dates that could not be resolved are kept as is and reported in the returned error.
+func Resolve(dates []friend.Date, from time.Time) ([]friend.Date, error) {
+	var errs []error
+
+	resolved := make([]friend.Date, 0, len(dates))
+
+	for _, d := range dates {
+		occ, err := Next(d, from)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve date %s: %w", d.ID, err))
+		} else {
+			d.Next = &occ
+		}
+
+		resolved = append(resolved, d)
+	}
+
+	return resolved, errors.Join(errs...)
+}
+
+// Upcoming returns dates that happen within the given period from now sorted by their next occurrence
+func Upcoming(dates []friend.Date, from time.Time, within time.Duration) ([]friend.Date, error) {
+	resolved, err := Resolve(dates, from)
+
+	maxDays := int(within / (24 * time.Hour))
+	upcoming := make([]friend.Date, 0, len(resolved))
+
+	for _, d := range resolved {
+		if d.Next == nil || d.Next.DaysLeft > maxDays {
+			continue
+		}
+
+		upcoming = append(upcoming, d)
+	}
+
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].Next.Date.Before(upcoming[j].Next.Date)
+	})
+
+	return upcoming, err
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpcoming(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+
+	dates := []friend.Date{
+		{ID: "far", Calendar: friend.CalendarGregorian, DateExpr: "March 1"},
+		{ID: "soon", Calendar: friend.CalendarGregorian, DateExpr: "1990-10-20"},
+		{ID: "today", Calendar: friend.CalendarGregorian, DateExpr: "Oct 16"},
+		{ID: "broken", Calendar: friend.CalendarGregorian, DateExpr: "someday"},
+		{ID: "hebrew", Calendar: friend.CalendarHebrew, DateExpr: "25 Kislev"},
+	}
+
+	upcoming, err := Upcoming(dates, from, 60*24*time.Hour)
+	require.Error(t, err)
+
+	ids := make([]string, 0, len(upcoming))
+
+	for _, d := range upcoming {
+		ids = append(ids, d.ID)
+	}
+
+	require.Equal(t, []string{"today", "soon", "hebrew"}, ids)
+}
@@ -0,0 +1,338 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package carddav serves friends as a CardDAV (RFC 6352) address book, so address books can sync them.
+package carddav
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/store"
+	"github.com/roma-glushko/frens/internal/vcard"
+)
+
+const (
+	// Prefix is the path of the principal and the address book home
+	Prefix = "/carddav/"
+	// WellKnownPath lets clients discover the service by the server address only (RFC 6764)
+	WellKnownPath = "/.well-known/carddav"
+	// BookPath is the path of the address book with friends
+	BookPath = Prefix + "friends/"
+
+	cardExt         = ".vcf"
+	cardContentType = "text/vcard; charset=utf-8"
+	maxCardSize     = 1 << 20
+)
+
+var (
+	errNotFound           = errors.New("not found")
+	errPreconditionFailed = errors.New("precondition failed")
+)
+
+// Handler serves the address book. The journal stays the source of truth,
+// so every request works with the current state of friends.
+type Handler struct {
+	store store.Store
+}
+
+func NewHandler(s store.Store) *Handler {
+	return &Handler{store: s}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodOptions:
+		w.Header().Set("DAV", "1, 3, addressbook")
+		w.Header().Set("Allow", "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT")
+		w.WriteHeader(http.StatusNoContent)
+	case "PROPFIND":
+		h.handlePropfind(w, r)
+	case "REPORT":
+		h.handleReport(w, r)
+	case http.MethodGet, http.MethodHead:
+		h.handleGet(w, r)
+	case http.MethodPut:
+		h.handlePut(w, r)
+	case http.MethodDelete:
+		h.handleDelete(w, r)
+	default:
+		w.Header().Set("Allow", "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// card is a friend rendered as a vCard resource
+type card struct {
+	id   string
+	data []byte
+	etag string
+}
+
+func (c card) href() string {
+	return BookPath + url.PathEscape(c.id) + cardExt
+}
+
+func newCard(jr *journal.Journal, f friend.Person) (card, error) {
+	var buf bytes.Buffer
+
+	if err := vcard.Encode(&buf, []vcard.Card{vcard.FromFriend(jr, f)}); err != nil {
+		return card{}, err
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+
+	return card{id: f.ID, data: buf.Bytes(), etag: `"` + hex.EncodeToString(sum[:16]) + `"`}, nil
+}
+
+func listCards(jr *journal.Journal) ([]card, error) {
+	cards := make([]card, 0, len(jr.Friends))
+
+	for _, f := range jr.Friends {
+		c, err := newCard(jr, *f)
+		if err != nil {
+			return nil, err
+		}
+
+		cards = append(cards, c)
+	}
+
+	return cards, nil
+}
+
+// ctag changes whenever any card of the address book changes
+func ctag(cards []card) string {
+	h := sha256.New()
+
+	for _, c := range cards {
+		h.Write([]byte(c.id + c.etag))
+	}
+
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// cardID returns the friend ID of the card path, e.g. /carddav/friends/jim-halpert.vcf
+func cardID(p string) (string, bool) {
+	name, ok := strings.CutPrefix(p, BookPath)
+	if !ok || strings.Contains(name, "/") || !strings.HasSuffix(name, cardExt) {
+		return "", false
+	}
+
+	id := strings.TrimSuffix(name, cardExt)
+
+	return id, id != ""
+}
+
+// findFriend looks the friend up by the exact ID, as guessing is not an option for syncing
+func findFriend(jr *journal.Journal, id string) (friend.Person, bool) {
+	i := slices.IndexFunc(jr.Friends, func(f *friend.Person) bool { return f.ID == id })
+	if i < 0 {
+		return friend.Person{}, false
+	}
+
+	return *jr.Friends[i], true
+}
+
+func (h *Handler) handleGet(w http.ResponseWriter, r *http.Request) {
+	id, ok := cardID(r.URL.Path)
+	if !ok {
+		http.Error(w, "not an address book card", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var c card
+
+	err := h.store.Tx(r.Context(), func(jr *journal.Journal) error {
+		f, ok := findFriend(jr, id)
+		if !ok {
+			return errNotFound
+		}
+
+		var err error
+
+		c, err = newCard(jr, f)
+
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", cardContentType)
+	w.Header().Set("ETag", c.etag)
+
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(c.data)
+	}
+}
+
+func (h *Handler) handlePut(w http.ResponseWriter, r *http.Request) {
+	id, ok := cardID(r.URL.Path)
+	if !ok {
+		http.Error(w, "cards can only be put into "+BookPath, http.StatusForbidden)
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCardSize))
+	if err != nil {
+		http.Error(w, "failed to read the card: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cards, err := vcard.Decode(bytes.NewReader(data))
+	if err != nil || len(cards) != 1 {
+		http.Error(w, "expected a single vCard with a name", http.StatusBadRequest)
+		return
+	}
+
+	created := false
+
+	err = h.store.Tx(r.Context(), func(jr *journal.Journal) error {
+		c := cards[0]
+		c.Person.Locations = c.Locations(jr)
+
+		f, exists := findFriend(jr, id)
+
+		if err := checkPreconditions(jr, r, f, exists); err != nil {
+			return err
+		}
+
+		if !exists {
+			p := c.Person
+			p.ID = id
+			created = true
+
+			jr.AddFriend(p)
+
+			return nil
+		}
+
+		jr.UpdateFriend(f, c.Apply(f))
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// the card is stored in a different form, so clients have to fetch it to learn the ETag
+	if created {
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	id, ok := cardID(r.URL.Path)
+	if !ok {
+		http.Error(w, "only cards can be deleted", http.StatusForbidden)
+		return
+	}
+
+	err := h.store.Tx(r.Context(), func(jr *journal.Journal) error {
+		f, exists := findFriend(jr, id)
+		if !exists {
+			return errNotFound
+		}
+
+		if err := checkPreconditions(jr, r, f, exists); err != nil {
+			return err
+		}
+
+		// activities and notes with the friend are kept
+		jr.RemoveFriends([]friend.Person{f}, journal.RemoveModeDetach)
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkPreconditions makes sure the card wasn't changed since the client has seen it
+func checkPreconditions(jr *journal.Journal, r *http.Request, f friend.Person, exists bool) error {
+	ifMatch := r.Header.Get("If-Match")
+	ifNoneMatch := r.Header.Get("If-None-Match")
+
+	if ifNoneMatch == "*" && exists {
+		return fmt.Errorf("%w: the card already exists", errPreconditionFailed)
+	}
+
+	if ifMatch == "" {
+		return nil
+	}
+
+	if !exists {
+		return fmt.Errorf("%w: the card doesn't exist", errPreconditionFailed)
+	}
+
+	if ifMatch == "*" {
+		return nil
+	}
+
+	c, err := newCard(jr, f)
+	if err != nil {
+		return err
+	}
+
+	for etag := range strings.SplitSeq(ifMatch, ",") {
+		if strings.TrimSpace(etag) == c.etag {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: the card has been changed", errPreconditionFailed)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, errPreconditionFailed):
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+	case errors.Is(err, friend.ErrFriendNameEmpty):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// cleanPath brings collection paths to the form with the trailing slash
+func cleanPath(p string) string {
+	p = path.Clean("/" + p)
+
+	if !strings.HasSuffix(p, cardExt) {
+		p += "/"
+	}
+
+	return p
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carddav
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *file.TOMLFileStore) {
+	t.Helper()
+
+	s := file.NewTOMLFileStore(t.TempDir())
+	require.NoError(t, s.Init(t.Context()))
+
+	err := s.Tx(t.Context(), func(jr *journal.Journal) error {
+		jr.AddLocation(friend.Location{Name: "Scranton", Country: "USA"})
+		jr.AddFriend(friend.Person{
+			ID:        "jim",
+			Name:      "Jim Halpert",
+			Nicknames: []string{"Big Tuna"},
+			Locations: []string{"scranton"},
+			Contacts: []*friend.Contact{
+				{Type: friend.ContactTypeEmail, Value: "jim@dundermifflin.com", Tags: []string{"work"}},
+			},
+			Dates: []*friend.Date{
+				{DateExpr: "October 1 1978", Desc: "birthday"},
+				{DateExpr: "May 5", Desc: "Name day"},
+			},
+		})
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(NewHandler(s))
+	t.Cleanup(srv.Close)
+
+	return srv, s
+}
+
+func doRequest(
+	t *testing.T,
+	srv *httptest.Server,
+	method, path, body string,
+	headers ...string,
+) (*http.Response, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, srv.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, string(data)
+}
+
+// testMultistatus decodes responses keeping only values of properties
+type testMultistatus struct {
+	Responses []struct {
+		Href     string `xml:"href"`
+		Status   string `xml:"status"`
+		Propstat []struct {
+			Status string `xml:"status"`
+			Prop   struct {
+				Props []struct {
+					XMLName xml.Name
+					Value   string `xml:",innerxml"`
+				} `xml:",any"`
+			} `xml:"prop"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+func parseMultistatus(t *testing.T, body string) map[string]map[string]string {
+	t.Helper()
+
+	var ms testMultistatus
+
+	require.NoError(t, xml.Unmarshal([]byte(body), &ms))
+
+	out := make(map[string]map[string]string, len(ms.Responses))
+
+	for _, r := range ms.Responses {
+		props := make(map[string]string)
+
+		if r.Status != "" {
+			props["status"] = r.Status
+		}
+
+		for _, ps := range r.Propstat {
+			if ps.Status != statusOK {
+				continue
+			}
+
+			for _, p := range ps.Prop.Props {
+				props[p.XMLName.Local] = p.Value
+			}
+		}
+
+		out[r.Href] = props
+	}
+
+	return out
+}
+
+func TestHandler_Discovery(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newTestServer(t)
+
+	resp, _ := doRequest(t, srv, http.MethodOptions, BookPath, "")
+	require.Contains(t, resp.Header.Get("DAV"), "addressbook")
+
+	resp, body := doRequest(t, srv, "PROPFIND", Prefix, `<?xml version="1.0"?>
+<propfind xmlns="DAV:" xmlns:C="urn:ietf:params:xml:ns:carddav">
+  <prop><current-user-principal/><C:addressbook-home-set/><getlastmodified/></prop>
+</propfind>`, "Depth", "0")
+	require.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+
+	res := parseMultistatus(t, body)
+	require.Len(t, res, 1)
+	require.Contains(t, res[Prefix]["addressbook-home-set"], "<href xmlns=\"DAV:\">/carddav/</href>")
+	require.NotContains(t, res[Prefix], "getlastmodified")
+	require.Contains(t, body, statusNotFound)
+
+	resp, body = doRequest(t, srv, "PROPFIND", Prefix, "", "Depth", "1")
+	require.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+
+	res = parseMultistatus(t, body)
+	require.Contains(t, res[BookPath]["resourcetype"], "addressbook")
+	require.NotEmpty(t, res[BookPath]["getctag"])
+}
+
+func TestHandler_Sync(t *testing.T) {
+	t.Parallel()
+
+	srv, s := newTestServer(t)
+	jimPath := BookPath + "jim.vcf"
+
+	_, body := doRequest(t, srv, "PROPFIND", BookPath, `<propfind xmlns="DAV:"><prop><getetag/></prop></propfind>`,
+		"Depth", "1")
+	res := parseMultistatus(t, body)
+	require.Len(t, res, 2)
+
+	etag := strings.ReplaceAll(res[jimPath]["getetag"], "&#34;", `"`)
+	require.NotEmpty(t, etag)
+
+	resp, card := doRequest(t, srv, http.MethodGet, jimPath, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, etag, resp.Header.Get("ETag"))
+	require.Contains(t, card, "FN:Jim Halpert\r\n")
+	require.Contains(t, card, "ADR:;;;Scranton;;;USA\r\n")
+
+	// the ETag is stable
+	resp, _ = doRequest(t, srv, http.MethodHead, jimPath, "")
+	require.Equal(t, etag, resp.Header.Get("ETag"))
+
+	multiget := `<C:addressbook-multiget xmlns="DAV:" xmlns:C="urn:ietf:params:xml:ns:carddav">
+  <prop><getetag/><C:address-data/></prop>
+  <href>` + jimPath + `</href>
+  <href>` + srv.URL + BookPath + `dwight.vcf</href>
+</C:addressbook-multiget>`
+
+	resp, body = doRequest(t, srv, "REPORT", BookPath, multiget, "Depth", "1")
+	require.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+
+	res = parseMultistatus(t, body)
+	require.Contains(t, res[jimPath]["address-data"], "FN:Jim Halpert")
+	require.Equal(t, statusNotFound, res[BookPath+"dwight.vcf"]["status"])
+
+	edited := strings.Replace(card, "FN:Jim Halpert", "FN:James Halpert", 1)
+	edited = strings.Replace(edited, "BDAY:19781001", "BDAY:19781002", 1)
+
+	resp, _ = doRequest(t, srv, http.MethodPut, jimPath, edited, "If-Match", `"stale"`)
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	resp, _ = doRequest(t, srv, http.MethodPut, jimPath, edited, "If-Match", etag)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, _ = doRequest(t, srv, http.MethodGet, jimPath, "")
+	require.NotEqual(t, etag, resp.Header.Get("ETag"))
+
+	jr, err := s.Load(t.Context())
+	require.NoError(t, err)
+
+	jim := jr.Friends[0]
+	require.Equal(t, "James Halpert", jim.Name)
+	require.Equal(t, []string{"scranton"}, jim.Locations)
+	require.Equal(t, []string{"work"}, jim.Contacts[0].Tags)
+	require.Len(t, jim.Dates, 2)
+	require.Equal(t, "Name day", jim.Dates[0].Desc)
+	require.Equal(t, "birthday", jim.Dates[1].Desc)
+	require.Equal(t, "1978-10-02", jim.Dates[1].DateExpr)
+
+	newCard := "BEGIN:VCARD\r\nVERSION:3.0\r\nFN:Dwight Schrute\r\nTEL:+1 570 555 0102\r\nEND:VCARD\r\n"
+
+	resp, _ = doRequest(t, srv, http.MethodPut, BookPath+"6F0C1A2B.vcf", newCard, "If-None-Match", "*")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, _ = doRequest(t, srv, http.MethodPut, BookPath+"6F0C1A2B.vcf", newCard, "If-None-Match", "*")
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	resp, _ = doRequest(t, srv, http.MethodPut, BookPath+"empty.vcf", "BEGIN:VCARD\r\nEND:VCARD\r\n")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = doRequest(t, srv, http.MethodDelete, jimPath, "")
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, _ = doRequest(t, srv, http.MethodGet, jimPath, "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	jr, err = s.Load(t.Context())
+	require.NoError(t, err)
+	require.Len(t, jr.Friends, 1)
+	require.Equal(t, "6F0C1A2B", jr.Friends[0].ID)
+	require.Len(t, jr.Friends[0].Contacts, 1)
+}
+
+func TestHandler_UnsupportedReport(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newTestServer(t)
+
+	resp, body := doRequest(t, srv, "REPORT", BookPath,
+		`<sync-collection xmlns="DAV:"><sync-token/><prop><getetag/></prop></sync-collection>`)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	require.Contains(t, body, "supported-report")
+}
@@ -0,0 +1,389 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carddav
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/roma-glushko/frens/internal/journal"
+)
+
+const (
+	nsDAV     = "DAV:"
+	nsCardDAV = "urn:ietf:params:xml:ns:carddav"
+	// nsCalServer holds the collection tag clients use to check if anything changed before syncing
+	nsCalServer = "http://calendarserver.org/ns/"
+
+	statusOK       = "HTTP/1.1 200 OK"
+	statusNotFound = "HTTP/1.1 404 Not Found"
+)
+
+var (
+	propAddressData = xml.Name{Space: nsCardDAV, Local: "address-data"}
+
+	reportMultiget = xml.Name{Space: nsCardDAV, Local: "addressbook-multiget"}
+	reportQuery    = xml.Name{Space: nsCardDAV, Local: "addressbook-query"}
+)
+
+// prop is a property with its value rendered as XML
+type prop struct {
+	XMLName xml.Name
+	Value   string `xml:",innerxml"`
+}
+
+func davProp(local, value string) prop {
+	return prop{XMLName: xml.Name{Space: nsDAV, Local: local}, Value: value}
+}
+
+func cardDAVProp(local, value string) prop {
+	return prop{XMLName: xml.Name{Space: nsCardDAV, Local: local}, Value: value}
+}
+
+// resource is anything with properties: the principal, the address book or a card
+type resource struct {
+	href  string
+	props []prop
+}
+
+func (r resource) prop(name xml.Name) (prop, bool) {
+	for _, p := range r.props {
+		if p.XMLName == name {
+			return p, true
+		}
+	}
+
+	return prop{}, false
+}
+
+type multistatus struct {
+	XMLName   xml.Name   `xml:"DAV: multistatus"`
+	Responses []response `xml:"DAV: response"`
+}
+
+type response struct {
+	Href      string     `xml:"DAV: href"`
+	Propstats []propstat `xml:"DAV: propstat,omitempty"`
+	Status    string     `xml:"DAV: status,omitempty"`
+}
+
+type propstat struct {
+	Prop   propList `xml:"DAV: prop"`
+	Status string   `xml:"DAV: status"`
+}
+
+type propList struct {
+	Props []prop
+}
+
+// propRequest lists properties the client is interested in
+type propRequest struct {
+	AllProp  *struct{} `xml:"DAV: allprop"`
+	PropName *struct{} `xml:"DAV: propname"`
+	Prop     *struct {
+		Names []struct {
+			XMLName xml.Name
+		} `xml:",any"`
+	} `xml:"DAV: prop"`
+}
+
+func (pr propRequest) names() []xml.Name {
+	if pr.Prop == nil {
+		return nil
+	}
+
+	names := make([]xml.Name, 0, len(pr.Prop.Names))
+
+	for _, n := range pr.Prop.Names {
+		names = append(names, n.XMLName)
+	}
+
+	return names
+}
+
+type reportRequest struct {
+	XMLName xml.Name
+	propRequest
+
+	Hrefs []string `xml:"DAV: href"`
+}
+
+// response renders requested properties of the resource. All properties but card data are returned by default.
+func (r resource) response(req propRequest) response {
+	resp := response{Href: r.href}
+	names := req.names()
+
+	if names == nil {
+		for _, p := range r.props {
+			if p.XMLName != propAddressData {
+				names = append(names, p.XMLName)
+			}
+		}
+	}
+
+	var found, missing []prop
+
+	for _, name := range names {
+		p, ok := r.prop(name)
+
+		switch {
+		case !ok:
+			missing = append(missing, prop{XMLName: name})
+		case req.PropName != nil:
+			found = append(found, prop{XMLName: name})
+		default:
+			found = append(found, p)
+		}
+	}
+
+	if len(found) > 0 {
+		resp.Propstats = append(resp.Propstats, propstat{Prop: propList{found}, Status: statusOK})
+	}
+
+	if len(missing) > 0 {
+		resp.Propstats = append(resp.Propstats, propstat{Prop: propList{missing}, Status: statusNotFound})
+	}
+
+	return resp
+}
+
+func hrefXML(href string) string {
+	return `<href xmlns="DAV:">` + escapeXML(href) + `</href>`
+}
+
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+
+	_ = xml.EscapeText(&buf, []byte(s))
+
+	return buf.String()
+}
+
+func privilegesXML(privileges ...string) string {
+	var s string
+
+	for _, p := range privileges {
+		s += `<privilege xmlns="DAV:"><` + p + ` xmlns="DAV:"/></privilege>`
+	}
+
+	return s
+}
+
+func homeResource() resource {
+	return resource{href: Prefix, props: []prop{
+		davProp("resourcetype", `<collection xmlns="DAV:"/><principal xmlns="DAV:"/>`),
+		davProp("displayname", "frens"),
+		davProp("current-user-principal", hrefXML(Prefix)),
+		davProp("principal-URL", hrefXML(Prefix)),
+		davProp("current-user-privilege-set", privilegesXML("read")),
+		cardDAVProp("addressbook-home-set", hrefXML(Prefix)),
+	}}
+}
+
+func bookResource(cards []card) resource {
+	reports := ""
+
+	for _, r := range []xml.Name{reportMultiget, reportQuery} {
+		reports += `<supported-report xmlns="DAV:"><report xmlns="DAV:"><` + r.Local + ` xmlns="` + r.Space +
+			`"/></report></supported-report>`
+	}
+
+	tag := ctag(cards)
+
+	return resource{href: BookPath, props: []prop{
+		davProp("resourcetype", `<collection xmlns="DAV:"/><addressbook xmlns="`+nsCardDAV+`"/>`),
+		davProp("displayname", "Friends"),
+		davProp("getetag", escapeXML(tag)),
+		davProp("current-user-principal", hrefXML(Prefix)),
+		davProp("current-user-privilege-set", privilegesXML("read", "write", "write-content", "bind", "unbind")),
+		davProp("supported-report-set", reports),
+		cardDAVProp("addressbook-description", "Friends from the frens journal"),
+		cardDAVProp("supported-address-data",
+			`<address-data-type xmlns="`+nsCardDAV+`" content-type="text/vcard" version="4.0"/>`),
+		cardDAVProp("max-resource-size", strconv.Itoa(maxCardSize)),
+		{XMLName: xml.Name{Space: nsCalServer, Local: "getctag"}, Value: escapeXML(tag)},
+	}}
+}
+
+func cardResource(c card) resource {
+	return resource{href: c.href(), props: []prop{
+		davProp("resourcetype", ""),
+		davProp("getetag", escapeXML(c.etag)),
+		davProp("getcontenttype", cardContentType),
+		davProp("getcontentlength", strconv.Itoa(len(c.data))),
+		{XMLName: propAddressData, Value: escapeXML(string(c.data))},
+	}}
+}
+
+func readXML(r *http.Request, w http.ResponseWriter, v any) error {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCardSize))
+	if err != nil {
+		return err
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return io.EOF
+	}
+
+	return xml.Unmarshal(data, v)
+}
+
+func (h *Handler) handlePropfind(w http.ResponseWriter, r *http.Request) {
+	var req propRequest
+
+	// an empty request asks for all properties
+	if err := readXML(r, w, &req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "invalid PROPFIND request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	p := cleanPath(r.URL.Path)
+	children := r.Header.Get("Depth") != "0"
+
+	var resources []resource
+
+	err := h.store.Tx(r.Context(), func(jr *journal.Journal) error {
+		cards, err := listCards(jr)
+		if err != nil {
+			return err
+		}
+
+		switch p {
+		case Prefix:
+			resources = append(resources, homeResource())
+
+			if children {
+				resources = append(resources, bookResource(cards))
+			}
+		case BookPath:
+			resources = append(resources, bookResource(cards))
+
+			if children {
+				for _, c := range cards {
+					resources = append(resources, cardResource(c))
+				}
+			}
+		default:
+			id, _ := cardID(p)
+
+			for _, c := range cards {
+				if c.id == id {
+					resources = append(resources, cardResource(c))
+				}
+			}
+
+			if len(resources) == 0 {
+				return errNotFound
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	responses := make([]response, 0, len(resources))
+
+	for _, res := range resources {
+		responses = append(responses, res.response(req))
+	}
+
+	writeMultistatus(w, responses)
+}
+
+func (h *Handler) handleReport(w http.ResponseWriter, r *http.Request) {
+	var req reportRequest
+
+	if err := readXML(r, w, &req); err != nil {
+		http.Error(w, "invalid REPORT request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.XMLName != reportMultiget && req.XMLName != reportQuery {
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, xml.Header+`<error xmlns="DAV:"><supported-report/></error>`)
+
+		return
+	}
+
+	var responses []response
+
+	err := h.store.Tx(r.Context(), func(jr *journal.Journal) error {
+		cards, err := listCards(jr)
+		if err != nil {
+			return err
+		}
+
+		// address books are small, so queries return all cards and clients filter them on their side
+		if req.XMLName == reportQuery {
+			for _, c := range cards {
+				responses = append(responses, cardResource(c).response(req.propRequest))
+			}
+
+			return nil
+		}
+
+		for _, href := range req.Hrefs {
+			responses = append(responses, multigetResponse(cards, href, req.propRequest))
+		}
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMultistatus(w, responses)
+}
+
+func multigetResponse(cards []card, href string, req propRequest) response {
+	// clients may send full URLs
+	if u, err := url.Parse(href); err == nil {
+		href = u.Path
+	}
+
+	if id, ok := cardID(cleanPath(href)); ok {
+		for _, c := range cards {
+			if c.id == id {
+				return cardResource(c).response(req)
+			}
+		}
+	}
+
+	return response{Href: href, Status: statusNotFound}
+}
+
+func writeMultistatus(w http.ResponseWriter, responses []response) {
+	data, err := xml.Marshal(multistatus{Responses: responses})
+	if err != nil {
+		http.Error(w, "failed to encode the response: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+
+	_, _ = io.WriteString(w, xml.Header)
+	_, _ = w.Write(data)
+}
@@ -14,4 +14,166 @@
 
 package config
 
-type Config struct{}
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// FileName is the name of the journal-level settings file kept in the journal directory
+const FileName = "frens.toml"
+
+type StoreType = string
+
+var (
+	StoreTypeTOML   StoreType = "toml"
+	StoreTypeSQLite StoreType = "sqlite"
+)
+
+var StoreTypes = []StoreType{
+	StoreTypeTOML,
+	StoreTypeSQLite,
+}
+
+// DefaultPushDelay is how long auto-sync waits for more changes before pushing them in the background
+const DefaultPushDelay = 30 * time.Second
+
+// Config holds journal-level settings
+type Config struct {
+	Store StoreConfig `toml:"store"`
+	Sync  SyncConfig  `toml:"sync,omitempty"`
+	Match MatchConfig `toml:"match,omitempty"`
+}
+
+type StoreConfig struct {
+	Type StoreType `toml:"type,omitempty"`
+	// LockTimeout is how long to wait for other processes to release the journal (e.g. "10s")
+	LockTimeout string `toml:"lock_timeout,omitempty"`
+	// UndoDepth is how many transactions are kept to undo, zero turns undo off. Unset means the default depth.
+	UndoDepth *int `toml:"undo_depth,omitempty"`
+}
+
+// LockTimeoutDuration returns the configured journal lock timeout or zero if the default one should be used
+func (c StoreConfig) LockTimeoutDuration() (time.Duration, error) {
+	if c.LockTimeout == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(c.LockTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid store lock timeout '%s': %w", c.LockTimeout, err)
+	}
+
+	if d < 0 {
+		return 0, fmt.Errorf("invalid store lock timeout '%s': must not be negative", c.LockTimeout)
+	}
+
+	return d, nil
+}
+
+type MatchConfig struct {
+	// Fuzzy lets friends and locations be looked up by names with typos (e.g. "frens friend get Micheal").
+	// Friends and locations mentioned in events are recorded only when their names are certain anyway.
+	Fuzzy bool `toml:"fuzzy,omitempty"`
+}
+
+type SyncConfig struct {
+	// Auto commits every change of the journal and pushes them in the background
+	Auto bool `toml:"auto,omitempty"`
+	// PushDelay is how long to wait for more changes before pushing them in the background (e.g. "1m")
+	PushDelay string `toml:"push_delay,omitempty"`
+}
+
+// PushDelayDuration returns the configured push delay falling back to the default one
+func (c SyncConfig) PushDelayDuration() (time.Duration, error) {
+	if c.PushDelay == "" {
+		return DefaultPushDelay, nil
+	}
+
+	d, err := time.ParseDuration(c.PushDelay)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sync push delay '%s': %w", c.PushDelay, err)
+	}
+
+	if d < 0 {
+		return 0, fmt.Errorf("invalid sync push delay '%s': must not be negative", c.PushDelay)
+	}
+
+	return d, nil
+}
+
+// StoreType returns the configured journal store type falling back to TOML files
+func (c Config) StoreType() StoreType {
+	if c.Store.Type == "" {
+		return StoreTypeTOML
+	}
+
+	return c.Store.Type
+}
+
+func ValidateStoreType(t string) error {
+	for _, st := range StoreTypes {
+		if t == st {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported store type '%s' (supported: toml, sqlite)", t)
+}
+
+// Load reads journal settings from the journal directory. Missing settings file means default settings.
+func Load(jDir string) (Config, error) {
+	var cfg Config
+
+	path := filepath.Join(jDir, FileName)
+
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Config{}, nil
+		}
+
+		return Config{}, fmt.Errorf("failed to load journal settings from %s: %w", path, err)
+	}
+
+	if cfg.Store.Type != "" {
+		if err := ValidateStoreType(cfg.Store.Type); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if _, err := cfg.Store.LockTimeoutDuration(); err != nil {
+		return Config{}, err
+	}
+
+	if _, err := cfg.Sync.PushDelayDuration(); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.Store.UndoDepth != nil && *cfg.Store.UndoDepth < 0 {
+		return Config{}, fmt.Errorf("invalid store undo depth %d: must not be negative", *cfg.Store.UndoDepth)
+	}
+
+	return cfg, nil
+}
+
+// Save writes journal settings into the journal directory
+func Save(jDir string, cfg Config) error {
+	path := filepath.Join(jDir, FileName)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create journal settings file %s: %w", path, err)
+	}
+
+	if err := toml.NewEncoder(f).Encode(cfg); err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("failed to encode journal settings: %w", err)
+	}
+
+	return f.Close()
+}
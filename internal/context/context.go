@@ -19,12 +19,15 @@ import (
 
 	"github.com/roma-glushko/frens/internal/log"
 	"github.com/roma-glushko/frens/internal/store"
+	"github.com/roma-glushko/frens/internal/sync"
 )
 
 type AppContext struct {
 	JournalDir string
 	Store      store.Store
 	Printer    log.Printer
+	// AutoSync is set when the journal commits its changes on its own
+	AutoSync *sync.AutoSync
 }
 
 type ctxKey struct{}
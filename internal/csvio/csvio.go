@@ -0,0 +1,451 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package csvio reads and writes journal records as CSV tables, so they can be reviewed and edited in spreadsheets
+package csvio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/tag"
+)
+
+const (
+	idField = "id"
+	listSep = ","
+	// timeFormat is easy to read and to edit in spreadsheets
+	timeFormat = "2006-01-02 15:04:05"
+)
+
+var (
+	ErrNoHeader      = errors.New("CSV file has no header")
+	ErrUnknownField  = errors.New("unknown field")
+	ErrMissingColumn = errors.New("column not found")
+	ErrInvalidTime   = errors.New("invalid time")
+	ErrFriendChanged = errors.New("records can't be moved to another friend, delete and add them instead")
+)
+
+// timeFormats are accepted on import, as spreadsheets tend to reformat dates
+var timeFormats = []string{
+	timeFormat,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+}
+
+// Column is a CSV column that holds a field of the record
+type Column[T any] struct {
+	Name string
+	Get  func(T) string
+	// Set parses the cell into the record. Columns without it are exported, but ignored on import.
+	Set func(*T, string) error
+}
+
+// Entity describes how records of one type are kept in CSV tables and applied to the journal
+type Entity[T any] struct {
+	Name    string
+	Columns []Column[T]
+	// Find returns the record with the ID, so rows update only fields of the columns they have
+	Find func(jr *journal.Journal, id string) (T, bool)
+	// Apply adds the record to the journal or updates the original one, if it's given
+	Apply func(jr *journal.Journal, o *T, n T) (T, error)
+	// Label names the record in import previews
+	Label func(T) string
+}
+
+// Fields lists names of the entity columns
+func (e Entity[T]) Fields() []string {
+	fields := make([]string, 0, len(e.Columns))
+
+	for _, col := range e.Columns {
+		fields = append(fields, col.Name)
+	}
+
+	return fields
+}
+
+// Encode writes records as a CSV table with a header of field names
+func Encode[T any](w io.Writer, e Entity[T], records []T) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(e.Fields()); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	row := make([]string, len(e.Columns))
+
+	for _, rec := range records {
+		for i, col := range e.Columns {
+			row[i] = col.Get(rec)
+		}
+
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write %s: %w", e.Label(rec), err)
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return nil
+}
+
+// Mapping maps field names to CSV columns. Fields that are not mapped are read from columns of the same name.
+type Mapping map[string]string
+
+// ParseMapping parses field mappings like "name=Full Name"
+func ParseMapping(specs []string) (Mapping, error) {
+	m := make(Mapping, len(specs))
+
+	for _, spec := range specs {
+		field, column, ok := strings.Cut(spec, "=")
+		if !ok || strings.TrimSpace(field) == "" || strings.TrimSpace(column) == "" {
+			return nil, fmt.Errorf("invalid column mapping '%s' (expected: FIELD=COLUMN)", spec)
+		}
+
+		m[strings.ToLower(strings.TrimSpace(field))] = strings.TrimSpace(column)
+	}
+
+	return m, nil
+}
+
+// Action is what importing a row does to the journal
+type Action string
+
+const (
+	ActionAdd    Action = "add"
+	ActionUpdate Action = "update"
+	// ActionKeep is for rows that are the same as their records, so they don't change the journal
+	ActionKeep Action = "keep"
+)
+
+// Change is the outcome of importing a row
+type Change struct {
+	Line   int
+	Action Action
+	Label  string
+	Err    error
+}
+
+// Result lists changes made by the import
+type Result struct {
+	Changes []Change
+	// Ignored are columns that don't hold any field
+	Ignored []string
+}
+
+// Count returns the number of rows successfully imported with the action
+func (r Result) Count(a Action) int {
+	n := 0
+
+	for _, c := range r.Changes {
+		if c.Err == nil && c.Action == a {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Failed returns rows that couldn't be imported
+func (r Result) Failed() []Change {
+	var failed []Change
+
+	for _, c := range r.Changes {
+		if c.Err != nil {
+			failed = append(failed, c)
+		}
+	}
+
+	return failed
+}
+
+// Import applies rows of the CSV table to the journal.
+// Rows with IDs of existing records update them, other rows add new records.
+// Rows that fail are reported in the result and don't stop the import.
+func Import[T any](jr *journal.Journal, e Entity[T], r io.Reader, m Mapping) (Result, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return Result{}, ErrNoHeader
+	}
+
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	cols, ignored, err := e.columnIndex(header, m)
+	if err != nil {
+		return Result{}, err
+	}
+
+	res := Result{Ignored: ignored}
+
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return res, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		if isBlank(row) {
+			continue
+		}
+
+		res.Changes = append(res.Changes, e.importRow(jr, cols, row, line))
+	}
+
+	return res, nil
+}
+
+// cell is a column found in the CSV header
+type cell[T any] struct {
+	Column[T]
+	index int
+}
+
+// columnIndex finds positions of the entity columns in the header
+func (e Entity[T]) columnIndex(header []string, m Mapping) ([]cell[T], []string, error) {
+	for field := range m {
+		if !e.hasField(field) {
+			return nil, nil, fmt.Errorf("%w '%s' of %s (supported: %s)",
+				ErrUnknownField, field, e.Name, strings.Join(e.Fields(), ", "))
+		}
+	}
+
+	if len(header) > 0 {
+		// spreadsheet apps often start UTF-8 files with the byte order mark
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	cols := make([]cell[T], 0, len(e.Columns))
+	used := make(map[int]bool, len(e.Columns))
+
+	for _, col := range e.Columns {
+		name, mapped := m[col.Name]
+		if !mapped {
+			name = col.Name
+		}
+
+		i := headerIndex(header, name)
+
+		if i < 0 && mapped {
+			return nil, nil, fmt.Errorf("%w: %s", ErrMissingColumn, name)
+		}
+
+		if i >= 0 && col.Set != nil {
+			cols = append(cols, cell[T]{Column: col, index: i})
+			used[i] = true
+		}
+	}
+
+	var ignored []string
+
+	for i, h := range header {
+		if !used[i] && !e.hasField(h) {
+			ignored = append(ignored, h)
+		}
+	}
+
+	return cols, ignored, nil
+}
+
+func (e Entity[T]) hasField(name string) bool {
+	for _, col := range e.Columns {
+		if strings.EqualFold(col.Name, strings.TrimSpace(name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e Entity[T]) importRow(jr *journal.Journal, cols []cell[T], row []string, line int) Change {
+	var (
+		rec T
+		o   *T
+	)
+
+	for _, col := range cols {
+		if col.Name != idField || col.index >= len(row) {
+			continue
+		}
+
+		if id := strings.TrimSpace(row[col.index]); id != "" {
+			if existing, ok := e.Find(jr, id); ok {
+				rec = existing
+				o = &existing
+			}
+		}
+	}
+
+	change := Change{Line: line, Action: ActionAdd}
+
+	if o != nil {
+		change.Action = ActionUpdate
+	}
+
+	for _, col := range cols {
+		value := ""
+
+		if col.index < len(row) {
+			value = strings.TrimSpace(row[col.index])
+		}
+
+		if err := col.Set(&rec, value); err != nil {
+			change.Label = e.Label(rec)
+			change.Err = fmt.Errorf("invalid %s: %w", col.Name, err)
+
+			return change
+		}
+	}
+
+	if o != nil && e.same(cols, *o, rec) {
+		change.Action = ActionKeep
+		change.Label = e.Label(rec)
+
+		return change
+	}
+
+	applied, err := e.Apply(jr, o, rec)
+	if err != nil {
+		change.Label = e.Label(rec)
+		change.Err = err
+
+		return change
+	}
+
+	change.Label = e.Label(applied)
+
+	return change
+}
+
+// same tells whether the row holds the same values as the record, as they are formatted for CSV
+func (e Entity[T]) same(cols []cell[T], o, n T) bool {
+	for _, col := range cols {
+		if col.Get(o) != col.Get(n) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func headerIndex(header []string, name string) int {
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isBlank(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func joinList(list []string) string {
+	return strings.Join(list, listSep+" ")
+}
+
+func splitList(s string) []string {
+	var list []string
+
+	for _, item := range strings.Split(s, listSep) {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+func splitTags(s string) []string {
+	var tags []string
+
+	for _, t := range splitList(s) {
+		tags = append(tags, tag.NewTag(t).Name)
+	}
+
+	return tags
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(timeFormat)
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range timeFormats {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w '%s' (expected: %s)", ErrInvalidTime, s, timeFormat)
+}
+
+func formatFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+func parseFloat(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number '%s'", s)
+	}
+
+	return &f, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csvio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/stretchr/testify/require"
+)
+
+func newJournal(t *testing.T) *journal.Journal {
+	t.Helper()
+
+	jr := &journal.Journal{}
+	jr.Init()
+
+	jr.AddFriend(friend.Person{
+		ID:        "jim-halpert",
+		Name:      "Jim Halpert",
+		Nicknames: []string{"Big Tuna"},
+		Tags:      []string{"office"},
+		Contacts:  []*friend.Contact{{ID: "c1", Type: friend.ContactTypeEmail, Value: "jim@dundermifflin.com"}},
+		CreatedAt: time.Date(2005, 3, 24, 0, 0, 0, 0, time.UTC),
+	})
+
+	return jr
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	jr := newJournal(t)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, Encode(&buf, Friends, []friend.Person{*jr.Friends[0]}))
+	require.Equal(t, "id,name,nicknames,description,tags,locations,created_at\n"+
+		"jim-halpert,Jim Halpert,Big Tuna,,office,,2005-03-24 00:00:00\n", buf.String())
+
+	contacts, err := jr.ListFriendContacts(friend.ListContactQuery{})
+	require.NoError(t, err)
+
+	buf.Reset()
+
+	require.NoError(t, Encode(&buf, Contacts, contacts))
+	require.Equal(t, "id,friend,type,value,tags\nc1,jim-halpert,email,jim@dundermifflin.com,\n", buf.String())
+}
+
+func TestImport_Friends(t *testing.T) {
+	t.Parallel()
+
+	jr := newJournal(t)
+
+	m, err := ParseMapping([]string{"name=Full Name", "nicknames=Also known as"})
+	require.NoError(t, err)
+
+	res, err := Import(jr, Friends, strings.NewReader("\ufeffID,Full Name,Also known as,Tags,Phone\n"+
+		"jim-halpert,Jim Halpert,\"Big Tuna, Jimbo\",\"office, #Sales\",\n"+
+		",Pam Beesly,,office,555-1234\n"+
+		",,,,\n"+
+		",,Nameless,,\n",
+	), m)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"Phone"}, res.Ignored)
+	require.Len(t, res.Changes, 3)
+	require.Equal(t, 1, res.Count(ActionAdd))
+	require.Equal(t, 1, res.Count(ActionUpdate))
+
+	failed := res.Failed()
+	require.Len(t, failed, 1)
+	require.Equal(t, 5, failed[0].Line)
+	require.ErrorIs(t, failed[0].Err, friend.ErrFriendNameEmpty)
+
+	jim, err := jr.GetFriend("jim")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Big Tuna", "Jimbo"}, jim.Nicknames)
+	require.Equal(t, []string{"office", "sales"}, jim.Tags)
+	// fields without columns and nested records are kept
+	require.Equal(t, 2005, jim.CreatedAt.Year())
+	require.Len(t, jim.Contacts, 1)
+
+	pam, err := jr.GetFriend("pam")
+	require.NoError(t, err)
+	require.Equal(t, "pam-beesly", pam.ID)
+
+	_, err = Import(jr, Friends, strings.NewReader("name\n"), Mapping{"nickname": "Nick"})
+	require.ErrorIs(t, err, ErrUnknownField)
+
+	_, err = Import(jr, Friends, strings.NewReader("name\n"), Mapping{"name": "Full Name"})
+	require.ErrorIs(t, err, ErrMissingColumn)
+
+	_, err = Import(jr, Friends, strings.NewReader(""), nil)
+	require.ErrorIs(t, err, ErrNoHeader)
+}
+
+func TestImport_Contacts(t *testing.T) {
+	t.Parallel()
+
+	jr := newJournal(t)
+	jr.AddFriend(friend.Person{ID: "pam-beesly", Name: "Pam Beesly"})
+
+	res, err := Import(jr, Contacts, strings.NewReader("id,friend,type,value\n"+
+		"c1,jim,email,jim.halpert@dundermifflin.com\n"+
+		",Pam,,+1 570 555 0101\n"+
+		"c1,pam,email,pam@dundermifflin.com\n"+
+		",jim,fax,555-1234\n",
+	), nil)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, res.Count(ActionAdd))
+	require.Equal(t, 1, res.Count(ActionUpdate))
+
+	failed := res.Failed()
+	require.Len(t, failed, 2)
+	require.ErrorIs(t, failed[0].Err, ErrFriendChanged)
+	require.ErrorContains(t, failed[1].Err, "invalid type")
+
+	c, err := jr.GetFriendContact("c1")
+	require.NoError(t, err)
+	require.Equal(t, "jim.halpert@dundermifflin.com", c.Value)
+
+	contacts, err := jr.ListFriendContacts(friend.ListContactQuery{Friends: []string{"pam-beesly"}})
+	require.NoError(t, err)
+	require.Len(t, contacts, 1)
+	require.Equal(t, friend.ContactTypePhone, contacts[0].Type)
+}
+
+func TestImport_Events(t *testing.T) {
+	t.Parallel()
+
+	jr := newJournal(t)
+
+	res, err := Import(jr, Activities, strings.NewReader("date,description,friends,tags\n"+
+		"2024-12-25,Christmas party with Jim,pam-beesly,party\n"+
+		"25/12/2024,Secret Santa,,\n",
+	), nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Count(ActionAdd))
+	require.ErrorIs(t, res.Failed()[0].Err, ErrInvalidTime)
+
+	require.Len(t, jr.Activities, 1)
+
+	act := jr.Activities[0]
+	require.Equal(t, time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC), act.Date)
+	require.Equal(t, []string{"jim-halpert"}, act.FriendIDs)
+	require.Equal(t, []string{"party"}, act.Tags)
+}
+
+func TestImport_UnchangedEvents(t *testing.T) {
+	t.Parallel()
+
+	jr := newJournal(t)
+
+	_, err := jr.AddEvent(friend.Event{Type: friend.EventTypeActivity, Date: time.Now(), Desc: "Dinner with Jim Halpert"})
+	require.NoError(t, err)
+	require.Equal(t, 1, jr.Friends[0].Activities)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, Encode(&buf, Activities, []friend.Event{*jr.Activities[0]}))
+
+	exported := buf.String()
+
+	// importing the exported table again doesn't change anything
+	for range 2 {
+		res, err := Import(jr, Activities, strings.NewReader(exported), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Count(ActionKeep))
+		require.Equal(t, 1, jr.Friends[0].Activities)
+	}
+
+	res, err := Import(jr, Activities, strings.NewReader(strings.Replace(exported, "Dinner", "Lunch", 1)), nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Count(ActionUpdate))
+	require.Equal(t, "Lunch with Jim Halpert", jr.Activities[0].Desc)
+	require.Equal(t, 1, jr.Friends[0].Activities)
+}
@@ -0,0 +1,526 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csvio
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/roma-glushko/frens/internal/calendar"
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/lang"
+)
+
+var Friends = Entity[friend.Person]{
+	Name: "friends",
+	Columns: []Column[friend.Person]{
+		{
+			Name: idField,
+			Get:  func(p friend.Person) string { return p.ID },
+			Set:  func(p *friend.Person, v string) error { p.ID = v; return nil },
+		},
+		{
+			Name: "name",
+			Get:  func(p friend.Person) string { return p.Name },
+			Set:  func(p *friend.Person, v string) error { p.Name = v; return nil },
+		},
+		{
+			Name: "nicknames",
+			Get:  func(p friend.Person) string { return joinList(p.Nicknames) },
+			Set:  func(p *friend.Person, v string) error { p.Nicknames = splitList(v); return nil },
+		},
+		{
+			Name: "description",
+			Get:  func(p friend.Person) string { return p.Desc },
+			Set:  func(p *friend.Person, v string) error { p.Desc = v; return nil },
+		},
+		{
+			Name: "tags",
+			Get:  func(p friend.Person) string { return joinList(p.Tags) },
+			Set:  func(p *friend.Person, v string) error { p.Tags = splitTags(v); return nil },
+		},
+		{
+			Name: "locations",
+			Get:  func(p friend.Person) string { return joinList(p.Locations) },
+			Set:  func(p *friend.Person, v string) error { p.Locations = splitList(v); return nil },
+		},
+		{
+			Name: "created_at",
+			Get:  func(p friend.Person) string { return formatTime(p.CreatedAt) },
+			Set: func(p *friend.Person, v string) (err error) {
+				p.CreatedAt, err = parseTime(v)
+				return err
+			},
+		},
+	},
+	Find: func(jr *journal.Journal, id string) (friend.Person, bool) {
+		for _, f := range jr.Friends {
+			if f.ID == id {
+				return *f, true
+			}
+		}
+
+		return friend.Person{}, false
+	},
+	Apply: func(jr *journal.Journal, o *friend.Person, n friend.Person) (friend.Person, error) {
+		if err := n.Validate(); err != nil {
+			return n, err
+		}
+
+		if o != nil {
+			jr.UpdateFriend(*o, n)
+			return n, nil
+		}
+
+		if n.ID == "" {
+			n.ID = slug.Make(n.Name)
+		}
+
+		for _, f := range jr.Friends {
+			if f.ID == n.ID {
+				return n, fmt.Errorf("friend with ID %s already exists, set it in the id column to update the friend", n.ID)
+			}
+		}
+
+		jr.AddFriend(n)
+
+		return n, nil
+	},
+	Label: func(p friend.Person) string { return p.Name },
+}
+
+var Locations = Entity[friend.Location]{
+	Name: "locations",
+	Columns: []Column[friend.Location]{
+		{
+			Name: idField,
+			Get:  func(l friend.Location) string { return l.ID },
+			Set:  func(l *friend.Location, v string) error { l.ID = v; return nil },
+		},
+		{
+			Name: "name",
+			Get:  func(l friend.Location) string { return l.Name },
+			Set:  func(l *friend.Location, v string) error { l.Name = v; return nil },
+		},
+		{
+			Name: "country",
+			Get:  func(l friend.Location) string { return l.Country },
+			Set:  func(l *friend.Location, v string) error { l.Country = v; return nil },
+		},
+		{
+			Name: "description",
+			Get:  func(l friend.Location) string { return l.Desc },
+			Set:  func(l *friend.Location, v string) error { l.Desc = v; return nil },
+		},
+		{
+			Name: "aliases",
+			Get:  func(l friend.Location) string { return joinList(l.Aliases) },
+			Set:  func(l *friend.Location, v string) error { l.Aliases = splitList(v); return nil },
+		},
+		{
+			Name: "tags",
+			Get:  func(l friend.Location) string { return joinList(l.Tags) },
+			Set:  func(l *friend.Location, v string) error { l.Tags = splitTags(v); return nil },
+		},
+		{
+			Name: "lat",
+			Get:  func(l friend.Location) string { return formatFloat(l.Lat) },
+			Set: func(l *friend.Location, v string) (err error) {
+				l.Lat, err = parseFloat(v)
+				return err
+			},
+		},
+		{
+			Name: "lng",
+			Get:  func(l friend.Location) string { return formatFloat(l.Lng) },
+			Set: func(l *friend.Location, v string) (err error) {
+				l.Lng, err = parseFloat(v)
+				return err
+			},
+		},
+		{
+			Name: "created_at",
+			Get:  func(l friend.Location) string { return formatTime(l.CreatedAt) },
+			Set: func(l *friend.Location, v string) (err error) {
+				l.CreatedAt, err = parseTime(v)
+				return err
+			},
+		},
+	},
+	Find: func(jr *journal.Journal, id string) (friend.Location, bool) {
+		for _, l := range jr.Locations {
+			if l.ID == id {
+				return *l, true
+			}
+		}
+
+		return friend.Location{}, false
+	},
+	Apply: func(jr *journal.Journal, o *friend.Location, n friend.Location) (friend.Location, error) {
+		if err := n.Validate(); err != nil {
+			return n, err
+		}
+
+		if o != nil {
+			jr.UpdateLocation(*o, n)
+			return n, nil
+		}
+
+		if n.ID == "" {
+			n.ID = slug.Make(n.Name)
+		}
+
+		for _, l := range jr.Locations {
+			if l.ID == n.ID {
+				return n, fmt.Errorf("location with ID %s already exists, set it in the id column to update the location", n.ID)
+			}
+		}
+
+		jr.AddLocation(n)
+
+		return n, nil
+	},
+	Label: func(l friend.Location) string { return l.Name },
+}
+
+var (
+	Activities = events(friend.EventTypeActivity)
+	Notes      = events(friend.EventTypeNote)
+)
+
+// events describes activities or notes.
+// Friends of imported events are recognized in their descriptions, like for events added by commands,
+// so the friends column is exported only.
+func events(t friend.EventType) Entity[friend.Event] {
+	name := "activities"
+
+	if t == friend.EventTypeNote {
+		name = "notes"
+	}
+
+	return Entity[friend.Event]{
+		Name: name,
+		Columns: []Column[friend.Event]{
+			{
+				Name: idField,
+				Get:  func(e friend.Event) string { return e.ID },
+				Set:  func(e *friend.Event, v string) error { e.ID = v; return nil },
+			},
+			{
+				Name: "date",
+				Get:  func(e friend.Event) string { return formatTime(e.Date) },
+				Set: func(e *friend.Event, v string) (err error) {
+					e.Date, err = parseTime(v)
+					return err
+				},
+			},
+			{
+				Name: "description",
+				Get:  func(e friend.Event) string { return e.Desc },
+				Set:  func(e *friend.Event, v string) error { e.Desc = v; return nil },
+			},
+			{
+				Name: "friends",
+				Get:  func(e friend.Event) string { return joinList(e.FriendIDs) },
+			},
+			{
+				Name: "locations",
+				Get:  func(e friend.Event) string { return joinList(e.LocationIDs) },
+				Set:  func(e *friend.Event, v string) error { e.LocationIDs = splitList(v); return nil },
+			},
+			{
+				Name: "tags",
+				Get:  func(e friend.Event) string { return joinList(e.Tags) },
+				Set:  func(e *friend.Event, v string) error { e.Tags = splitTags(v); return nil },
+			},
+		},
+		Find: func(jr *journal.Journal, id string) (friend.Event, bool) {
+			e, err := jr.GetEvent(t, id)
+
+			return e, err == nil
+		},
+		Apply: func(jr *journal.Journal, o *friend.Event, n friend.Event) (friend.Event, error) {
+			n.Type = t
+
+			if err := n.Validate(); err != nil {
+				return n, err
+			}
+
+			if n.Date.IsZero() {
+				n.Date = time.Now().UTC()
+			}
+
+			// friends are guessed from the description again
+			n.FriendIDs = nil
+
+			if o != nil {
+				return jr.UpdateEvent(*o, n)
+			}
+
+			return jr.AddEvent(n)
+		},
+		Label: func(e friend.Event) string { return e.Desc },
+	}
+}
+
+var Contacts = Entity[friend.Contact]{
+	Name: "contacts",
+	Columns: []Column[friend.Contact]{
+		{
+			Name: idField,
+			Get:  func(c friend.Contact) string { return c.ID },
+			Set:  func(c *friend.Contact, v string) error { c.ID = v; return nil },
+		},
+		{
+			Name: "friend",
+			Get:  func(c friend.Contact) string { return c.Person },
+			Set:  func(c *friend.Contact, v string) error { c.Person = v; return nil },
+		},
+		{
+			Name: "type",
+			Get:  func(c friend.Contact) string { return string(c.Type) },
+			Set: func(c *friend.Contact, v string) error {
+				if v == "" {
+					c.Type = ""
+					return nil
+				}
+
+				if err := friend.ValidateContactType(v); err != nil {
+					return err
+				}
+
+				c.Type = friend.ParseContactType(v)
+
+				return nil
+			},
+		},
+		{
+			Name: "value",
+			Get:  func(c friend.Contact) string { return c.Value },
+			Set:  func(c *friend.Contact, v string) error { c.Value = v; return nil },
+		},
+		{
+			Name: "tags",
+			Get:  func(c friend.Contact) string { return joinList(c.Tags) },
+			Set:  func(c *friend.Contact, v string) error { c.Tags = splitTags(v); return nil },
+		},
+	},
+	Find: func(jr *journal.Journal, id string) (friend.Contact, bool) {
+		c, err := jr.GetFriendContact(id)
+
+		return c, err == nil
+	},
+	Apply: func(jr *journal.Journal, o *friend.Contact, n friend.Contact) (friend.Contact, error) {
+		if n.Type == "" && n.Value != "" {
+			// detect the type by the value, as for contacts added by commands
+			detected, err := lang.ExtractContact(n.Value)
+			if err != nil {
+				return n, err
+			}
+
+			n.Type, n.Value = detected.Type, detected.Value
+		}
+
+		if err := n.Validate(); err != nil {
+			return n, err
+		}
+
+		if o != nil {
+			if err := sameFriend(jr, n.Person, o.Person); err != nil {
+				return n, err
+			}
+
+			return jr.UpdateFriendContact(*o, n)
+		}
+
+		if n.Person == "" {
+			return n, errFriendEmpty
+		}
+
+		return jr.AddFriendContact(n.Person, n)
+	},
+	Label: func(c friend.Contact) string { return c.Person + ": " + c.Value },
+}
+
+var Dates = Entity[friend.Date]{
+	Name: "dates",
+	Columns: []Column[friend.Date]{
+		{
+			Name: idField,
+			Get:  func(d friend.Date) string { return d.ID },
+			Set:  func(d *friend.Date, v string) error { d.ID = v; return nil },
+		},
+		{
+			Name: "friend",
+			Get:  func(d friend.Date) string { return d.Person },
+			Set:  func(d *friend.Date, v string) error { d.Person = v; return nil },
+		},
+		{
+			Name: "date",
+			Get:  func(d friend.Date) string { return d.DateExpr },
+			Set:  func(d *friend.Date, v string) error { d.DateExpr = v; return nil },
+		},
+		{
+			Name: "calendar",
+			Get:  func(d friend.Date) string { return d.Calendar },
+			Set:  func(d *friend.Date, v string) error { d.Calendar = v; return nil },
+		},
+		{
+			Name: "description",
+			Get:  func(d friend.Date) string { return d.Desc },
+			Set:  func(d *friend.Date, v string) error { d.Desc = v; return nil },
+		},
+		{
+			Name: "tags",
+			Get:  func(d friend.Date) string { return joinList(d.Tags) },
+			Set:  func(d *friend.Date, v string) error { d.Tags = splitTags(v); return nil },
+		},
+	},
+	Find: func(jr *journal.Journal, id string) (friend.Date, bool) {
+		d, err := jr.GetFriendDate(id)
+
+		return d, err == nil
+	},
+	Apply: func(jr *journal.Journal, o *friend.Date, n friend.Date) (friend.Date, error) {
+		if n.Calendar == "" {
+			n.Calendar = friend.CalendarGregorian
+		}
+
+		if err := n.Validate(); err != nil {
+			return n, err
+		}
+
+		if _, err := calendar.Parse(n); err != nil {
+			return n, err
+		}
+
+		if o != nil {
+			if err := sameFriend(jr, n.Person, o.Person); err != nil {
+				return n, err
+			}
+
+			return jr.UpdateFriendDate(*o, n)
+		}
+
+		if n.Person == "" {
+			return n, errFriendEmpty
+		}
+
+		return jr.AddFriendDate(n.Person, n)
+	},
+	Label: func(d friend.Date) string { return d.Person + ": " + d.DateExpr },
+}
+
+var Wishlist = Entity[friend.WishlistItem]{
+	Name: "wishlist items",
+	Columns: []Column[friend.WishlistItem]{
+		{
+			Name: idField,
+			Get:  func(w friend.WishlistItem) string { return w.ID },
+			Set:  func(w *friend.WishlistItem, v string) error { w.ID = v; return nil },
+		},
+		{
+			Name: "friend",
+			Get:  func(w friend.WishlistItem) string { return w.Person },
+			Set:  func(w *friend.WishlistItem, v string) error { w.Person = v; return nil },
+		},
+		{
+			Name: "description",
+			Get:  func(w friend.WishlistItem) string { return w.Desc },
+			Set:  func(w *friend.WishlistItem, v string) error { w.Desc = v; return nil },
+		},
+		{
+			Name: "link",
+			Get:  func(w friend.WishlistItem) string { return w.Link },
+			Set:  func(w *friend.WishlistItem, v string) error { w.Link = v; return nil },
+		},
+		{
+			Name: "price",
+			Get:  func(w friend.WishlistItem) string { return w.Price },
+			Set:  func(w *friend.WishlistItem, v string) error { w.Price = v; return nil },
+		},
+		{
+			Name: "tags",
+			Get:  func(w friend.WishlistItem) string { return joinList(w.Tags) },
+			Set:  func(w *friend.WishlistItem, v string) error { w.Tags = splitTags(v); return nil },
+		},
+		{
+			Name: "created_at",
+			Get:  func(w friend.WishlistItem) string { return formatTime(w.CreatedAt) },
+			Set: func(w *friend.WishlistItem, v string) (err error) {
+				w.CreatedAt, err = parseTime(v)
+				return err
+			},
+		},
+	},
+	Find: func(jr *journal.Journal, id string) (friend.WishlistItem, bool) {
+		w, err := jr.GetFriendWishlistItem(id)
+
+		return w, err == nil
+	},
+	Apply: func(jr *journal.Journal, o *friend.WishlistItem, n friend.WishlistItem) (friend.WishlistItem, error) {
+		if err := n.Validate(); err != nil {
+			return n, err
+		}
+
+		if o != nil {
+			if err := sameFriend(jr, n.Person, o.Person); err != nil {
+				return n, err
+			}
+
+			return jr.UpdateFriendWishlistItem(*o, n)
+		}
+
+		if n.Person == "" {
+			return n, errFriendEmpty
+		}
+
+		if n.CreatedAt.IsZero() {
+			n.CreatedAt = time.Now().UTC()
+		}
+
+		return jr.AddFriendWishlistItem(n.Person, n)
+	},
+	Label: func(w friend.WishlistItem) string {
+		if w.Desc == "" {
+			return w.Person + ": " + w.Link
+		}
+
+		return w.Person + ": " + w.Desc
+	},
+}
+
+var errFriendEmpty = errors.New("friend must be provided")
+
+// sameFriend checks that the friend reference of the updated record still points to its friend
+func sameFriend(jr *journal.Journal, ref, id string) error {
+	if ref == "" || ref == id {
+		return nil
+	}
+
+	f, err := jr.GetFriend(ref)
+	if err != nil {
+		return err
+	}
+
+	if f.ID != id {
+		return ErrFriendChanged
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ package friend
 
 import (
 	"errors"
+	"time"
 
 	"github.com/roma-glushko/frens/internal/tag"
 )
@@ -28,12 +29,22 @@ var (
 )
 
 type Date struct {
-	ID       string   `toml:"id"`
-	Calendar Calendar `toml:"calendar"`
-	DateExpr string   `toml:"date_expr"`
-	Desc     string   `toml:"desc"`
-	Tags     []string `toml:"tags"`
-	Person   string   `toml:"-"`
+	ID        string      `toml:"id"                  json:"id"`
+	Calendar  Calendar    `toml:"calendar"            json:"calendar"`
+	DateExpr  string      `toml:"date_expr"           json:"dateExpr"`
+	Desc      string      `toml:"desc"                json:"description,omitempty"`
+	Tags      []string    `toml:"tags"                json:"tags,omitempty"`
+	Reminders []*Reminder `toml:"reminders,omitempty" json:"reminders,omitempty"`
+	Person    string      `toml:"-"                   json:"person,omitempty"`
+	Next      *Occurrence `toml:"-"                   json:"next,omitempty"`
+}
+
+// Occurrence is the next time the date happens in the Gregorian calendar
+type Occurrence struct {
+	Date     time.Time `json:"date"`
+	DaysLeft int       `json:"daysLeft"`
+	// Years is the age or the number of years passed since the date, known only when the date has a year
+	Years int `json:"years,omitempty"`
 }
 
 func (d *Date) SetTags(tags []string) {
@@ -105,6 +105,7 @@ type ListLocationQuery struct {
 type ListEventQuery struct {
 	Type         EventType
 	Keyword      string
+	Friends      []string
 	Locations    []string
 	Tags         []string
 	Since, Until time.Time
@@ -130,3 +131,9 @@ type ListContactQuery struct {
 	Types   []ContactType
 	Tags    []string
 }
+
+type ListReminderQuery struct {
+	Keyword string
+	Friends []string
+	Tags    []string
+}
@@ -14,7 +14,14 @@
 
 package friend
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/tag"
+)
 
 type Recurrence = string
 
@@ -24,6 +31,26 @@ var (
 	RecurrenceYearly  Recurrence = "yearly"
 )
 
+var Recurrences = []Recurrence{
+	RecurrenceOnce,
+	RecurrenceMonthly,
+	RecurrenceYearly,
+}
+
+func ValidateRecurrence(s string) error {
+	for _, r := range Recurrences {
+		if strings.EqualFold(s, r) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"invalid recurrence '%s' (supported: %s)",
+		s,
+		strings.Join(Recurrences, ", "),
+	)
+}
+
 type OffsetDirection = string
 
 var (
@@ -31,8 +58,78 @@ var (
 	OffsetDirectionAfter  OffsetDirection = "after"
 )
 
+var ErrReminderDateEmpty = errors.New("reminder must be attached to a friend date or have a date")
+
+// Reminder notifies about a friend date (e.g. a birthday) or a standalone item.
+// Reminders attached to a friend date are stored under that date,
+// standalone reminders carry their own date expression.
 type Reminder struct {
-	Recurrence      Recurrence      `toml:"recurrence,omitempty"`
-	OffsetDirection OffsetDirection `toml:"offset_direction,omitempty"`
-	Offset          *time.Duration  `toml:"offset,omitempty"`
+	ID              string          `toml:"id"                            json:"id"`
+	Desc            string          `toml:"desc,omitempty"                json:"description,omitempty"`
+	DateExpr        string          `toml:"date_expr,omitempty"           json:"dateExpr,omitempty"`
+	Recurrence      Recurrence      `toml:"recurrence,omitempty"          json:"recurrence,omitempty"`
+	OffsetDirection OffsetDirection `toml:"offset_direction,omitempty"    json:"offsetDirection,omitempty"`
+	Offset          *time.Duration  `toml:"offset,omitempty"              json:"offset,omitempty"`
+	Tags            []string        `toml:"tags,omitempty"                json:"tags,omitempty"`
+	CreatedAt       time.Time       `toml:"created_at,omitempty,omitzero" json:"createdAt,omitzero"`
+	// Resolved information
+	DateID string    `toml:"-" json:"dateId,omitempty"`
+	Person string    `toml:"-" json:"person,omitempty"`
+	Anchor *Date     `toml:"-" json:"-"`
+	NextAt time.Time `toml:"-" json:"nextAt,omitzero"`
+}
+
+var _ tag.Tagged = (*Reminder)(nil)
+
+func (r *Reminder) Validate() error {
+	if r.Recurrence != "" {
+		if err := ValidateRecurrence(r.Recurrence); err != nil {
+			return err
+		}
+
+		// recurrences are matched case-insensitively, but stored in the canonical form
+		r.Recurrence = strings.ToLower(r.Recurrence)
+	}
+
+	if r.OffsetDirection != "" &&
+		r.OffsetDirection != OffsetDirectionBefore &&
+		r.OffsetDirection != OffsetDirectionAfter {
+		return fmt.Errorf("invalid reminder offset direction '%s'", r.OffsetDirection)
+	}
+
+	if r.Offset != nil && *r.Offset < 0 {
+		return errors.New("reminder offset cannot be negative")
+	}
+
+	return nil
+}
+
+func (r *Reminder) SetTags(tags []string) {
+	r.Tags = tags
+}
+
+func (r *Reminder) GetTags() []string {
+	return r.Tags
+}
+
+// Standalone tells if the reminder is not attached to any friend date.
+func (r *Reminder) Standalone() bool {
+	return r.DateID == ""
+}
+
+// Label returns the reminder description falling back to the description of its date.
+func (r *Reminder) Label() string {
+	if r.Desc != "" {
+		return r.Desc
+	}
+
+	if r.Anchor != nil && r.Anchor.Desc != "" {
+		return r.Anchor.Desc
+	}
+
+	if r.Anchor != nil {
+		return r.Anchor.DateExpr
+	}
+
+	return r.DateExpr
 }
@@ -21,14 +21,14 @@ import (
 )
 
 type WishlistItem struct {
-	ID        string    `toml:"id"`
-	CreatedAt time.Time `toml:"created_at"`
-	Desc      string    `toml:"desc,omitempty"`
-	Link      string    `toml:"link,omitempty"`
-	Price     string    `toml:"price,omitempty"`
-	Tags      []string  `toml:"tags,omitempty"`
-	Location  []string  `toml:"locations,omitempty"`
-	Person    string    `toml:"-"`
+	ID        string    `toml:"id"                  json:"id"`
+	CreatedAt time.Time `toml:"created_at"          json:"createdAt,omitzero"`
+	Desc      string    `toml:"desc,omitempty"      json:"description,omitempty"`
+	Link      string    `toml:"link,omitempty"      json:"link,omitempty"`
+	Price     string    `toml:"price,omitempty"     json:"price,omitempty"`
+	Tags      []string  `toml:"tags,omitempty"      json:"tags,omitempty"`
+	Location  []string  `toml:"locations,omitempty" json:"locations,omitempty"`
+	Person    string    `toml:"-"                   json:"person,omitempty"`
 }
 
 func (w *WishlistItem) SetTags(tags []string) {
@@ -0,0 +1,296 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package friendsmd reads journals kept by JacobEvelyn/friends in its friends.md format
+package friendsmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+)
+
+const dateFormat = "2006-01-02"
+
+var (
+	headingRe = regexp.MustCompile(`^#+\s*(.+?)\s*:?\s*$`)
+	eventRe   = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}):\s*(.*)$`)
+	friendRe  = regexp.MustCompile(`^([^(\[@]+?)\s*(?:\(([^)]*)\))?\s*(?:\[([^\]]*)\])?\s*$`)
+	locRe     = regexp.MustCompile(`^([^(@]+?)\s*(?:\(([^)]*)\))?\s*$`)
+	akaRe     = regexp.MustCompile(`(?i)\ba\.?k\.?a\.?\s+`)
+	tagRe     = regexp.MustCompile(`(?:^|\s)@([\p{L}\p{N}_:/-]+)`)
+	mentionRe = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	// friends.md wraps location names in underscores, which must not be a part of a word (e.g. snake_case)
+	placeRe  = regexp.MustCompile(`(^|[\s("'])_([^_\n]+)_`)
+	spacesRe = regexp.MustCompile(`\s+`)
+)
+
+type section string
+
+const (
+	sectionNone       section = ""
+	sectionActivities section = "activities"
+	sectionNotes      section = "notes"
+	sectionFriends    section = "friends"
+	sectionLocations  section = "locations"
+)
+
+// Friend is a friend listed in friends.md (e.g. "Grace Hopper (a.k.a. The Admiral) [Paris] @navy")
+type Friend struct {
+	Name      string
+	Nicknames []string
+	Location  string
+	Tags      []string
+	Line      int
+}
+
+// Location is a location listed in friends.md
+type Location struct {
+	Name    string
+	Aliases []string
+	Line    int
+}
+
+// Event is an activity or a note (e.g. "2017-11-01: Lunch with **Grace Hopper** in _Paris_. @food")
+type Event struct {
+	Type friend.EventType
+	Date time.Time
+	// Desc is the description without the markup and tags
+	Desc string
+	// Friends are names of friends mentioned in bold
+	Friends []string
+	// Locations are names of locations mentioned in italic
+	Locations []string
+	Tags      []string
+	Line      int
+}
+
+// Issue is a line that could not be imported
+type Issue struct {
+	Line   int
+	Text   string
+	Reason string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("line %d: %s: %s", i.Line, i.Reason, i.Text)
+}
+
+// Document is the parsed content of friends.md
+type Document struct {
+	Friends    []Friend
+	Locations  []Location
+	Activities []Event
+	Notes      []Event
+	// Issues are lines that could not be parsed
+	Issues []Issue
+}
+
+// Parse reads friends.md. Lines it doesn't understand are collected in Document.Issues instead of failing.
+func Parse(r io.Reader) (Document, error) {
+	var doc Document
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	sec := sectionNone
+	lineNum := 0
+
+	for sc.Scan() {
+		lineNum++
+
+		line := strings.TrimSpace(sc.Text())
+
+		if line == "" {
+			continue
+		}
+
+		if m := headingRe.FindStringSubmatch(line); m != nil {
+			sec = section(strings.ToLower(m[1]))
+			continue
+		}
+
+		item, ok := strings.CutPrefix(line, "- ")
+		if !ok {
+			item, ok = strings.CutPrefix(line, "* ")
+		}
+
+		if !ok {
+			doc.Issues = append(doc.Issues, Issue{Line: lineNum, Text: line, Reason: "not a list item"})
+			continue
+		}
+
+		item = strings.TrimSpace(item)
+
+		if err := doc.add(sec, lineNum, item); err != nil {
+			doc.Issues = append(doc.Issues, Issue{Line: lineNum, Text: line, Reason: err.Error()})
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return Document{}, fmt.Errorf("failed to read friends.md: %w", err)
+	}
+
+	return doc, nil
+}
+
+func (d *Document) add(sec section, line int, item string) error {
+	switch sec {
+	case sectionFriends:
+		f, err := parseFriend(item)
+		if err != nil {
+			return err
+		}
+
+		f.Line = line
+		d.Friends = append(d.Friends, f)
+	case sectionLocations:
+		l, err := parseLocation(item)
+		if err != nil {
+			return err
+		}
+
+		l.Line = line
+		d.Locations = append(d.Locations, l)
+	case sectionActivities, sectionNotes:
+		t := friend.EventTypeActivity
+
+		if sec == sectionNotes {
+			t = friend.EventTypeNote
+		}
+
+		e, err := parseEvent(t, item)
+		if err != nil {
+			return err
+		}
+
+		e.Line = line
+
+		if t == friend.EventTypeNote {
+			d.Notes = append(d.Notes, e)
+		} else {
+			d.Activities = append(d.Activities, e)
+		}
+	default:
+		return fmt.Errorf("unknown section %q", sec)
+	}
+
+	return nil
+}
+
+func parseFriend(item string) (Friend, error) {
+	tags := extractTags(item)
+
+	m := friendRe.FindStringSubmatch(removeTags(item))
+	if m == nil || strings.TrimSpace(m[1]) == "" {
+		return Friend{}, errors.New("unrecognized friend")
+	}
+
+	return Friend{
+		Name:      strings.TrimSpace(m[1]),
+		Nicknames: splitAKA(m[2]),
+		Location:  strings.TrimSpace(m[3]),
+		Tags:      tags,
+	}, nil
+}
+
+func parseLocation(item string) (Location, error) {
+	m := locRe.FindStringSubmatch(removeTags(item))
+	if m == nil || strings.TrimSpace(m[1]) == "" {
+		return Location{}, errors.New("unrecognized location")
+	}
+
+	return Location{
+		Name:    strings.TrimSpace(m[1]),
+		Aliases: splitAKA(m[2]),
+	}, nil
+}
+
+func parseEvent(t friend.EventType, item string) (Event, error) {
+	m := eventRe.FindStringSubmatch(item)
+	if m == nil {
+		return Event{}, fmt.Errorf("%s has no date", t)
+	}
+
+	date, err := time.Parse(dateFormat, m[1])
+	if err != nil {
+		return Event{}, fmt.Errorf("invalid date: %w", err)
+	}
+
+	desc := m[2]
+	e := Event{Type: t, Date: date, Tags: extractTags(desc)}
+
+	for _, mention := range mentionRe.FindAllStringSubmatch(desc, -1) {
+		e.Friends = appendUnique(e.Friends, strings.TrimSpace(mention[1]))
+	}
+
+	for _, place := range placeRe.FindAllStringSubmatch(desc, -1) {
+		e.Locations = appendUnique(e.Locations, strings.TrimSpace(place[2]))
+	}
+
+	desc = removeTags(desc)
+	desc = mentionRe.ReplaceAllString(desc, "$1")
+	desc = placeRe.ReplaceAllString(desc, "$1$2")
+
+	e.Desc = strings.TrimSpace(spacesRe.ReplaceAllString(desc, " "))
+
+	if e.Desc == "" {
+		return Event{}, fmt.Errorf("%s has no description", t)
+	}
+
+	return e, nil
+}
+
+// splitAKA splits nicknames listed as "a.k.a. The Admiral a.k.a. Amazing Grace"
+func splitAKA(s string) []string {
+	var names []string
+
+	for _, name := range akaRe.Split(s, -1) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func extractTags(s string) []string {
+	var tags []string
+
+	for _, m := range tagRe.FindAllStringSubmatch(s, -1) {
+		tags = appendUnique(tags, strings.ToLower(m[1]))
+	}
+
+	return tags
+}
+
+func removeTags(s string) string {
+	return strings.TrimSpace(tagRe.ReplaceAllString(s, ""))
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return list
+		}
+	}
+
+	return append(list, s)
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package friendsmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/stretchr/testify/require"
+)
+
+const friendsMD = `### Activities:
+- 2017-11-01: **Grace Hopper** and I went to _Marie's Diner_. George had to cancel at the last minute. @food
+- 2017-10-26: Went to **Marie Curie**'s party in _Atlantis_ with **Nikola Tesla**. @party @science:physics
+- Played chess with Grace.
+
+### Notes:
+- 2017-03-12: **Marie Curie** completed her PhD in record time. @school
+
+### Friends:
+- George Washington Carver
+- Grace Hopper (a.k.a. The Admiral a.k.a. Amazing Grace) [Paris] @navy @science
+- Marie Curie [Atlantis] @science
+
+### Locations:
+- Atlantis
+- Marie's Diner
+- Paris (a.k.a. City of Light)
+
+Some trailing text
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	doc, err := Parse(strings.NewReader(friendsMD))
+	require.NoError(t, err)
+
+	require.Len(t, doc.Friends, 3)
+	require.Equal(t, Friend{
+		Name:      "Grace Hopper",
+		Nicknames: []string{"The Admiral", "Amazing Grace"},
+		Location:  "Paris",
+		Tags:      []string{"navy", "science"},
+		Line:      11,
+	}, doc.Friends[1])
+
+	require.Len(t, doc.Locations, 3)
+	require.Equal(t, Location{Name: "Paris", Aliases: []string{"City of Light"}, Line: 17}, doc.Locations[2])
+
+	require.Len(t, doc.Activities, 2)
+	require.Equal(t, Event{
+		Type:      friend.EventTypeActivity,
+		Date:      time.Date(2017, 10, 26, 0, 0, 0, 0, time.UTC),
+		Desc:      "Went to Marie Curie's party in Atlantis with Nikola Tesla.",
+		Friends:   []string{"Marie Curie", "Nikola Tesla"},
+		Locations: []string{"Atlantis"},
+		Tags:      []string{"party", "science:physics"},
+		Line:      3,
+	}, doc.Activities[1])
+
+	require.Len(t, doc.Notes, 1)
+	require.Equal(t, friend.EventTypeNote, doc.Notes[0].Type)
+
+	require.Equal(t, []Issue{
+		{Line: 4, Text: "- Played chess with Grace.", Reason: "activity has no date"},
+		{Line: 19, Text: "Some trailing text", Reason: "not a list item"},
+	}, doc.Issues)
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	doc, err := Parse(strings.NewReader(friendsMD))
+	require.NoError(t, err)
+
+	jr := &journal.Journal{}
+	jr.Init()
+
+	r := Import(jr, doc)
+
+	require.Equal(t, 3, r.Friends)
+	require.Equal(t, 3, r.Locations)
+	require.Equal(t, 2, r.Activities)
+	require.Equal(t, 1, r.Notes)
+	require.Zero(t, r.Skipped)
+
+	grace, err := jr.GetFriend("The Admiral")
+	require.NoError(t, err)
+	require.Equal(t, "grace-hopper", grace.ID)
+	require.Equal(t, []string{"paris"}, grace.Locations)
+	require.Equal(t, 1, grace.Activities)
+
+	party := jr.Activities[1]
+	require.Contains(t, party.FriendIDs, "marie-curie")
+	require.Equal(t, []string{"atlantis"}, party.LocationIDs)
+	require.ElementsMatch(t, []string{"party", "science:physics"}, party.Tags)
+
+	require.Equal(t, []string{"marie-curie"}, jr.Notes[0].FriendIDs)
+
+	reasons := make([]string, 0, len(r.Issues))
+
+	for _, i := range r.Issues {
+		reasons = append(reasons, i.Reason)
+	}
+
+	require.Equal(t, []string{
+		"unresolved friend mention Nikola Tesla",
+		"activity has no date",
+		"not a list item",
+	}, reasons)
+
+	// importing the same file again doesn't duplicate records
+	r = Import(jr, doc)
+	require.Equal(t, 9, r.Skipped)
+	require.Zero(t, r.Friends+r.Locations+r.Activities+r.Notes)
+	require.Len(t, jr.Friends, 3)
+	require.Len(t, jr.Activities, 2)
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package friendsmd
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gosimple/slug"
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/tag"
+)
+
+// Report summarizes what was imported and what could not be mapped
+type Report struct {
+	Friends    int
+	Locations  int
+	Activities int
+	Notes      int
+	// Skipped counts records that are already in the journal
+	Skipped int
+	Issues  []Issue
+}
+
+// Import adds records of the document to the journal.
+// Records that are already in the journal are skipped, so importing the same file again doesn't duplicate them.
+// Friend and location mentions of events are resolved by the journal the same way as for new events,
+// mentions it can't resolve are reported as issues.
+func Import(jr *journal.Journal, doc Document) Report {
+	r := Report{Issues: slices.Clone(doc.Issues)}
+
+	for _, l := range doc.Locations {
+		r.addLocation(jr, l)
+	}
+
+	for _, f := range doc.Friends {
+		r.addFriend(jr, f)
+	}
+
+	for _, e := range doc.Activities {
+		r.addEvent(jr, e)
+	}
+
+	for _, e := range doc.Notes {
+		r.addEvent(jr, e)
+	}
+
+	slices.SortStableFunc(r.Issues, func(a, b Issue) int { return a.Line - b.Line })
+
+	return r
+}
+
+func (r *Report) issue(line int, text, reason string) {
+	r.Issues = append(r.Issues, Issue{Line: line, Text: text, Reason: reason})
+}
+
+func (r *Report) addLocation(jr *journal.Journal, l Location) {
+	id := slug.Make(l.Name)
+
+	for _, existing := range jr.Locations {
+		if existing.ID == id || strings.EqualFold(existing.Name, l.Name) {
+			r.Skipped++
+			return
+		}
+	}
+
+	jr.AddLocation(friend.Location{ID: id, Name: l.Name, Aliases: l.Aliases})
+
+	r.Locations++
+}
+
+func (r *Report) addFriend(jr *journal.Journal, f Friend) {
+	id := slug.Make(f.Name)
+
+	for _, existing := range jr.Friends {
+		if existing.ID == id || strings.EqualFold(existing.Name, f.Name) {
+			r.Skipped++
+			return
+		}
+	}
+
+	p := friend.Person{ID: id, Name: f.Name, Nicknames: f.Nicknames, Tags: f.Tags}
+
+	if f.Location != "" {
+		if l, err := jr.GetLocation(f.Location); err == nil {
+			p.Locations = []string{l.ID}
+		} else {
+			r.issue(f.Line, f.Name, "unknown location "+f.Location)
+		}
+	}
+
+	addTags(jr, f.Tags)
+	jr.AddFriend(p)
+
+	r.Friends++
+}
+
+func (r *Report) addEvent(jr *journal.Journal, e Event) {
+	events := jr.Activities
+
+	if e.Type == friend.EventTypeNote {
+		events = jr.Notes
+	}
+
+	for _, existing := range events {
+		if existing.Date.Equal(e.Date) && existing.Desc == e.Desc {
+			r.Skipped++
+			return
+		}
+	}
+
+	for _, loc := range jr.UnknownLocations(e.Locations) {
+		r.issue(e.Line, e.Desc, "unknown location "+loc)
+	}
+
+	addTags(jr, e.Tags)
+
+	added, err := jr.AddEvent(friend.Event{
+		Type:        e.Type,
+		Date:        e.Date,
+		Desc:        e.Desc,
+		LocationIDs: e.Locations,
+		Tags:        e.Tags,
+	})
+	if err != nil {
+		r.issue(e.Line, e.Desc, err.Error())
+		return
+	}
+
+	for _, name := range e.Friends {
+		f, err := jr.GetFriend(name)
+		if err != nil || !slices.Contains(added.FriendIDs, f.ID) {
+			r.issue(e.Line, e.Desc, "unresolved friend mention "+name)
+		}
+	}
+
+	if e.Type == friend.EventTypeNote {
+		r.Notes++
+	} else {
+		r.Activities++
+	}
+}
+
+func addTags(jr *journal.Journal, names []string) {
+	if len(names) == 0 {
+		return
+	}
+
+	tags := make([]tag.Tag, 0, len(names))
+
+	for _, n := range names {
+		tags = append(tags, tag.NewTag(n))
+	}
+
+	jr.AddTags(tags)
+}
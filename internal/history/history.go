@@ -0,0 +1,101 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/roma-glushko/frens/internal/sync"
+)
+
+// Revision is the change of the entity made by a commit to the journal repository
+type Revision struct {
+	Commit  string             `json:"commit"`
+	Author  string             `json:"author"`
+	Date    time.Time          `json:"date"`
+	Message string             `json:"message"`
+	Kind    journal.ChangeKind `json:"kind"`
+	// Changes lists fields set on addition and fields changed on update
+	Changes []file.FieldChange `json:"changes,omitempty"`
+}
+
+// Entity tells how the entity of the kind (e.g. "friend" or "activity") changed over the history of the journal.
+// Revisions go from the newest change to the oldest one. Changes that aren't committed yet are not included.
+func Entity(ctx context.Context, repo sync.Repository, kind, id string) ([]Revision, error) {
+	name, err := file.EntityFile(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.Inited(); err != nil {
+		return nil, err
+	}
+
+	if _, err := repo.GetBranchName(ctx); err != nil {
+		// nothing is committed yet
+		return nil, nil //nolint:nilerr
+	}
+
+	versions, err := repo.FileHistory(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		revisions []Revision
+		before    any
+	)
+
+	// versions follow first parents, so each one is diffed against the version its commit was based on
+	for _, v := range slices.Backward(versions) {
+		after, err := file.DecodeEntity(kind, id, v.Content)
+		if err != nil {
+			// e.g. the version was committed with conflict markers
+			log.Warnf("Skipping %s of commit %s: %v", name, v.Commit, err)
+			continue
+		}
+
+		rev := Revision{Commit: v.Commit, Author: v.Author, Date: v.Date, Message: v.Message}
+
+		switch {
+		case before == nil && after == nil:
+			continue
+		case after == nil:
+			rev.Kind = journal.ChangeRemoved
+		case before == nil:
+			rev.Kind = journal.ChangeAdded
+			rev.Changes = file.DiffEntities(nil, after)
+		default:
+			rev.Kind = journal.ChangeUpdated
+			rev.Changes = file.DiffEntities(before, after)
+
+			if len(rev.Changes) == 0 {
+				continue
+			}
+		}
+
+		before = after
+		revisions = append(revisions, rev)
+	}
+
+	slices.Reverse(revisions)
+
+	return revisions, nil
+}
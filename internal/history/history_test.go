@@ -0,0 +1,102 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/roma-glushko/frens/internal/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntity(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	dir := t.TempDir()
+	repo := sync.NewGoGit(dir)
+	s := file.NewTOMLFileStore(dir)
+
+	require.NoError(t, repo.Init(ctx))
+	require.NoError(t, s.Init(ctx))
+
+	revisions, err := Entity(ctx, repo, "friend", "jim")
+	require.NoError(t, err)
+	require.Empty(t, revisions)
+
+	commit := func(message string, fn func(j *journal.Journal) error) {
+		require.NoError(t, s.Tx(ctx, fn))
+		require.NoError(t, repo.Commit(ctx, message))
+	}
+
+	commit("add jim", func(j *journal.Journal) error {
+		j.AddFriend(friend.Person{ID: "jim", Name: "Jim Halpert", Desc: "a prankster"})
+		return nil
+	})
+
+	var dinner friend.Event
+
+	commit("add dinner", func(j *journal.Journal) error {
+		var err error
+
+		dinner, err = j.AddEvent(friend.Event{
+			Type:      friend.EventTypeActivity,
+			Date:      time.Date(2026, 10, 16, 19, 0, 0, 0, time.UTC),
+			Desc:      "Dinner with Jim",
+			FriendIDs: []string{"jim"},
+		})
+
+		return err
+	})
+
+	commit("edit jim", func(j *journal.Journal) error {
+		jim := *j.Friends[0]
+		jim.Desc = "Pam's husband"
+		j.UpdateFriend(*j.Friends[0], jim)
+
+		return nil
+	})
+
+	commit("remove jim", func(j *journal.Journal) error {
+		j.RemoveFriends([]friend.Person{*j.Friends[0]}, journal.RemoveModeDetach)
+		return nil
+	})
+
+	revisions, err = Entity(ctx, repo, "friend", "jim")
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+
+	// counting the dinner isn't a change of Jim
+	require.Equal(t, journal.ChangeRemoved, revisions[0].Kind)
+	require.Equal(t, "remove jim", revisions[0].Message)
+	require.Empty(t, revisions[0].Changes)
+
+	require.Equal(t, journal.ChangeUpdated, revisions[1].Kind)
+	require.Equal(t, []file.FieldChange{{Field: "desc", Old: "a prankster", New: "Pam's husband"}}, revisions[1].Changes)
+
+	require.Equal(t, journal.ChangeAdded, revisions[2].Kind)
+	require.Contains(t, revisions[2].Changes, file.FieldChange{Field: "name", New: "Jim Halpert"})
+
+	// detaching Jim changes the dinner too
+	revisions, err = Entity(ctx, repo, "activity", dinner.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	require.Equal(t, []file.FieldChange{{Field: "friends", Old: "jim"}}, revisions[0].Changes)
+	require.Equal(t, "add dinner", revisions[1].Message)
+}
@@ -37,6 +37,14 @@ import (
 
 var ErrEventNotFound = errors.New("event not found")
 
+const (
+	// maxTypos is the max number of typos tolerated when friends and locations are looked up by their names
+	maxTypos = 2
+	// guessConfidence is the lowest confidence of mentions recorded in events and lookups without fuzzy lookups on.
+	// Typos don't reach it unless the name is long, so plural or similar words (e.g. "Oscars") aren't taken for friends.
+	guessConfidence = 0.8
+)
+
 type Stats struct {
 	Friends    int `json:"friends"`
 	Locations  int `json:"locations"`
@@ -51,8 +59,11 @@ type Journal struct {
 	Locations  friend.Locations
 	Activities []*friend.Event
 	Notes      []*friend.Event
+	Reminders  []*friend.Reminder
 
 	dirty           bool
+	touched         map[EntityRef]struct{}
+	fuzzyLookup     bool
 	matcherMu       sync.Mutex
 	friendMatcher   *matcher.Matcher[friend.Person]
 	locationMatcher *matcher.Matcher[friend.Location]
@@ -63,7 +74,7 @@ func (j *Journal) Init() {
 	defer j.matcherMu.Unlock()
 
 	if j.friendMatcher == nil {
-		j.friendMatcher = matcher.NewMatcher[friend.Person]()
+		j.friendMatcher = matcher.NewMatcher[friend.Person](matcher.WithFuzzy(maxTypos))
 
 		for _, f := range j.Friends {
 			j.friendMatcher.Add(f)
@@ -71,7 +82,7 @@ func (j *Journal) Init() {
 	}
 
 	if j.locationMatcher == nil {
-		j.locationMatcher = matcher.NewMatcher[friend.Location]()
+		j.locationMatcher = matcher.NewMatcher[friend.Location](matcher.WithFuzzy(maxTypos))
 
 		for _, l := range j.Locations {
 			j.locationMatcher.Add(l)
@@ -79,12 +90,20 @@ func (j *Journal) Init() {
 	}
 }
 
+// EnableFuzzyLookup lets friends and locations be looked up by names with typos.
+// The most confident matches are picked, while mentions in events are still recorded only when they are certain.
+func (j *Journal) EnableFuzzyLookup() {
+	j.fuzzyLookup = true
+}
+
 func (j *Journal) IsDirty() bool {
 	return j.dirty
 }
 
+// SetDirty marks the whole journal changed or saved
 func (j *Journal) SetDirty(d bool) {
 	j.dirty = d
+	j.touched = nil
 }
 
 func (j *Journal) Path() string {
@@ -100,46 +119,46 @@ func (j *Journal) AddFriend(f friend.Person) {
 		f.CreatedAt = time.Now()
 	}
 
+	assignIDs(&f)
+
 	// TODO: check for duplicated IDs
 	// TODO: check for duplicated aliases
 
 	j.Friends = append(j.Friends, &f)
-	j.SetDirty(true)
-}
 
-func (j *Journal) GetFriend(q string) (friend.Person, error) {
-	matches := j.frenMatcher().Match(q)
-
-	if len(matches) == 0 {
-		return friend.Person{}, fmt.Errorf("no friends found for '%s'", q)
+	if j.friendMatcher != nil {
+		j.friendMatcher.Add(&f)
 	}
 
-	if len(matches) > 1 {
-		names := make([]string, 0, len(matches))
+	j.touch(EntityFriend, f.ID)
+}
 
-		for _, m := range matches {
-			for _, f := range m.Entities {
-				names = append(names, f.Name)
-			}
+// assignIDs identifies contacts and dates added along with the friend
+func assignIDs(f *friend.Person) {
+	for _, c := range f.Contacts {
+		if c.ID == "" {
+			c.ID = ksuid.New().String()
 		}
+	}
 
-		return friend.Person{}, fmt.Errorf(
-			"multiple friends found for '%s': %s",
-			q,
-			strings.Join(names, ", "),
-		)
+	for _, d := range f.Dates {
+		if d.ID == "" {
+			d.ID = ksuid.New().String()
+		}
 	}
+}
 
-	m := matches[0]
+func (j *Journal) GetFriend(q string) (friend.Person, error) {
+	candidates := bestMatched(lookupMatches(j, j.frenMatcher().Match(q)))
 
-	if len(m.Entities) == 0 {
-		return friend.Person{}, fmt.Errorf("no friend found for '%s'", q)
+	if len(candidates) == 0 {
+		return friend.Person{}, fmt.Errorf("no friends found for '%s'", q)
 	}
 
-	if len(m.Entities) > 1 {
-		names := make([]string, 0, len(m.Entities))
+	if len(candidates) > 1 {
+		names := make([]string, 0, len(candidates))
 
-		for _, f := range m.Entities {
+		for _, f := range candidates {
 			names = append(names, f.Name)
 		}
 
@@ -150,7 +169,7 @@ func (j *Journal) GetFriend(q string) (friend.Person, error) {
 		)
 	}
 
-	return *m.Entities[0], nil
+	return *candidates[0], nil
 }
 
 func (j *Journal) ListFriends(q friend.ListFriendQuery) []friend.Person { //nolint:cyclop
@@ -216,32 +235,86 @@ func (j *Journal) UpdateFriend(o, n friend.Person) {
 	n.Notes = o.Notes
 	n.MostRecentActivity = o.MostRecentActivity
 
+	// contacts, dates and wishlist are managed separately, so keep them unless given explicitly
+	if n.Contacts == nil {
+		n.Contacts = o.Contacts
+	}
+
+	if n.Dates == nil {
+		n.Dates = o.Dates
+	}
+
+	if n.Wishlist == nil {
+		n.Wishlist = o.Wishlist
+	}
+
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = o.CreatedAt
+	}
+
+	assignIDs(&n)
+
 	for i, f := range j.Friends {
-		if f.Name == o.Name {
+		if f.ID == o.ID {
 			j.Friends[i] = &n
-			j.SetDirty(true)
+
+			if j.friendMatcher != nil {
+				j.friendMatcher.Remove(f)
+				j.friendMatcher.Add(&n)
+			}
+
+			if n.ID != o.ID {
+				j.renameFriendRefs(o.ID, n.ID)
+			}
+
+			j.touch(EntityFriend, o.ID)
+			j.touch(EntityFriend, n.ID)
 
 			return
 		}
 	}
 
-	// TODO: update friend references in activities and notes
-
 	// If the friend was not found, add it as a new one
 	j.AddFriend(n)
 }
 
-func (j *Journal) RemoveFriends(toRemove []friend.Person) {
+// RemoveFriends removes friends with the given IDs and detaches or cascades their events
+func (j *Journal) RemoveFriends(toRemove []friend.Person, mode RemoveMode) {
+	ids := make(map[string]struct{}, len(toRemove))
+
 	for _, fr := range toRemove {
 		for i, f := range j.Friends {
-			if f.Name == fr.Name {
+			if f.ID == fr.ID {
+				ids[f.ID] = struct{}{}
+
+				if j.friendMatcher != nil {
+					j.friendMatcher.Remove(f)
+				}
+
 				j.Friends = append(j.Friends[:i], j.Friends[i+1:]...)
-				j.SetDirty(true)
+				j.touch(EntityFriend, f.ID)
 
 				break
 			}
 		}
 	}
+
+	if len(ids) == 0 {
+		return
+	}
+
+	j.removeRefs(mode, ids, func(e *friend.Event) *[]string { return &e.FriendIDs })
+}
+
+// FriendRefs counts events that mention any of the friends
+func (j *Journal) FriendRefs(friends []friend.Person) EventRefs {
+	ids := make(map[string]struct{}, len(friends))
+
+	for _, f := range friends {
+		ids[f.ID] = struct{}{}
+	}
+
+	return j.countRefs(ids, func(e *friend.Event) []string { return e.FriendIDs })
 }
 
 func (j *Journal) AddLocation(l friend.Location) {
@@ -257,23 +330,26 @@ func (j *Journal) AddLocation(l friend.Location) {
 	// TODO: check for duplicated aliases
 
 	j.Locations = append(j.Locations, &l)
-	j.SetDirty(true)
+
+	if j.locationMatcher != nil {
+		j.locationMatcher.Add(&l)
+	}
+
+	j.touch(EntityLocation, l.ID)
 }
 
 func (j *Journal) GetLocation(q string) (friend.Location, error) {
-	matches := j.locMatcher().Match(q)
+	candidates := bestMatched(lookupMatches(j, j.locMatcher().Match(q)))
 
-	if len(matches) == 0 {
+	if len(candidates) == 0 {
 		return friend.Location{}, fmt.Errorf("no locations found for '%s'", q)
 	}
 
-	if len(matches) > 1 {
-		names := make([]string, 0, len(matches))
+	if len(candidates) > 1 {
+		names := make([]string, 0, len(candidates))
 
-		for _, m := range matches {
-			for _, f := range m.Entities {
-				names = append(names, f.Name)
-			}
+		for _, l := range candidates {
+			names = append(names, l.Name)
 		}
 
 		return friend.Location{}, fmt.Errorf(
@@ -283,27 +359,49 @@ func (j *Journal) GetLocation(q string) (friend.Location, error) {
 		)
 	}
 
-	m := matches[0]
+	return *candidates[0], nil
+}
 
-	if len(m.Entities) == 0 {
-		return friend.Location{}, fmt.Errorf("no locations found for '%s'", q)
+// lookupMatches keeps matches entities can be looked up by. Fuzzy ones only rank candidates if fuzzy lookups are on.
+func lookupMatches[T any](j *Journal, matches []matcher.Match[T]) []matcher.Match[T] {
+	if j.fuzzyLookup {
+		return matches
 	}
 
-	if len(m.Entities) > 1 {
-		names := make([]string, 0, len(m.Entities))
+	return confident(matches)
+}
 
-		for _, f := range m.Entities {
-			names = append(names, f.Name)
+// confident drops matches that are likely typos or other words, so they are not taken for entities without asking
+func confident[T any](matches []matcher.Match[T]) []matcher.Match[T] {
+	return slices.DeleteFunc(matches, func(m matcher.Match[T]) bool {
+		return m.Confidence < guessConfidence
+	})
+}
+
+// bestMatched returns distinct matched entities that have the highest match confidence
+func bestMatched[T any](matches []matcher.Match[T]) []*T {
+	var (
+		best       []*T
+		confidence float64
+	)
+
+	for _, m := range matches {
+		if m.Confidence < confidence {
+			continue
 		}
 
-		return friend.Location{}, fmt.Errorf(
-			"multiple locations found for '%s': %s",
-			q,
-			strings.Join(names, ", "),
-		)
+		if m.Confidence > confidence {
+			best, confidence = nil, m.Confidence
+		}
+
+		for _, e := range m.Entities {
+			if !slices.Contains(best, e) {
+				best = append(best, e)
+			}
+		}
 	}
 
-	return *m.Entities[0], nil
+	return best
 }
 
 func (j *Journal) UpdateLocation(o, n friend.Location) {
@@ -312,20 +410,30 @@ func (j *Journal) UpdateLocation(o, n friend.Location) {
 	}
 
 	n.Activities = o.Activities
+	n.Notes = o.Notes
 	n.MostRecentActivity = o.MostRecentActivity
 
 	for i, l := range j.Locations {
-		if l.Name == o.Name {
+		if l.ID == o.ID {
 			j.Locations[i] = &n
-			j.SetDirty(true)
+
+			if j.locationMatcher != nil {
+				j.locationMatcher.Remove(l)
+				j.locationMatcher.Add(&n)
+			}
+
+			if n.ID != o.ID {
+				j.renameLocationRefs(o.ID, n.ID)
+			}
+
+			j.touch(EntityLocation, o.ID)
+			j.touch(EntityLocation, n.ID)
 
 			return
 		}
 	}
 
-	// TODO: update friend references in activities and notes
-
-	// If the friend was not found, add it as a new one
+	// If the location was not found, add it as a new one
 	j.AddLocation(n)
 }
 
@@ -382,27 +490,75 @@ func (j *Journal) ListLocations(q friend.ListLocationQuery) []friend.Location {
 	return locations
 }
 
-func (j *Journal) RemoveLocations(toRemove []friend.Location) {
+// RemoveLocations removes locations with the given IDs and detaches or cascades their events
+func (j *Journal) RemoveLocations(toRemove []friend.Location, mode RemoveMode) {
+	ids := make(map[string]struct{}, len(toRemove))
+
 	for _, loc := range toRemove {
 		for i, l := range j.Locations {
-			if l.Name == loc.Name {
+			if l.ID == loc.ID {
+				ids[l.ID] = struct{}{}
+
+				if j.locationMatcher != nil {
+					j.locationMatcher.Remove(l)
+				}
+
 				j.Locations = append(j.Locations[:i], j.Locations[i+1:]...)
-				j.dirty = true
+				j.touch(EntityLocation, l.ID)
 
 				break
 			}
 		}
 	}
+
+	if len(ids) == 0 {
+		return
+	}
+
+	for _, f := range j.Friends {
+		n := len(f.Locations)
+
+		f.Locations = slices.DeleteFunc(f.Locations, func(lID string) bool {
+			_, ok := ids[lID]
+			return ok
+		})
+
+		if len(f.Locations) != n {
+			j.touch(EntityFriend, f.ID)
+		}
+	}
+
+	j.removeRefs(mode, ids, func(e *friend.Event) *[]string { return &e.LocationIDs })
+}
+
+// LocationRefs counts events that mention any of the locations
+func (j *Journal) LocationRefs(locations []friend.Location) EventRefs {
+	ids := make(map[string]struct{}, len(locations))
+
+	for _, l := range locations {
+		ids[l.ID] = struct{}{}
+	}
+
+	return j.countRefs(ids, func(e *friend.Event) []string { return e.LocationIDs })
 }
 
 func (j *Journal) AddTags(t []tag.Tag) {
-	j.Tags = append(j.Tags, t...).Unique()
+	for _, tg := range t {
+		if !slices.Contains(j.Tags, tg) {
+			j.touch(EntityTag, tg.Name)
+		}
+	}
 
-	j.SetDirty(true)
+	j.Tags = append(j.Tags, t...).Unique()
 }
 
 func (j *Journal) GuessFriends(q string) []*friend.Person { //nolint:cyclop
-	matches := j.frenMatcher().Match(q)
+	matches := confident(j.frenMatcher().Match(q))
+
+	// more confident matches go first, so they win over fuzzy guesses of the same friends
+	sort.SliceStable(matches, func(a, b int) bool {
+		return matches[a].Confidence > matches[b].Confidence
+	})
 
 	certainPersons := make([]*friend.Person, 0, len(matches))
 	ambiguitiesMatches := make([]matcher.Match[friend.Person], 0, len(matches))
@@ -477,17 +633,145 @@ func (j *Journal) GuessFriends(q string) []*friend.Person { //nolint:cyclop
 		}
 	}
 
-	return append(certainPersons, guessedPersons...)
+	persons := make([]*friend.Person, 0, len(certainPersons)+len(guessedPersons))
+
+	for _, p := range append(certainPersons, guessedPersons...) {
+		if !slices.Contains(persons, p) {
+			persons = append(persons, p)
+		}
+	}
+
+	return persons
+}
+
+// GuessLocations resolves location markers and location mentions in the description into known locations.
+// Markers that don't refer to any known location are skipped.
+func (j *Journal) GuessLocations(desc string, markers []string) []*friend.Location {
+	locations := make([]*friend.Location, 0, len(markers))
+	seen := make(map[string]struct{}, len(markers))
+
+	add := func(l *friend.Location) {
+		if _, ok := seen[l.ID]; ok {
+			return
+		}
+
+		seen[l.ID] = struct{}{}
+		locations = append(locations, l)
+	}
+
+	for _, marker := range markers {
+		if l := j.resolveLocMarker(marker); l != nil {
+			add(l)
+		}
+	}
+
+	for _, m := range confident(j.locMatcher().Match(desc)) {
+		// ambiguous mentions are not recorded as we can't tell which location is meant
+		if len(m.Entities) == 1 {
+			add(m.Entities[0])
+		}
+	}
+
+	return locations
+}
+
+// UnknownLocations returns location markers that don't refer to any known location
+func (j *Journal) UnknownLocations(markers []string) []string {
+	unknown := make([]string, 0, len(markers))
+
+	for _, marker := range markers {
+		if j.resolveLocMarker(marker) == nil {
+			unknown = append(unknown, marker)
+		}
+	}
+
+	return unknown
+}
+
+// resolveLocMarker finds the location by its ID, name or alias.
+// Markers can't contain spaces, so underscores and dashes in them also match spaces (e.g. @new_york).
+func (j *Journal) resolveLocMarker(marker string) *friend.Location {
+	for _, l := range j.Locations {
+		if strings.EqualFold(l.ID, marker) {
+			return l
+		}
+	}
+
+	refs := []string{
+		strings.ToLower(marker),
+		strings.ToLower(strings.NewReplacer("_", " ", "-", " ").Replace(marker)),
+	}
+
+	for _, ref := range refs {
+		if p, ok := j.locMatcher().EntityPatterns[ref]; ok && len(p.Entities) == 1 {
+			return p.Entities[0]
+		}
+	}
+
+	return nil
+}
+
+func countFriendEvent(p *friend.Person, e friend.Event, delta int) {
+	if e.Type == friend.EventTypeNote {
+		p.Notes = max(p.Notes+delta, 0)
+		return
+	}
+
+	p.Activities = max(p.Activities+delta, 0)
+
+	if delta > 0 && e.Date.After(p.MostRecentActivity) {
+		p.MostRecentActivity = e.Date
+	}
+}
+
+// recountEventFriends moves the updated event from counters of its previous friends to the new ones
+func (j *Journal) recountEventFriends(o, n friend.Event) {
+	for _, p := range j.Friends {
+		if slices.Contains(o.FriendIDs, p.ID) {
+			countFriendEvent(p, o, -1)
+			j.touch(EntityFriend, p.ID)
+		}
+
+		if slices.Contains(n.FriendIDs, p.ID) {
+			countFriendEvent(p, n, 1)
+			j.touch(EntityFriend, p.ID)
+		}
+	}
+}
+
+func countLocationEvent(l *friend.Location, e friend.Event, delta int) {
+	if e.Type == friend.EventTypeNote {
+		l.Notes = max(l.Notes+delta, 0)
+		return
+	}
+
+	l.Activities = max(l.Activities+delta, 0)
+
+	if delta > 0 && e.Date.After(l.MostRecentActivity) {
+		l.MostRecentActivity = e.Date
+	}
+}
+
+// recountEventLocations moves the updated event from counters of its previous locations to the new ones
+func (j *Journal) recountEventLocations(o, n friend.Event) {
+	for _, l := range j.Locations {
+		if slices.Contains(o.LocationIDs, l.ID) {
+			countLocationEvent(l, o, -1)
+			j.touch(EntityLocation, l.ID)
+		}
+
+		if slices.Contains(n.LocationIDs, l.ID) {
+			countLocationEvent(l, n, 1)
+			j.touch(EntityLocation, l.ID)
+		}
+	}
 }
 
 func (j *Journal) AddEvent(e friend.Event) (friend.Event, error) {
 	e.ID = ksuid.New().String()
 
 	guessedPersons := j.GuessFriends(e.Desc)
-
-	_ = j.locMatcher().Match(e.Desc)
-
-	// TODO: record locs/friends
+	guessedLocations := j.GuessLocations(e.Desc, e.LocationIDs)
 
 	tags := lang.ExtractTags(e.Desc)
 
@@ -501,12 +785,17 @@ func (j *Journal) AddEvent(e friend.Event) (friend.Event, error) {
 	for _, p := range guessedPersons {
 		e.FriendIDs = append(e.FriendIDs, p.ID)
 
-		if e.Type == friend.EventTypeActivity {
-			p.MostRecentActivity = e.Date
-			p.Activities++
-		} else {
-			p.Notes++
-		}
+		countFriendEvent(p, e, 1)
+		j.touch(EntityFriend, p.ID)
+	}
+
+	e.LocationIDs = make([]string, 0, len(guessedLocations))
+
+	for _, l := range guessedLocations {
+		e.LocationIDs = append(e.LocationIDs, l.ID)
+
+		countLocationEvent(l, e, 1)
+		j.touch(EntityLocation, l.ID)
 	}
 
 	switch e.Type {
@@ -518,7 +807,7 @@ func (j *Journal) AddEvent(e friend.Event) (friend.Event, error) {
 		return friend.Event{}, fmt.Errorf("unknown event type: %s", e.Type)
 	}
 
-	j.SetDirty(true)
+	j.touchEvent(e.Type, e.ID)
 
 	return e, nil
 }
@@ -547,30 +836,34 @@ func (j *Journal) UpdateEvent(o, n friend.Event) (friend.Event, error) {
 	n.ID = o.ID
 
 	guessedPersons := j.GuessFriends(n.Desc)
+	guessedLocations := j.GuessLocations(n.Desc, n.LocationIDs)
 	tags := lang.ExtractTags(n.Desc)
-	_ = j.locMatcher().Match(n.Desc) // TODO: parse location
 
 	if len(tags) > 0 {
 		j.AddTags(tags)
 		tag.Add(&n, tags)
 	}
 
+	// friends are guessed from the description again, counters are moved once the event is found
+	n.FriendIDs = make([]string, 0, len(guessedPersons))
+
 	for _, p := range guessedPersons {
 		n.FriendIDs = append(n.FriendIDs, p.ID)
+	}
 
-		if n.Type == friend.EventTypeActivity {
-			p.MostRecentActivity = n.Date
-			p.Activities++
-		} else {
-			p.Notes++
-		}
+	n.LocationIDs = make([]string, 0, len(guessedLocations))
+
+	for _, l := range guessedLocations {
+		n.LocationIDs = append(n.LocationIDs, l.ID)
 	}
 
 	if o.Type == friend.EventTypeActivity {
 		for i, act := range j.Activities {
 			if act.ID == o.ID {
 				j.Activities[i] = &n
-				j.SetDirty(true)
+				j.recountEventFriends(o, n)
+				j.recountEventLocations(o, n)
+				j.touchEvent(o.Type, o.ID)
 
 				return n, nil
 			}
@@ -581,7 +874,9 @@ func (j *Journal) UpdateEvent(o, n friend.Event) (friend.Event, error) {
 		for i, note := range j.Notes {
 			if note.ID == o.ID {
 				j.Notes[i] = &n
-				j.SetDirty(true)
+				j.recountEventFriends(o, n)
+				j.recountEventLocations(o, n)
+				j.touchEvent(o.Type, o.ID)
 
 				return n, nil
 			}
@@ -606,6 +901,16 @@ func (j *Journal) ListEvents(q friend.ListEventQuery) ([]friend.Event, error) {
 		return events, fmt.Errorf("unknown event type: %s", q.Type)
 	}
 
+	friendIDs, err := j.resolveFriendRefs(q.Friends)
+	if err != nil {
+		return events, err
+	}
+
+	locationIDs, err := j.resolveLocationRefs(q.Locations)
+	if err != nil {
+		return events, err
+	}
+
 	for _, note := range source {
 		if q.Keyword != "" &&
 			!strings.Contains(strings.ToLower(note.Desc), strings.ToLower(q.Keyword)) {
@@ -616,6 +921,15 @@ func (j *Journal) ListEvents(q friend.ListEventQuery) ([]friend.Event, error) {
 			continue
 		}
 
+		// events should involve all requested friends, but could happen at any of requested locations
+		if !containsAll(note.FriendIDs, friendIDs) {
+			continue
+		}
+
+		if len(locationIDs) > 0 && !containsAny(note.LocationIDs, locationIDs) {
+			continue
+		}
+
 		if !q.Since.IsZero() && note.Date.Before(q.Since) {
 			continue
 		}
@@ -653,13 +967,80 @@ func (j *Journal) ListEvents(q friend.ListEventQuery) ([]friend.Event, error) {
 	return events, nil
 }
 
+// resolveFriendRefs turns friend names, nicknames or IDs into friend IDs the same way events record them
+func (j *Journal) resolveFriendRefs(refs []string) ([][]string, error) {
+	ids := make([][]string, 0, len(refs))
+
+	for _, ref := range refs {
+		var refIDs []string
+
+		for _, f := range j.Friends {
+			if strings.EqualFold(f.ID, ref) || strings.EqualFold(f.ID, strings.ReplaceAll(ref, " ", "_")) {
+				refIDs = append(refIDs, f.ID)
+			}
+		}
+
+		if len(refIDs) == 0 {
+			for _, f := range j.GuessFriends(ref) {
+				refIDs = append(refIDs, f.ID)
+			}
+		}
+
+		if len(refIDs) == 0 {
+			return nil, fmt.Errorf("no friends found for '%s'", ref)
+		}
+
+		ids = append(ids, refIDs)
+	}
+
+	return ids, nil
+}
+
+// resolveLocationRefs turns location markers into location IDs.
+// Markers are kept as is too, so events recorded before markers were resolved can still be found.
+func (j *Journal) resolveLocationRefs(refs []string) ([]string, error) {
+	ids := make([]string, 0, len(refs))
+
+	for _, ref := range refs {
+		l := j.resolveLocMarker(ref)
+		if l == nil {
+			return nil, fmt.Errorf("no locations found for '%s'", ref)
+		}
+
+		ids = append(ids, l.ID, ref)
+	}
+
+	return ids, nil
+}
+
+// containsAll checks that every group has at least one of its IDs in the list
+func containsAll(list []string, groups [][]string) bool {
+	for _, group := range groups {
+		if !containsAny(list, group) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsAny(list, ids []string) bool {
+	for _, id := range ids {
+		if slices.ContainsFunc(list, func(s string) bool { return strings.EqualFold(s, id) }) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (j *Journal) RemoveEvents(t friend.EventType, toRemove []friend.Event) {
 	for _, act := range toRemove {
 		if t == friend.EventTypeActivity {
 			for i, a := range j.Activities {
 				if a.ID == act.ID {
 					j.Activities = append(j.Activities[:i], j.Activities[i+1:]...)
-					j.SetDirty(true)
+					j.touchEvent(t, a.ID)
 
 					break
 				}
@@ -670,7 +1051,7 @@ func (j *Journal) RemoveEvents(t friend.EventType, toRemove []friend.Event) {
 			for i, n := range j.Notes {
 				if n.ID == act.ID {
 					j.Notes = append(j.Notes[:i], j.Notes[i+1:]...)
-					j.SetDirty(true)
+					j.touchEvent(t, n.ID)
 
 					break
 				}
@@ -689,9 +1070,14 @@ func (j *Journal) AddFriendDate(fID string, d friend.Date) (friend.Date, error)
 		d.ID = ksuid.New().String()
 	}
 
-	f.Dates = append(f.Dates, &d)
+	for _, p := range j.Friends {
+		if p.ID == f.ID {
+			p.Dates = append(p.Dates, &d)
+			j.touch(EntityFriend, p.ID)
 
-	j.SetDirty(true)
+			break
+		}
+	}
 
 	return d, nil
 }
@@ -699,12 +1085,16 @@ func (j *Journal) AddFriendDate(fID string, d friend.Date) (friend.Date, error)
 func (j *Journal) UpdateFriendDate(o, n friend.Date) (friend.Date, error) {
 	n.ID = o.ID
 
+	if n.Reminders == nil {
+		n.Reminders = o.Reminders
+	}
+
 	for _, f := range j.Friends {
 		for i, d := range f.Dates {
 			if d.ID == o.ID {
 				f.Dates[i] = &n
 
-				j.SetDirty(true)
+				j.touch(EntityFriend, f.ID)
 
 				return n, nil
 			}
@@ -782,7 +1172,7 @@ func (j *Journal) RemoveFriendDates(toRemove []friend.Date) error {
 					f.Dates = append(f.Dates[:i], f.Dates[i+1:]...)
 					found = true
 
-					j.SetDirty(true)
+					j.touch(EntityFriend, f.ID)
 
 					break
 				}
@@ -816,9 +1206,14 @@ func (j *Journal) AddFriendWishlistItem(
 		w.ID = ksuid.New().String()
 	}
 
-	f.Wishlist = append(f.Wishlist, &w)
+	for _, p := range j.Friends {
+		if p.ID == f.ID {
+			p.Wishlist = append(p.Wishlist, &w)
+			j.touch(EntityFriend, p.ID)
 
-	j.SetDirty(true)
+			break
+		}
+	}
 
 	return w, nil
 }
@@ -832,7 +1227,7 @@ func (j *Journal) UpdateFriendWishlistItem(o, n friend.WishlistItem) (friend.Wis
 			if w.ID == o.ID {
 				f.Wishlist[i] = &n
 
-				j.SetDirty(true)
+				j.touch(EntityFriend, f.ID)
 
 				return n, nil
 			}
@@ -907,7 +1302,7 @@ func (j *Journal) RemoveFriendWishlistItems(toRemove []friend.WishlistItem) erro
 					f.Wishlist = append(f.Wishlist[:i], f.Wishlist[i+1:]...)
 					found = true
 
-					j.SetDirty(true)
+					j.touch(EntityFriend, f.ID)
 
 					break
 				}
@@ -941,12 +1336,12 @@ func (j *Journal) AddFriendContact(fID string, c friend.Contact) (friend.Contact
 	for _, p := range j.Friends {
 		if p.ID == f.ID {
 			p.Contacts = append(p.Contacts, &c)
+			j.touch(EntityFriend, p.ID)
+
 			break
 		}
 	}
 
-	j.SetDirty(true)
-
 	return c, nil
 }
 
@@ -958,7 +1353,7 @@ func (j *Journal) UpdateFriendContact(o, n friend.Contact) (friend.Contact, erro
 			if c.ID == o.ID {
 				f.Contacts[i] = &n
 
-				j.SetDirty(true)
+				j.touch(EntityFriend, f.ID)
 
 				return n, nil
 			}
@@ -1047,7 +1442,7 @@ func (j *Journal) RemoveFriendContacts(toRemove []friend.Contact) error {
 					f.Contacts = append(f.Contacts[:i], f.Contacts[i+1:]...)
 					found = true
 
-					j.SetDirty(true)
+					j.touch(EntityFriend, f.ID)
 
 					break
 				}
@@ -1066,6 +1461,211 @@ func (j *Journal) RemoveFriendContacts(toRemove []friend.Contact) error {
 	return nil
 }
 
+// Reminder methods
+
+func (j *Journal) AddReminder(r friend.Reminder) (friend.Reminder, error) {
+	if r.ID == "" {
+		r.ID = ksuid.New().String()
+	}
+
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now()
+	}
+
+	dateID, person := r.DateID, r.Person
+
+	r.DateID, r.Person, r.Anchor = "", "", nil
+
+	if dateID == "" {
+		if r.DateExpr == "" {
+			return friend.Reminder{}, friend.ErrReminderDateEmpty
+		}
+
+		if r.Recurrence == "" {
+			r.Recurrence = friend.RecurrenceOnce
+		}
+
+		j.Reminders = append(j.Reminders, &r)
+		j.touch(EntityReminder, r.ID)
+
+		return resolveReminder(r, nil, nil), nil
+	}
+
+	for _, f := range j.Friends {
+		if person != "" && f.ID != person {
+			continue
+		}
+
+		for _, d := range f.Dates {
+			if d.ID != dateID {
+				continue
+			}
+
+			if r.Recurrence == "" {
+				r.Recurrence = friend.RecurrenceYearly
+			}
+
+			// the reminder follows its date, so it should not carry its own
+			r.DateExpr = ""
+
+			d.Reminders = append(d.Reminders, &r)
+			j.touch(EntityFriend, f.ID)
+
+			return resolveReminder(r, f, d), nil
+		}
+	}
+
+	return friend.Reminder{}, fmt.Errorf("date with ID %s not found", dateID)
+}
+
+func (j *Journal) GetReminder(rID string) (friend.Reminder, error) {
+	for _, r := range j.Reminders {
+		if r.ID == rID {
+			return resolveReminder(*r, nil, nil), nil
+		}
+	}
+
+	for _, f := range j.Friends {
+		for _, d := range f.Dates {
+			for _, r := range d.Reminders {
+				if r.ID == rID {
+					return resolveReminder(*r, f, d), nil
+				}
+			}
+		}
+	}
+
+	return friend.Reminder{}, fmt.Errorf("reminder with ID %s not found", rID)
+}
+
+func (j *Journal) ListReminders( //nolint:cyclop
+	q friend.ListReminderQuery,
+) ([]friend.Reminder, error) {
+	reminders := make([]friend.Reminder, 0, 10)
+
+	frs := j.Friends
+
+	if len(q.Friends) > 0 {
+		frs = make([]*friend.Person, 0, len(q.Friends))
+
+		for _, fID := range q.Friends {
+			f, err := j.GetFriend(fID)
+			if err != nil {
+				return reminders, fmt.Errorf("failed to get friend %s: %w", fID, err)
+			}
+
+			frs = append(frs, &f)
+		}
+	}
+
+	matches := func(r friend.Reminder) bool {
+		if q.Keyword != "" &&
+			!strings.Contains(strings.ToLower(r.Label()), strings.ToLower(q.Keyword)) {
+			return false
+		}
+
+		if len(q.Tags) > 0 && !tag.HasTags(&r, q.Tags) {
+			return false
+		}
+
+		return true
+	}
+
+	for _, f := range frs {
+		for _, d := range f.Dates {
+			for _, r := range d.Reminders {
+				resolved := resolveReminder(*r, f, d)
+
+				if matches(resolved) {
+					reminders = append(reminders, resolved)
+				}
+			}
+		}
+	}
+
+	if len(q.Friends) > 0 {
+		return reminders, nil
+	}
+
+	for _, r := range j.Reminders {
+		resolved := resolveReminder(*r, nil, nil)
+
+		if matches(resolved) {
+			reminders = append(reminders, resolved)
+		}
+	}
+
+	return reminders, nil
+}
+
+func (j *Journal) RemoveReminders(toRemove []friend.Reminder) error {
+	for _, rm := range toRemove {
+		found := false
+
+		for i, r := range j.Reminders {
+			if r.ID == rm.ID {
+				j.Reminders = append(j.Reminders[:i], j.Reminders[i+1:]...)
+				found = true
+
+				j.touch(EntityReminder, r.ID)
+
+				break
+			}
+		}
+
+		for _, f := range j.Friends {
+			if found {
+				break
+			}
+
+			for _, d := range f.Dates {
+				if found {
+					break
+				}
+
+				for i, r := range d.Reminders {
+					if r.ID == rm.ID {
+						d.Reminders = append(d.Reminders[:i], d.Reminders[i+1:]...)
+						found = true
+
+						j.touch(EntityFriend, f.ID)
+
+						break
+					}
+				}
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("reminder with ID %s not found", rm.ID)
+		}
+	}
+
+	return nil
+}
+
+// resolveReminder fills in the information about the date the reminder is anchored to.
+func resolveReminder(r friend.Reminder, f *friend.Person, d *friend.Date) friend.Reminder {
+	if d == nil {
+		r.Anchor = &friend.Date{
+			Calendar: friend.CalendarGregorian,
+			DateExpr: r.DateExpr,
+			Desc:     r.Desc,
+		}
+
+		return r
+	}
+
+	anchor := *d
+	anchor.Person = f.ID
+
+	r.DateID = d.ID
+	r.Person = f.ID
+	r.Anchor = &anchor
+
+	return r
+}
+
 func (j *Journal) Stats() Stats {
 	return Stats{
 		Friends:    len(j.Friends),
@@ -52,10 +52,209 @@ func TestJournal_AddEvent(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, event.ID)
 	require.Contains(t, event.FriendIDs, frID)
-	// require.Contains(t, event.Locations, locID)
+	require.Contains(t, event.LocationIDs, locID)
 
 	f, err := jr.GetFriend(frID)
 	require.NoError(t, err)
 
 	require.Equal(t, 1, f.Activities)
+
+	l, err := jr.GetLocation(locID)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, l.Activities)
+	require.Equal(t, event.Date, l.MostRecentActivity)
+}
+
+func TestJournal_AddEventLocationMarkers(t *testing.T) {
+	jr := Journal{
+		Locations: []*friend.Location{
+			{ID: "nyc", Name: "New York", Aliases: []string{"Big Apple"}},
+			{ID: "scranton", Name: "Scranton"},
+		},
+	}
+
+	jr.Init()
+
+	require.Equal(t, []string{"atlantis"}, jr.UnknownLocations([]string{"new_york", "Scranton", "atlantis"}))
+
+	event, err := jr.AddEvent(friend.Event{
+		Type:        friend.EventTypeNote,
+		Date:        time.Now().UTC(),
+		Desc:        "Moved from the Big Apple",
+		LocationIDs: []string{"new_york", "atlantis", "nyc"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"nyc"}, event.LocationIDs)
+	require.Equal(t, 1, jr.Locations[0].Notes)
+	require.Equal(t, 0, jr.Locations[0].Activities)
+}
+
+func TestJournal_ListEventsByFriendAndLocation(t *testing.T) {
+	jr := Journal{
+		Friends: []*friend.Person{
+			{ID: "jim", Name: "Jim Halpert", Nicknames: []string{"Big Tuna"}},
+			{ID: "pam", Name: "Pam Beesly"},
+		},
+		Locations: []*friend.Location{
+			{ID: "scranton", Name: "Scranton"},
+			{ID: "nyc", Name: "New York"},
+		},
+		Activities: []*friend.Event{
+			{ID: "a1", FriendIDs: []string{"jim"}, LocationIDs: []string{"scranton"}},
+			{ID: "a2", FriendIDs: []string{"jim", "pam"}, LocationIDs: []string{"nyc"}},
+			{ID: "a3", FriendIDs: []string{"pam"}},
+		},
+	}
+
+	jr.Init()
+
+	ids := func(q friend.ListEventQuery) []string {
+		q.Type = friend.EventTypeActivity
+
+		events, err := jr.ListEvents(q)
+		require.NoError(t, err)
+
+		found := make([]string, 0, len(events))
+
+		for _, e := range events {
+			found = append(found, e.ID)
+		}
+
+		return found
+	}
+
+	require.Equal(t, []string{"a1", "a2"}, ids(friend.ListEventQuery{Friends: []string{"Big Tuna"}}))
+	require.Equal(t, []string{"a2"}, ids(friend.ListEventQuery{Friends: []string{"jim", "Pam"}}))
+	require.Equal(t, []string{"a1"}, ids(friend.ListEventQuery{Friends: []string{"Jim"}, Locations: []string{"Scranton"}}))
+	require.Equal(t, []string{"a2"}, ids(friend.ListEventQuery{Locations: []string{"new_york"}}))
+
+	_, err := jr.ListEvents(friend.ListEventQuery{Type: friend.EventTypeActivity, Friends: []string{"Dwight"}})
+	require.Error(t, err)
+}
+
+func TestJournal_GetFriendFuzzy(t *testing.T) {
+	jr := Journal{
+		Friends: []*friend.Person{
+			{ID: "michael", Name: "Michael Scott"},
+			{ID: "dwight", Name: "Dwight Schrute"},
+		},
+	}
+
+	jr.Init()
+
+	_, err := jr.GetFriend("Micheal")
+	require.Error(t, err)
+
+	jr.EnableFuzzyLookup()
+
+	f, err := jr.GetFriend("Micheal")
+	require.NoError(t, err)
+	require.Equal(t, "michael", f.ID)
+
+	// the exact match wins over the fuzzy one
+	f, err = jr.GetFriend("Dwight Shrute")
+	require.NoError(t, err)
+	require.Equal(t, "dwight", f.ID)
+
+	// typos in long names are still certain enough, short names have to be spelled right
+	persons := jr.GuessFriends("Micheal Scott and Dwigth went to a meeting")
+	require.Len(t, persons, 1)
+	require.Equal(t, "michael", persons[0].ID)
+}
+
+func TestJournal_GuessFriendsSkipsSimilarWords(t *testing.T) {
+	jr := Journal{
+		Friends: []*friend.Person{
+			{ID: "oscar", Name: "Oscar Martinez", Nicknames: []string{"Oscar"}},
+			{ID: "angela", Name: "Angela Martin", Nicknames: []string{"Angela"}},
+			{ID: "karen", Name: "Karen Filippelli", Nicknames: []string{"Karen"}},
+		},
+	}
+
+	jr.Init()
+	jr.EnableFuzzyLookup()
+
+	for _, desc := range []string{"Watched the Oscars together", "Saw some angels at church", "Karens everywhere"} {
+		require.Empty(t, jr.GuessFriends(desc), desc)
+	}
+
+	_, err := jr.AddEvent(friend.Event{Type: friend.EventTypeActivity, Desc: "Watched the Oscars together"})
+	require.NoError(t, err)
+	require.Empty(t, jr.Activities[0].FriendIDs)
+	require.Zero(t, jr.Friends[0].Activities)
+}
+
+func TestJournal_MatcherFollowsChanges(t *testing.T) {
+	jr := Journal{
+		Friends: []*friend.Person{
+			{ID: "michael", Name: "Michael Scott"},
+		},
+	}
+
+	jr.Init()
+
+	jr.AddFriend(friend.Person{Name: "Dwight Schrute"})
+
+	f, err := jr.GetFriend("Dwight")
+	require.NoError(t, err)
+	require.Equal(t, "dwight-schrute", f.ID)
+
+	jr.UpdateFriend(f, friend.Person{Name: "Dwight Schrute", Nicknames: []string{"Assistant Manager"}})
+
+	f, err = jr.GetFriend("the assistant manager")
+	require.NoError(t, err)
+	require.Equal(t, "dwight-schrute", f.ID)
+
+	jr.RemoveFriends([]friend.Person{f}, RemoveModeDetach)
+
+	_, err = jr.GetFriend("Dwight")
+	require.Error(t, err)
+}
+
+func TestJournal_UpdateEventMovesFriendCounters(t *testing.T) {
+	jr := Journal{
+		Friends: []*friend.Person{
+			{ID: "jim", Name: "Jim Halpert"},
+			{ID: "pam", Name: "Pam Beesly"},
+		},
+	}
+
+	jr.Init()
+
+	e, err := jr.AddEvent(friend.Event{Type: friend.EventTypeActivity, Date: time.Now(), Desc: "Lunch with Jim Halpert"})
+	require.NoError(t, err)
+	require.Equal(t, 1, jr.Friends[0].Activities)
+
+	// saving the event as it is keeps the counters
+	_, err = jr.UpdateEvent(e, e)
+	require.NoError(t, err)
+	require.Equal(t, 1, jr.Friends[0].Activities)
+
+	n := e
+	n.Desc = "Lunch with Pam Beesly"
+
+	n, err = jr.UpdateEvent(e, n)
+	require.NoError(t, err)
+	require.Equal(t, []string{"pam"}, n.FriendIDs)
+	require.Zero(t, jr.Friends[0].Activities)
+	require.Equal(t, 1, jr.Friends[1].Activities)
+}
+
+func TestJournal_UpdateFriendByID(t *testing.T) {
+	jr := Journal{
+		Friends: []*friend.Person{
+			{ID: "jim", Name: "Jim"},
+			{ID: "jim-2", Name: "Jim"},
+		},
+	}
+
+	jr.Init()
+
+	jr.UpdateFriend(*jr.Friends[1], friend.Person{Name: "Jim", Desc: "from the warehouse"})
+
+	require.Empty(t, jr.Friends[0].Desc)
+	require.Equal(t, "from the warehouse", jr.Friends[1].Desc)
+	require.Equal(t, "jim-2", jr.Friends[1].ID)
 }
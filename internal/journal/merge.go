@@ -0,0 +1,206 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/segmentio/ksuid"
+)
+
+// SimilarFriends finds friends that are likely the same person as p, e.g. when importing contacts.
+// Friends are similar when they share the ID, an email or a phone number, or when every word of the name
+// or a nickname matches allowing for typos. First or last names alone are too common to tell people apart.
+func (j *Journal) SimilarFriends(p friend.Person) []*friend.Person { //nolint:cyclop
+	var similar []*friend.Person
+
+	add := func(f *friend.Person) {
+		if !slices.Contains(similar, f) {
+			similar = append(similar, f)
+		}
+	}
+
+	for _, f := range j.Friends {
+		if p.ID != "" && f.ID == p.ID {
+			add(f)
+			continue
+		}
+
+		for _, c := range p.Contacts {
+			if (c.Type == friend.ContactTypeEmail || c.Type == friend.ContactTypePhone) && hasContact(f, *c) {
+				add(f)
+				break
+			}
+		}
+	}
+
+	for _, name := range append([]string{p.Name}, p.Nicknames...) {
+		words := len(strings.Fields(name))
+		if words == 0 {
+			continue
+		}
+
+		// count the words of the name matched by references of every friend, allowing for typos
+		covered := make(map[*friend.Person]int)
+
+		for _, m := range j.frenMatcher().Match(name) {
+			for _, f := range m.Entities {
+				covered[f] += len(strings.Fields(m.MatchedRef))
+			}
+		}
+
+		for _, f := range j.Friends {
+			if covered[f] >= words && hasNameOfLength(f, words) {
+				add(f)
+			}
+		}
+	}
+
+	return similar
+}
+
+// MergeFriend adds information of the other friend record to the existing friend and returns the result.
+// Details of the existing friend win over the other ones, missing contacts and dates are added.
+func (j *Journal) MergeFriend(o, other friend.Person) friend.Person {
+	n := o
+
+	nicknames := other.Nicknames
+
+	if other.Name != "" && !strings.EqualFold(other.Name, o.Name) {
+		nicknames = append([]string{other.Name}, nicknames...)
+	}
+
+	n.Nicknames = union(o.Nicknames, nicknames)
+	n.Tags = union(o.Tags, other.Tags)
+	n.Locations = union(o.Locations, other.Locations)
+
+	if n.Desc == "" {
+		n.Desc = other.Desc
+	}
+
+	n.Contacts = slices.Clone(o.Contacts)
+
+	for _, c := range other.Contacts {
+		if hasContact(&n, *c) {
+			continue
+		}
+
+		nc := *c
+		nc.ID = ksuid.New().String()
+
+		n.Contacts = append(n.Contacts, &nc)
+	}
+
+	n.Dates = slices.Clone(o.Dates)
+
+	for _, d := range other.Dates {
+		if hasDate(&n, *d) {
+			continue
+		}
+
+		nd := *d
+		nd.ID = ksuid.New().String()
+
+		n.Dates = append(n.Dates, &nd)
+	}
+
+	j.UpdateFriend(o, n)
+
+	return n
+}
+
+// union appends values missing in the list ignoring case, keeping the order of both
+func union(list, values []string) []string {
+	out := slices.Clone(list)
+
+	for _, v := range values {
+		if !slices.ContainsFunc(out, func(o string) bool { return strings.EqualFold(o, v) }) {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+// hasNameOfLength tells whether the full name or a nickname of the friend has the given number of words
+func hasNameOfLength(f *friend.Person, words int) bool {
+	if len(strings.Fields(f.Name)) == words {
+		return true
+	}
+
+	for _, n := range f.Nicknames {
+		if len(strings.Fields(n)) == words {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasContact(f *friend.Person, c friend.Contact) bool {
+	value := contactKey(c)
+
+	for _, fc := range f.Contacts {
+		if fc.Type == c.Type && contactKey(*fc) == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contactKey brings contact values to a comparable form, e.g. "+1 (570) 555-0100" and "tel:+15705550100"
+func contactKey(c friend.Contact) string {
+	v := strings.ToLower(strings.TrimSpace(c.Value))
+
+	if c.Type == friend.ContactTypePhone {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+
+			return -1
+		}, v)
+	}
+
+	v = strings.TrimPrefix(v, "mailto:")
+
+	return strings.TrimPrefix(v, "@")
+}
+
+func hasDate(f *friend.Person, d friend.Date) bool {
+	for _, fd := range f.Dates {
+		if d.Desc != "" && strings.EqualFold(fd.Desc, d.Desc) {
+			return true
+		}
+
+		if strings.EqualFold(calendarOf(*fd), calendarOf(d)) && strings.EqualFold(fd.DateExpr, d.DateExpr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func calendarOf(d friend.Date) friend.Calendar {
+	if d.Calendar == "" {
+		return friend.CalendarGregorian
+	}
+
+	return d.Calendar
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"testing"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/stretchr/testify/require"
+)
+
+func newMergeJournal() *Journal {
+	jr := &Journal{
+		Friends: []*friend.Person{
+			{
+				ID:        "jim",
+				Name:      "Jim Halpert",
+				Nicknames: []string{"Big Tuna"},
+				Tags:      []string{"office"},
+				Contacts: []*friend.Contact{
+					{ID: "c1", Type: friend.ContactTypePhone, Value: "+1 (570) 555-0100"},
+				},
+				Dates: []*friend.Date{
+					{ID: "d1", DateExpr: "October 1", Desc: "Birthday"},
+				},
+			},
+			{ID: "jim-carrey", Name: "Jim Carrey"},
+			{ID: "pam", Name: "Pam Beesly", Contacts: []*friend.Contact{
+				{ID: "c2", Type: friend.ContactTypeEmail, Value: "Pam@DunderMifflin.com"},
+			}},
+		},
+	}
+
+	jr.Init()
+
+	return jr
+}
+
+func TestJournal_SimilarFriends(t *testing.T) {
+	t.Parallel()
+
+	jr := newMergeJournal()
+
+	ids := func(persons []*friend.Person) []string {
+		var out []string
+
+		for _, p := range persons {
+			out = append(out, p.ID)
+		}
+
+		return out
+	}
+
+	tests := map[string]struct {
+		person friend.Person
+		want   []string
+	}{
+		"full name":    {friend.Person{Name: "jim halpert"}, []string{"jim"}},
+		"typo":         {friend.Person{Name: "Jim Halpret"}, []string{"jim"}},
+		"nickname":     {friend.Person{Name: "James Halpert", Nicknames: []string{"Big Tuna"}}, []string{"jim"}},
+		"first name":   {friend.Person{Name: "Jim"}, nil},
+		"another name": {friend.Person{Name: "Jim Morrison"}, nil},
+		"id":           {friend.Person{ID: "pam", Name: "Pamela"}, []string{"pam"}},
+		"email": {friend.Person{Name: "Pamela", Contacts: []*friend.Contact{
+			{Type: friend.ContactTypeEmail, Value: "pam@dundermifflin.com"},
+		}}, []string{"pam"}},
+		"phone": {friend.Person{Name: "J. H.", Contacts: []*friend.Contact{
+			{Type: friend.ContactTypePhone, Value: "tel:+15705550100"},
+		}}, []string{"jim"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.want, ids(jr.SimilarFriends(tc.person)))
+		})
+	}
+}
+
+func TestJournal_MergeFriend(t *testing.T) {
+	t.Parallel()
+
+	jr := newMergeJournal()
+
+	jim, err := jr.GetFriend("Big Tuna")
+	require.NoError(t, err)
+
+	merged := jr.MergeFriend(jim, friend.Person{
+		Name: "James Halpert",
+		Desc: "a salesman",
+		Tags: []string{"office", "sales"},
+		Contacts: []*friend.Contact{
+			{Type: friend.ContactTypePhone, Value: "+15705550100"},
+			{Type: friend.ContactTypeEmail, Value: "jim@dundermifflin.com"},
+		},
+		Dates: []*friend.Date{
+			{DateExpr: "1978-10-01", Desc: "birthday"},
+			{DateExpr: "2009-10-10", Desc: "Anniversary"},
+		},
+	})
+
+	require.Equal(t, "Jim Halpert", merged.Name)
+	require.Equal(t, "a salesman", merged.Desc)
+	require.Equal(t, []string{"Big Tuna", "James Halpert"}, merged.Nicknames)
+	require.Equal(t, []string{"office", "sales"}, merged.Tags)
+	require.Len(t, merged.Contacts, 2)
+	require.Len(t, merged.Dates, 2)
+	require.NotEmpty(t, merged.Dates[1].ID)
+
+	jim, err = jr.GetFriend("James Halpert")
+	require.NoError(t, err)
+	require.Equal(t, merged.Contacts, jim.Contacts)
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/roma-glushko/frens/internal/friend"
+)
+
+// RemoveMode defines what happens to events that refer to removed friends or locations
+type RemoveMode string
+
+const (
+	// RemoveModeDetach keeps events, but drops references to removed entities
+	RemoveModeDetach RemoveMode = "detach"
+	// RemoveModeCascade also deletes events left without any friend or location once removed entities are detached
+	RemoveModeCascade RemoveMode = "cascade"
+)
+
+// NewRemoveMode picks the removal mode from the mutually exclusive cascade and detach options
+func NewRemoveMode(cascade, detach bool) (RemoveMode, error) {
+	if cascade && detach {
+		return "", errors.New("only one of cascade or detach can be used at a time")
+	}
+
+	if cascade {
+		return RemoveModeCascade, nil
+	}
+
+	return RemoveModeDetach, nil
+}
+
+// EventRefs summarizes events that refer to a set of friends or locations
+type EventRefs struct {
+	Activities int
+	Notes      int
+	// Exclusive is the number of events that refer to no friend or location but the set, so cascade removal deletes them
+	Exclusive int
+}
+
+func (r EventRefs) Total() int {
+	return r.Activities + r.Notes
+}
+
+func (j *Journal) countRefs(ids map[string]struct{}, refs func(e *friend.Event) []string) EventRefs {
+	var er EventRefs
+
+	for eType, e := range j.events() {
+		matched, exclusive := matchRefs(e, refs(e), ids)
+
+		if !matched {
+			continue
+		}
+
+		if eType == friend.EventTypeNote {
+			er.Notes++
+		} else {
+			er.Activities++
+		}
+
+		if exclusive {
+			er.Exclusive++
+		}
+	}
+
+	return er
+}
+
+// removeRefs detaches removed entities from events or deletes events that referred to nothing else
+func (j *Journal) removeRefs(mode RemoveMode, ids map[string]struct{}, refs func(e *friend.Event) *[]string) {
+	isRemoved := func(id string) bool {
+		_, ok := ids[id]
+		return ok
+	}
+
+	removeFrom := func(eType friend.EventType, events []*friend.Event) []*friend.Event {
+		return slices.DeleteFunc(events, func(e *friend.Event) bool {
+			r := refs(e)
+			matched, exclusive := matchRefs(e, *r, ids)
+
+			if !matched {
+				return false
+			}
+
+			j.touchEvent(eType, e.ID)
+
+			if mode == RemoveModeCascade && exclusive {
+				return true
+			}
+
+			*r = slices.DeleteFunc(*r, isRemoved)
+
+			return false
+		})
+	}
+
+	activities, notes := len(j.Activities), len(j.Notes)
+
+	j.Activities = removeFrom(friend.EventTypeActivity, j.Activities)
+	j.Notes = removeFrom(friend.EventTypeNote, j.Notes)
+
+	if len(j.Activities) != activities || len(j.Notes) != notes {
+		// deleted events could also mention other entities, so their counters have to be refreshed
+		j.repairCounters(&RepairReport{})
+	}
+}
+
+// matchRefs tells whether any of the event references is in the set and whether the event
+// refers to nothing else, counting both friends and locations
+func matchRefs(e *friend.Event, refs []string, ids map[string]struct{}) (bool, bool) {
+	matched := 0
+
+	for _, ref := range refs {
+		if _, ok := ids[ref]; ok {
+			matched++
+		}
+	}
+
+	return matched > 0, matched > 0 && matched == len(e.FriendIDs)+len(e.LocationIDs)
+}
+
+func (j *Journal) renameFriendRefs(oldID, newID string) {
+	for eType, e := range j.events() {
+		if renameRef(e.FriendIDs, oldID, newID) {
+			j.touchEvent(eType, e.ID)
+		}
+	}
+}
+
+func (j *Journal) renameLocationRefs(oldID, newID string) {
+	for eType, e := range j.events() {
+		if renameRef(e.LocationIDs, oldID, newID) {
+			j.touchEvent(eType, e.ID)
+		}
+	}
+
+	for _, f := range j.Friends {
+		if renameRef(f.Locations, oldID, newID) {
+			j.touch(EntityFriend, f.ID)
+		}
+	}
+}
+
+// renameRef replaces the old ID in the references and tells if it was there
+func renameRef(refs []string, oldID, newID string) bool {
+	renamed := false
+
+	for i, ref := range refs {
+		if ref == oldID {
+			refs[i] = newID
+			renamed = true
+		}
+	}
+
+	return renamed
+}
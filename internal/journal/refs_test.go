@@ -0,0 +1,150 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"testing"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/stretchr/testify/require"
+)
+
+func newRefsJournal() *Journal {
+	jr := &Journal{
+		Friends: []*friend.Person{
+			{ID: "alice", Name: "Alice", Locations: []string{"kyiv"}},
+			{ID: "bob", Name: "Bob"},
+		},
+		Locations: []*friend.Location{
+			{ID: "kyiv", Name: "Kyiv"},
+		},
+		Activities: []*friend.Event{
+			{ID: "a1", FriendIDs: []string{"alice"}, LocationIDs: []string{"kyiv"}},
+			{ID: "a2", FriendIDs: []string{"alice", "bob"}},
+			{ID: "a3", LocationIDs: []string{"kyiv"}},
+		},
+		Notes: []*friend.Event{
+			{ID: "n1", FriendIDs: []string{"alice"}},
+		},
+	}
+
+	jr.Init()
+
+	return jr
+}
+
+func TestJournal_RemoveFriends(t *testing.T) {
+	alice := friend.Person{ID: "alice", Name: "Alice"}
+
+	t.Run("detach", func(t *testing.T) {
+		jr := newRefsJournal()
+
+		require.Equal(t, EventRefs{Activities: 2, Notes: 1, Exclusive: 1}, jr.FriendRefs([]friend.Person{alice}))
+
+		jr.RemoveFriends([]friend.Person{alice}, RemoveModeDetach)
+
+		require.Len(t, jr.Friends, 1)
+		require.Len(t, jr.Activities, 3)
+		require.Empty(t, jr.Activities[0].FriendIDs)
+		require.Equal(t, []string{"bob"}, jr.Activities[1].FriendIDs)
+		require.Empty(t, jr.Notes[0].FriendIDs)
+	})
+
+	t.Run("cascade", func(t *testing.T) {
+		jr := newRefsJournal()
+
+		jr.RemoveFriends([]friend.Person{alice}, RemoveModeCascade)
+
+		// activities at places that are still in the journal are kept
+		require.Len(t, jr.Activities, 3)
+		require.Empty(t, jr.Activities[0].FriendIDs)
+		require.Equal(t, []string{"kyiv"}, jr.Activities[0].LocationIDs)
+		require.Equal(t, []string{"bob"}, jr.Activities[1].FriendIDs)
+		require.Empty(t, jr.Notes)
+		require.Equal(t, 2, jr.Locations[0].Activities)
+	})
+}
+
+func TestJournal_RemoveFriendsByID(t *testing.T) {
+	jr := newRefsJournal()
+	jr.AddFriend(friend.Person{ID: "alice-2", Name: "Alice"})
+
+	jr.RemoveFriends([]friend.Person{{ID: "alice-2", Name: "Alice"}}, RemoveModeCascade)
+
+	// the other Alice and her events stay
+	require.Equal(t, []string{"alice", "bob"}, []string{jr.Friends[0].ID, jr.Friends[1].ID})
+	require.Len(t, jr.Activities, 3)
+	require.Len(t, jr.Notes, 1)
+}
+
+func TestJournal_RemoveLocations(t *testing.T) {
+	kyiv := friend.Location{ID: "kyiv", Name: "Kyiv"}
+
+	t.Run("detach", func(t *testing.T) {
+		jr := newRefsJournal()
+
+		jr.RemoveLocations([]friend.Location{kyiv}, RemoveModeDetach)
+
+		require.Empty(t, jr.Locations)
+		require.Empty(t, jr.Friends[0].Locations)
+		require.Empty(t, jr.Activities[0].LocationIDs)
+		require.Len(t, jr.Activities, 3)
+	})
+
+	t.Run("cascade", func(t *testing.T) {
+		jr := newRefsJournal()
+
+		require.Equal(t, EventRefs{Activities: 2, Exclusive: 1}, jr.LocationRefs([]friend.Location{kyiv}))
+
+		jr.RemoveLocations([]friend.Location{kyiv}, RemoveModeCascade)
+
+		// dinners with friends there are kept, only activities about the place itself are deleted
+		require.Len(t, jr.Activities, 2)
+		require.Equal(t, "a1", jr.Activities[0].ID)
+		require.Empty(t, jr.Activities[0].LocationIDs)
+		require.Equal(t, "a2", jr.Activities[1].ID)
+	})
+}
+
+func TestJournal_UpdateFriendRenamesRefs(t *testing.T) {
+	jr := newRefsJournal()
+
+	jr.UpdateFriend(*jr.Friends[0], friend.Person{ID: "alice_smith", Name: "Alice Smith"})
+
+	require.Equal(t, []string{"alice_smith"}, jr.Activities[0].FriendIDs)
+	require.Equal(t, []string{"alice_smith", "bob"}, jr.Activities[1].FriendIDs)
+	require.Equal(t, []string{"alice_smith"}, jr.Notes[0].FriendIDs)
+}
+
+func TestJournal_UpdateLocationRenamesRefs(t *testing.T) {
+	jr := newRefsJournal()
+
+	jr.UpdateLocation(*jr.Locations[0], friend.Location{ID: "kyiv_ua", Name: "Kyiv"})
+
+	require.Equal(t, []string{"kyiv_ua"}, jr.Activities[0].LocationIDs)
+	require.Equal(t, []string{"kyiv_ua"}, jr.Friends[0].Locations)
+}
+
+func TestJournal_UpdateLocationKeepsCounters(t *testing.T) {
+	jr := newRefsJournal()
+	jr.Locations[0].Activities = 2
+	jr.Locations[0].Notes = 1
+
+	jr.UpdateLocation(*jr.Locations[0], friend.Location{Name: "Kyiv", Desc: "the capital"})
+
+	require.Equal(t, "the capital", jr.Locations[0].Desc)
+	require.Equal(t, 2, jr.Locations[0].Activities)
+	require.Equal(t, 1, jr.Locations[0].Notes)
+}
@@ -0,0 +1,395 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/tag"
+	"github.com/segmentio/ksuid"
+)
+
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "+"
+	ChangeUpdated ChangeKind = "~"
+	ChangeRemoved ChangeKind = "-"
+)
+
+// Verb describes the kind of change in words (e.g. "added")
+func (k ChangeKind) Verb() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	default:
+		return "updated"
+	}
+}
+
+// Change describes a single fix applied to the journal by Repair
+type Change struct {
+	Kind   ChangeKind `json:"kind"`
+	Entity string     `json:"entity"`
+	Field  string     `json:"field"`
+	Before string     `json:"before"`
+	After  string     `json:"after,omitempty"`
+}
+
+func (c Change) String() string {
+	if c.Kind == ChangeRemoved {
+		return fmt.Sprintf("%s %s: %s %s", c.Kind, c.Entity, c.Field, c.Before)
+	}
+
+	return fmt.Sprintf("%s %s: %s %s -> %s", c.Kind, c.Entity, c.Field, c.Before, c.After)
+}
+
+type RepairReport struct {
+	Changes []Change `json:"changes"`
+}
+
+func (r *RepairReport) Empty() bool {
+	return len(r.Changes) == 0
+}
+
+func (r *RepairReport) updated(entity, field, before, after string) {
+	r.Changes = append(r.Changes, Change{
+		Kind:   ChangeUpdated,
+		Entity: entity,
+		Field:  field,
+		Before: before,
+		After:  after,
+	})
+}
+
+func (r *RepairReport) removed(entity, field, value string) {
+	r.Changes = append(r.Changes, Change{
+		Kind:   ChangeRemoved,
+		Entity: entity,
+		Field:  field,
+		Before: value,
+	})
+}
+
+// Repair brings the journal back to a consistent state: it fixes duplicated IDs,
+// drops references to deleted friends and locations, deduplicates tags
+// and recomputes cached activity counters from the event lists.
+func (j *Journal) Repair() RepairReport {
+	var r RepairReport
+
+	j.repairIDs(&r)
+	j.repairReferences(&r)
+	j.repairTags(&r)
+	j.repairCounters(&r)
+
+	if !r.Empty() {
+		j.SetDirty(true)
+	}
+
+	return r
+}
+
+func (j *Journal) repairIDs(r *RepairReport) { //nolint:cyclop
+	friendIDs := make(map[string]struct{}, len(j.Friends))
+
+	for _, f := range j.Friends {
+		if id := uniqueSlug(f.ID, f.Name, friendIDs); id != f.ID {
+			r.updated("friend "+f.Name, "id", f.ID, id)
+			f.ID = id
+		}
+
+		friendIDs[f.ID] = struct{}{}
+	}
+
+	locationIDs := make(map[string]struct{}, len(j.Locations))
+
+	for _, l := range j.Locations {
+		if id := uniqueSlug(l.ID, l.Name, locationIDs); id != l.ID {
+			r.updated("location "+l.Name, "id", l.ID, id)
+			l.ID = id
+		}
+
+		locationIDs[l.ID] = struct{}{}
+	}
+
+	// the rest of entities are identified by generated IDs, so duplicates just get new ones
+	seen := make(map[string]struct{})
+
+	fixID := func(entity string, id *string) {
+		if _, ok := seen[*id]; ok || *id == "" {
+			newID := ksuid.New().String()
+
+			r.updated(entity, "id", *id, newID)
+			*id = newID
+		}
+
+		seen[*id] = struct{}{}
+	}
+
+	for _, e := range j.Activities {
+		fixID("activity "+e.ID, &e.ID)
+	}
+
+	for _, e := range j.Notes {
+		fixID("note "+e.ID, &e.ID)
+	}
+
+	for _, f := range j.Friends {
+		for _, c := range f.Contacts {
+			fixID("contact "+c.ID+" of "+f.ID, &c.ID)
+		}
+
+		for _, d := range f.Dates {
+			fixID("date "+d.ID+" of "+f.ID, &d.ID)
+
+			for _, rem := range d.Reminders {
+				fixID("reminder "+rem.ID+" of "+f.ID, &rem.ID)
+			}
+		}
+
+		for _, w := range f.Wishlist {
+			fixID("wishlist item "+w.ID+" of "+f.ID, &w.ID)
+		}
+	}
+
+	for _, rem := range j.Reminders {
+		fixID("reminder "+rem.ID, &rem.ID)
+	}
+}
+
+// uniqueSlug returns the ID itself or the first free "<id>-N" variant of it.
+// Missing IDs are generated from the entity name.
+func uniqueSlug(id, name string, taken map[string]struct{}) string {
+	if id == "" {
+		id = slug.Make(name)
+	}
+
+	if _, ok := taken[id]; !ok {
+		return id
+	}
+
+	for n := 2; ; n++ {
+		candidate := id + "-" + strconv.Itoa(n)
+
+		if _, ok := taken[candidate]; !ok {
+			return candidate
+		}
+	}
+}
+
+func (j *Journal) repairReferences(r *RepairReport) {
+	friendIDs := make(map[string]struct{}, len(j.Friends))
+	locationIDs := make(map[string]struct{}, len(j.Locations))
+
+	for _, f := range j.Friends {
+		friendIDs[f.ID] = struct{}{}
+	}
+
+	for _, l := range j.Locations {
+		locationIDs[l.ID] = struct{}{}
+	}
+
+	for _, f := range j.Friends {
+		f.Locations = filterRefs("friend "+f.ID, "location", f.Locations, locationIDs, r)
+	}
+
+	for eType, e := range j.events() {
+		entity := string(eType) + " " + e.ID
+
+		e.FriendIDs = filterRefs(entity, "friend", e.FriendIDs, friendIDs, r)
+		e.LocationIDs = filterRefs(entity, "location", e.LocationIDs, locationIDs, r)
+	}
+}
+
+// filterRefs drops references to unknown entities as well as repeated references
+func filterRefs(
+	entity, field string,
+	refs []string,
+	known map[string]struct{},
+	r *RepairReport,
+) []string {
+	if len(refs) == 0 {
+		return refs
+	}
+
+	kept := make([]string, 0, len(refs))
+	seen := make(map[string]struct{}, len(refs))
+
+	for _, ref := range refs {
+		if _, ok := known[ref]; !ok {
+			r.removed(entity, field, ref+" (not found)")
+			continue
+		}
+
+		if _, ok := seen[ref]; ok {
+			r.removed(entity, field, ref+" (duplicate)")
+			continue
+		}
+
+		seen[ref] = struct{}{}
+		kept = append(kept, ref)
+	}
+
+	return kept
+}
+
+func (j *Journal) repairTags(r *RepairReport) {
+	tags := make(tag.Tags, 0, len(j.Tags))
+	seen := make(map[string]struct{}, len(j.Tags))
+
+	for _, t := range j.Tags {
+		key := strings.ToLower(strings.TrimSpace(t.Name))
+
+		if key == "" {
+			r.removed("journal", "tag", "'"+t.Name+"' (empty)")
+			continue
+		}
+
+		if _, ok := seen[key]; ok {
+			r.removed("journal", "tag", "#"+t.Name+" (duplicate)")
+			continue
+		}
+
+		seen[key] = struct{}{}
+		tags = append(tags, t)
+	}
+
+	if len(tags) != len(j.Tags) {
+		j.Tags = tags
+	}
+}
+
+type eventCounters struct {
+	activities         int
+	notes              int
+	mostRecentActivity time.Time
+}
+
+func (c *eventCounters) count(eType friend.EventType, e *friend.Event) {
+	if eType == friend.EventTypeNote {
+		c.notes++
+		return
+	}
+
+	c.activities++
+
+	if e.Date.After(c.mostRecentActivity) {
+		c.mostRecentActivity = e.Date
+	}
+}
+
+func (j *Journal) repairCounters(r *RepairReport) {
+	friends := make(map[string]*eventCounters, len(j.Friends))
+	locations := make(map[string]*eventCounters, len(j.Locations))
+
+	counter := func(counters map[string]*eventCounters, id string) *eventCounters {
+		c, ok := counters[id]
+		if !ok {
+			c = &eventCounters{}
+			counters[id] = c
+		}
+
+		return c
+	}
+
+	for eType, e := range j.events() {
+		for _, fID := range e.FriendIDs {
+			counter(friends, fID).count(eType, e)
+		}
+
+		for _, lID := range e.LocationIDs {
+			counter(locations, lID).count(eType, e)
+		}
+	}
+
+	for _, f := range j.Friends {
+		c := counter(friends, f.ID)
+		entity := "friend " + f.ID
+		changes := len(r.Changes)
+
+		repairCounter(r, entity, "activities", &f.Activities, c.activities)
+		repairCounter(r, entity, "notes", &f.Notes, c.notes)
+		repairTime(r, entity, "most recent activity", &f.MostRecentActivity, c.mostRecentActivity)
+
+		if len(r.Changes) != changes {
+			j.touch(EntityFriend, f.ID)
+		}
+	}
+
+	for _, l := range j.Locations {
+		c := counter(locations, l.ID)
+		entity := "location " + l.ID
+		changes := len(r.Changes)
+
+		repairCounter(r, entity, "activities", &l.Activities, c.activities)
+		repairCounter(r, entity, "notes", &l.Notes, c.notes)
+		repairTime(r, entity, "most recent activity", &l.MostRecentActivity, c.mostRecentActivity)
+
+		if len(r.Changes) != changes {
+			j.touch(EntityLocation, l.ID)
+		}
+	}
+}
+
+func repairCounter(r *RepairReport, entity, field string, current *int, actual int) {
+	if *current == actual {
+		return
+	}
+
+	r.updated(entity, field, strconv.Itoa(*current), strconv.Itoa(actual))
+	*current = actual
+}
+
+func repairTime(r *RepairReport, entity, field string, current *time.Time, actual time.Time) {
+	if current.Equal(actual) {
+		return
+	}
+
+	r.updated(entity, field, formatRepairTime(*current), formatRepairTime(actual))
+	*current = actual
+}
+
+func formatRepairTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	return t.Format(time.DateTime)
+}
+
+// events iterates over both activities and notes along with the list they belong to
+func (j *Journal) events() iter.Seq2[friend.EventType, *friend.Event] {
+	return func(yield func(friend.EventType, *friend.Event) bool) {
+		for _, e := range j.Activities {
+			if !yield(friend.EventTypeActivity, e) {
+				return
+			}
+		}
+
+		for _, e := range j.Notes {
+			if !yield(friend.EventTypeNote, e) {
+				return
+			}
+		}
+	}
+}
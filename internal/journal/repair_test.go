@@ -0,0 +1,84 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/tag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal_Repair(t *testing.T) {
+	lastWeek := time.Date(2025, time.May, 6, 12, 0, 0, 0, time.UTC)
+	yesterday := time.Date(2025, time.May, 12, 12, 0, 0, 0, time.UTC)
+
+	jr := Journal{
+		Tags: tag.Tags{{Name: "coffee"}, {Name: "Coffee"}, {Name: "hiking"}},
+		Friends: []*friend.Person{
+			{ID: "alice", Name: "Alice", Activities: 10},
+			{ID: "alice", Name: "Alice Smith"},
+			{ID: "bob", Name: "Bob", Locations: []string{"kyiv", "gone"}},
+		},
+		Locations: []*friend.Location{
+			{ID: "kyiv", Name: "Kyiv"},
+		},
+		Activities: []*friend.Event{
+			{ID: "a1", Date: lastWeek, FriendIDs: []string{"alice", "bob"}, LocationIDs: []string{"kyiv"}},
+			{ID: "a2", Date: yesterday, FriendIDs: []string{"alice", "alice", "charlie"}},
+		},
+		Notes: []*friend.Event{
+			{ID: "a1", Date: yesterday, FriendIDs: []string{"bob"}},
+		},
+	}
+
+	report := jr.Repair()
+
+	require.False(t, report.Empty())
+	require.True(t, jr.IsDirty())
+
+	require.Equal(t, "alice", jr.Friends[0].ID)
+	require.Equal(t, "alice-2", jr.Friends[1].ID)
+	require.Equal(t, []string{"kyiv"}, jr.Friends[2].Locations)
+
+	require.Equal(t, tag.Tags{{Name: "coffee"}, {Name: "hiking"}}, jr.Tags)
+
+	require.Equal(t, []string{"alice"}, jr.Activities[1].FriendIDs)
+	require.NotEqual(t, "a1", jr.Notes[0].ID)
+
+	alice := jr.Friends[0]
+	require.Equal(t, 2, alice.Activities)
+	require.Equal(t, 0, alice.Notes)
+	require.Equal(t, yesterday, alice.MostRecentActivity)
+
+	bob := jr.Friends[2]
+	require.Equal(t, 1, bob.Activities)
+	require.Equal(t, 1, bob.Notes)
+	require.Equal(t, lastWeek, bob.MostRecentActivity)
+
+	kyiv := jr.Locations[0]
+	require.Equal(t, 1, kyiv.Activities)
+	require.Equal(t, lastWeek, kyiv.MostRecentActivity)
+
+	// the second run finds nothing to fix
+	jr.SetDirty(false)
+
+	report = jr.Repair()
+
+	require.True(t, report.Empty())
+	require.False(t, jr.IsDirty())
+}
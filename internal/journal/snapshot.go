@@ -0,0 +1,133 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+const snapshotLabelLimit = 50
+
+// EntityChange tells that an entity was added, updated or removed by a change of the journal
+type EntityChange struct {
+	Kind   ChangeKind `json:"kind"`
+	Entity string     `json:"entity"`
+	ID     string     `json:"id"`
+	Label  string     `json:"label"`
+}
+
+func (c EntityChange) String() string {
+	return fmt.Sprintf("%s %s: %s", c.Entity, c.Kind.Verb(), c.Label)
+}
+
+type snapshotKey struct {
+	entity string
+	id     string
+}
+
+type snapshotEntry struct {
+	label   string
+	content string
+}
+
+// Snapshot captures the state of journal entities to tell what a change did to them
+type Snapshot struct {
+	entries map[snapshotKey]snapshotEntry
+	order   []snapshotKey
+}
+
+// Snapshot captures the current state of the journal entities.
+// Entities are compared in the form they are saved in, so resolved fields don't count as changes.
+func (j *Journal) Snapshot() Snapshot {
+	s := Snapshot{entries: make(map[snapshotKey]snapshotEntry)}
+
+	for _, f := range j.Friends {
+		s.add(EntityFriend, f.ID, f.Name, f)
+	}
+
+	for _, l := range j.Locations {
+		s.add(EntityLocation, l.ID, l.Name, l)
+	}
+
+	for _, e := range j.Activities {
+		s.add(EntityActivity, e.ID, e.Desc, e)
+	}
+
+	for _, e := range j.Notes {
+		s.add(EntityNote, e.ID, e.Desc, e)
+	}
+
+	for _, r := range j.Reminders {
+		s.add(EntityReminder, r.ID, r.Desc, r)
+	}
+
+	for _, t := range j.Tags {
+		s.add(EntityTag, t.Name, t.Name, t)
+	}
+
+	return s
+}
+
+func (s *Snapshot) add(entity, id, label string, v any) {
+	content, err := toml.Marshal(v)
+	if err != nil {
+		// can't be saved either, so tell it apart from any other state
+		content = []byte(err.Error())
+	}
+
+	if label == "" {
+		label = id
+	}
+
+	if r := []rune(label); len(r) > snapshotLabelLimit {
+		label = string(r[:snapshotLabelLimit-1]) + "…"
+	}
+
+	key := snapshotKey{entity: entity, id: id}
+
+	s.entries[key] = snapshotEntry{label: label, content: string(content)}
+	s.order = append(s.order, key)
+}
+
+// Changes lists entities added, updated and removed since the snapshot
+func (s Snapshot) Changes(after Snapshot) []EntityChange {
+	var changes []EntityChange
+
+	change := func(kind ChangeKind, key snapshotKey, label string) {
+		changes = append(changes, EntityChange{Kind: kind, Entity: key.entity, ID: key.id, Label: label})
+	}
+
+	for _, key := range after.order {
+		entry := after.entries[key]
+		old, existed := s.entries[key]
+
+		switch {
+		case !existed:
+			change(ChangeAdded, key, entry.label)
+		case old.content != entry.content:
+			change(ChangeUpdated, key, entry.label)
+		}
+	}
+
+	for _, key := range s.order {
+		if _, exists := after.entries[key]; !exists {
+			change(ChangeRemoved, key, s.entries[key].label)
+		}
+	}
+
+	return changes
+}
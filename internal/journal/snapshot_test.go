@@ -0,0 +1,57 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_Changes(t *testing.T) {
+	t.Parallel()
+
+	jr := Journal{
+		Friends:   []*friend.Person{{ID: "jim", Name: "Jim Halpert"}},
+		Locations: []*friend.Location{{ID: "scranton", Name: "Scranton"}},
+	}
+
+	jr.Init()
+
+	before := jr.Snapshot()
+	require.Empty(t, before.Changes(jr.Snapshot()))
+
+	jr.AddFriend(friend.Person{ID: "pam", Name: "Pam Beesly"})
+
+	_, err := jr.AddEvent(friend.Event{
+		Type: friend.EventTypeActivity,
+		Date: time.Date(2026, 10, 16, 19, 0, 0, 0, time.UTC),
+		Desc: "Dinner with Jim at the Chili's, where Michael hosted the Dundies once again",
+	})
+	require.NoError(t, err)
+
+	jr.RemoveLocations([]friend.Location{*jr.Locations[0]}, RemoveModeDetach)
+
+	changes := before.Changes(jr.Snapshot())
+	require.Len(t, changes, 4)
+
+	// the activity is counted for Jim
+	require.Equal(t, "friend updated: Jim Halpert", changes[0].String())
+	require.Equal(t, "friend added: Pam Beesly", changes[1].String())
+	require.Equal(t, "activity added: Dinner with Jim at the Chili's, where Michael hos…", changes[2].String())
+	require.Equal(t, EntityChange{Kind: ChangeRemoved, Entity: "location", ID: "scranton", Label: "Scranton"}, changes[3])
+}
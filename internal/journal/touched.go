@@ -0,0 +1,83 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/roma-glushko/frens/internal/friend"
+)
+
+// Kinds of journal entities
+const (
+	EntityFriend   = "friend"
+	EntityLocation = "location"
+	EntityActivity = "activity"
+	EntityNote     = "note"
+	EntityReminder = "reminder"
+	EntityTag      = "tag"
+)
+
+// EntityRef points to a journal entity by its kind and ID. Tags are pointed to by their names.
+type EntityRef struct {
+	Entity string
+	ID     string
+}
+
+// touch marks the entity changed. Contacts, dates and wishlist items are parts of their friends.
+func (j *Journal) touch(entity, id string) {
+	if !j.dirty {
+		j.touched = make(map[EntityRef]struct{})
+	}
+
+	j.dirty = true
+
+	// the journal may have been marked dirty as a whole already
+	if j.touched != nil {
+		j.touched[EntityRef{Entity: entity, ID: id}] = struct{}{}
+	}
+}
+
+func (j *Journal) touchEvent(t friend.EventType, id string) {
+	j.touch(eventEntity(t), id)
+}
+
+func eventEntity(t friend.EventType) string {
+	if t == friend.EventTypeNote {
+		return EntityNote
+	}
+
+	return EntityActivity
+}
+
+// Touched lists entities changed since the journal was saved, so stores can write only them.
+// It's false if the journal was marked dirty without telling what has changed, so any entity may have.
+func (j *Journal) Touched() ([]EntityRef, bool) {
+	if !j.dirty {
+		return nil, true
+	}
+
+	if j.touched == nil {
+		return nil, false
+	}
+
+	refs := slices.SortedFunc(maps.Keys(j.touched), func(a, b EntityRef) int {
+		return cmp.Or(cmp.Compare(a.Entity, b.Entity), cmp.Compare(a.ID, b.ID))
+	})
+
+	return refs, true
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"testing"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal_Touched(t *testing.T) {
+	t.Parallel()
+
+	jr := Journal{
+		Friends:   []*friend.Person{{ID: "jim", Name: "Jim"}, {ID: "pam", Name: "Pam"}},
+		Locations: []*friend.Location{{ID: "scranton", Name: "Scranton"}},
+	}
+
+	jr.Init()
+
+	touched, ok := jr.Touched()
+	require.True(t, ok)
+	require.Empty(t, touched)
+
+	e, err := jr.AddEvent(friend.Event{Type: friend.EventTypeNote, Desc: "Jim pranked Dwight in Scranton #prank"})
+	require.NoError(t, err)
+
+	touched, ok = jr.Touched()
+	require.True(t, ok)
+	require.Equal(t, []EntityRef{
+		{Entity: EntityFriend, ID: "jim"},
+		{Entity: EntityLocation, ID: "scranton"},
+		{Entity: EntityNote, ID: e.ID},
+		{Entity: EntityTag, ID: "prank"},
+	}, touched)
+
+	jr.SetDirty(false)
+
+	jr.UpdateFriend(friend.Person{ID: "jim"}, friend.Person{ID: "jim_halpert", Name: "Jim Halpert"})
+
+	touched, ok = jr.Touched()
+	require.True(t, ok)
+	require.Equal(t, []EntityRef{
+		{Entity: EntityFriend, ID: "jim"},
+		{Entity: EntityFriend, ID: "jim_halpert"},
+		{Entity: EntityNote, ID: e.ID},
+	}, touched)
+
+	// the journal changed without journal methods is written as a whole
+	jr.SetDirty(true)
+	jr.UpdateFriend(friend.Person{ID: "pam"}, friend.Person{Name: "Pam Beesly"})
+
+	_, ok = jr.Touched()
+	require.False(t, ok)
+}
@@ -31,13 +31,14 @@ var (
 		FormatLocationMarkers,
 	)
 	FormatEventQuery = fmt.Sprintf(
-		"[SEARCH TERM] [%s] [%s] [$since:DATE] [$until:DATE] [$sort:SORT_OPTION] [$order:ORDER_OPTION]",
+		"[SEARCH TERM] [%s] [%s] [$with:FRIEND1[,FRIEND2...]] [$since:DATE] [$until:DATE] [$sort:SORT_OPTION] [$order:ORDER_OPTION]",
 		FormatTags,
 		FormatLocationMarkers,
 	)
 )
 
 type eventProps struct {
+	With      string `frentxt:"with"`
 	SortBy    string `frentxt:"sort"`
 	SortOrder string `frentxt:"order"`
 	Since     string `frentxt:"since"`
@@ -128,6 +129,7 @@ func ExtractEventQuery(q string) (friend.ListEventQuery, error) {
 
 	return friend.ListEventQuery{
 		Keyword:   search,
+		Friends:   extractFriendRefs(props.With),
 		Tags:      tags,
 		Locations: locations,
 		Since:     ExtractDate(props.Since),
@@ -136,3 +138,24 @@ func ExtractEventQuery(q string) (friend.ListEventQuery, error) {
 		SortOrder: friend.SortOrderOption(props.SortOrder),
 	}, nil
 }
+
+// extractFriendRefs splits comma-separated friend references.
+// Property values can't contain spaces, so underscores stand for them (e.g. $with:jim_halpert).
+func extractFriendRefs(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	parts := strings.Split(s, ",")
+	refs := make([]string, 0, len(parts))
+
+	for _, p := range parts {
+		p = strings.TrimSpace(strings.ReplaceAll(p, "_", " "))
+
+		if p != "" {
+			refs = append(refs, p)
+		}
+	}
+
+	return refs
+}
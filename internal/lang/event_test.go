@@ -101,6 +101,13 @@ func TestExtractEventQuery(t *testing.T) {
 				Locations: []string{"scranton", "utica"},
 			},
 		},
+		{
+			title: "friends only",
+			input: "$with:jim_halpert,Pam",
+			query: friend.ListEventQuery{
+				Friends: []string{"jim halpert", "Pam"},
+			},
+		},
 		{
 			title: "sort & order",
 			input: "$sort:alpha $order:reverse",
@@ -138,6 +145,7 @@ func TestExtractEventQuery(t *testing.T) {
 
 			require.Equal(t, tt.query.Keyword, q.Keyword)
 			require.ElementsMatch(t, tt.query.Tags, q.Tags)
+			require.ElementsMatch(t, tt.query.Friends, q.Friends)
 			require.ElementsMatch(t, tt.query.Locations, q.Locations)
 			require.WithinDuration(t, tt.query.Since, q.Since, 1*time.Second)
 			require.WithinDuration(t, tt.query.Until, q.Until, 1*time.Second)
@@ -0,0 +1,191 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/tag"
+)
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+var FormatReminderInfo = fmt.Sprintf(
+	"[DATE %s] [DESCRIPTION] [%s] [$every:once|monthly|yearly] [$before:DURATION] [$after:DURATION]",
+	Separator,
+	FormatTags,
+)
+
+type reminderProps struct {
+	Every  string `frentxt:"every"`
+	Before string `frentxt:"before"`
+	After  string `frentxt:"after"`
+}
+
+// ExtractDuration parses durations like "3d", "2w" or "12h" (days and weeks on top of time.ParseDuration).
+func ExtractDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+
+	if s == "" {
+		return 0, ErrNoInfo
+	}
+
+	unit := time.Duration(0)
+
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = day
+	case strings.HasSuffix(s, "w"):
+		unit = week
+	}
+
+	if unit == 0 {
+		return time.ParseDuration(s)
+	}
+
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration '%s': %w", s, err)
+	}
+
+	return time.Duration(n) * unit, nil
+}
+
+// RenderDuration renders a duration in the shortest form ExtractDuration understands.
+func RenderDuration(d time.Duration) string {
+	switch {
+	case d != 0 && d%week == 0:
+		return strconv.Itoa(int(d/week)) + "w"
+	case d != 0 && d%day == 0:
+		return strconv.Itoa(int(d/day)) + "d"
+	default:
+		return d.String()
+	}
+}
+
+func ExtractReminder(s string) (friend.Reminder, error) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return friend.Reminder{}, ErrNoInfo
+	}
+
+	props, err := ExtractProps[reminderProps](s)
+	if err != nil {
+		return friend.Reminder{}, fmt.Errorf("failed to parse reminder properties: %w", err)
+	}
+
+	tags := tag.Tags(ExtractTags(s)).ToNames()
+
+	s = RemoveTags(s)
+	s = RemoveProps(s)
+
+	var dateExpr, desc string
+
+	parts := strings.SplitN(s, Separator, 2)
+
+	if len(parts) == 2 {
+		dateExpr = strings.TrimSpace(parts[0])
+		desc = strings.TrimSpace(parts[1])
+	} else {
+		desc = strings.TrimSpace(parts[0])
+	}
+
+	r := friend.Reminder{
+		DateExpr: dateExpr,
+		Desc:     desc,
+		Tags:     tags,
+	}
+
+	if props.Every != "" {
+		if err := friend.ValidateRecurrence(props.Every); err != nil {
+			return friend.Reminder{}, err
+		}
+
+		r.Recurrence = strings.ToLower(props.Every)
+	}
+
+	if props.Before != "" && props.After != "" {
+		return friend.Reminder{}, errors.New(
+			"reminder can be set either before or after the date, not both",
+		)
+	}
+
+	if props.Before != "" {
+		offset, err := ExtractDuration(props.Before)
+		if err != nil {
+			return friend.Reminder{}, fmt.Errorf("failed to parse reminder offset: %w", err)
+		}
+
+		r.OffsetDirection = friend.OffsetDirectionBefore
+		r.Offset = &offset
+	}
+
+	if props.After != "" {
+		offset, err := ExtractDuration(props.After)
+		if err != nil {
+			return friend.Reminder{}, fmt.Errorf("failed to parse reminder offset: %w", err)
+		}
+
+		r.OffsetDirection = friend.OffsetDirectionAfter
+		r.Offset = &offset
+	}
+
+	return r, nil
+}
+
+func RenderReminder(r friend.Reminder) string {
+	var sb strings.Builder
+
+	if r.DateExpr != "" {
+		sb.WriteString(r.DateExpr)
+		sb.WriteString(" ")
+		sb.WriteString(Separator)
+		sb.WriteString(" ")
+	}
+
+	sb.WriteString(r.Desc)
+
+	if len(r.Tags) > 0 {
+		sb.WriteString(" ")
+		sb.WriteString(RenderTags(r.Tags))
+	}
+
+	props := reminderProps{Every: r.Recurrence}
+
+	if r.Offset != nil {
+		switch r.OffsetDirection {
+		case friend.OffsetDirectionAfter:
+			props.After = RenderDuration(*r.Offset)
+		default:
+			props.Before = RenderDuration(*r.Offset)
+		}
+	}
+
+	if rendered := RenderProps(props); rendered != "" {
+		sb.WriteString(" ")
+		sb.WriteString(rendered)
+	}
+
+	return strings.TrimSpace(sb.String())
+}
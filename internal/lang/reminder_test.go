@@ -0,0 +1,108 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lang
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected time.Duration
+		rendered string
+	}{
+		{input: "3d", expected: 3 * 24 * time.Hour, rendered: "3d"},
+		{input: "2w", expected: 14 * 24 * time.Hour, rendered: "2w"},
+		{input: "12h", expected: 12 * time.Hour, rendered: "12h0m0s"},
+		{input: "1h30m", expected: 90 * time.Minute, rendered: "1h30m0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := ExtractDuration(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, d)
+			require.Equal(t, tt.rendered, RenderDuration(d))
+		})
+	}
+}
+
+func TestExtractReminder(t *testing.T) {
+	t.Parallel()
+
+	week := 7 * 24 * time.Hour
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		input    string
+		expected friend.Reminder
+	}{
+		{
+			name:  "description only",
+			input: "buy a gift #gifts $before:1w",
+			expected: friend.Reminder{
+				Desc:            "buy a gift",
+				Tags:            []string{"gifts"},
+				OffsetDirection: friend.OffsetDirectionBefore,
+				Offset:          &week,
+			},
+		},
+		{
+			name:  "standalone with date",
+			input: "2025-12-01 :: renew passport $every:yearly $after:1d",
+			expected: friend.Reminder{
+				DateExpr:        "2025-12-01",
+				Desc:            "renew passport",
+				Tags:            []string{},
+				Recurrence:      friend.RecurrenceYearly,
+				OffsetDirection: friend.OffsetDirectionAfter,
+				Offset:          &day,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := ExtractReminder(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, r)
+		})
+	}
+}
+
+func TestExtractReminder_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ExtractReminder("")
+	require.ErrorIs(t, err, ErrNoInfo)
+
+	_, err = ExtractReminder("call mom $before:1d $after:1d")
+	require.Error(t, err)
+
+	_, err = ExtractReminder("call mom $every:weekly")
+	require.Error(t, err)
+}
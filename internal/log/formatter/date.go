@@ -23,6 +23,7 @@ import (
 	"github.com/roma-glushko/frens/internal/friend"
 	"github.com/roma-glushko/frens/internal/lang"
 	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/utils"
 )
 
 func init() {
@@ -59,6 +60,10 @@ func (f DateTextFormatter) formatCompact(dt *friend.Date) string {
 		parts = append(parts, dt.Person)
 	}
 
+	if dt.Next != nil {
+		parts = append(parts, dt.Next.Date.Format("2006-01-02"))
+	}
+
 	if len(dt.Tags) > 0 {
 		parts = append(parts, tagStyle.Render(lang.RenderTags(dt.Tags)))
 	}
@@ -71,6 +76,11 @@ func (f DateTextFormatter) formatRegular(dt *friend.Date) string {
 
 	sb.WriteString(fmt.Sprintf("[%s] %s", idStyle.Render(dt.ID), labelStyle.Render(dt.DateExpr)))
 
+	if dt.Next != nil {
+		sb.WriteString("\n")
+		sb.WriteString(" * next: " + renderOccurrence(dt.Next))
+	}
+
 	if len(dt.Tags) > 0 {
 		sb.WriteString("\n")
 		sb.WriteString(" * " + tagStyle.Render(lang.RenderTags(dt.Tags)))
@@ -91,7 +101,7 @@ func (f DateTextFormatter) formatRegular(dt *friend.Date) string {
 }
 
 func (f DateTextFormatter) FormatList(ctx log.FormatterContext, el any) (string, error) {
-	dates, ok := el.([]*friend.Date)
+	dates, ok := el.([]friend.Date)
 
 	if !ok {
 		return "", ErrInvalidEntity
@@ -103,20 +113,28 @@ func (f DateTextFormatter) FormatList(ctx log.FormatterContext, el any) (string,
 
 	for _, dt := range dates {
 		if ctx.Density == log.DensityCompact {
+			nextAt := ""
+
+			if dt.Next != nil {
+				nextAt = dt.Next.Date.Format("2006-01-02")
+			}
+
 			_, _ = fmt.Fprintf(
 				w,
-				"%s\t%s\t%s\n",
+				"%s\t%s\t%s\t%s\n",
 				idStyle.Render(dt.ID),
 				dt.Person,
 				dt.DateExpr,
+				nextAt,
 			)
 		} else {
 			_, _ = fmt.Fprintf(
 				w,
-				" %s\t%s\t%s\t%s\n",
+				" %s\t%s\t%s\t%s\t%s\n",
 				idStyle.Render(dt.ID),
 				labelStyle.Render(dt.Person),
 				labelStyle.Render(dt.DateExpr),
+				renderOccurrence(dt.Next),
 				tagStyle.Render(lang.RenderTags(dt.Tags)),
 			)
 		}
@@ -126,3 +144,27 @@ func (f DateTextFormatter) FormatList(ctx log.FormatterContext, el any) (string,
 
 	return buf.String(), nil
 }
+
+// renderOccurrence describes when the date happens next e.g. "Oct 20, 2026 (in 4 days, 36 years)"
+func renderOccurrence(o *friend.Occurrence) string {
+	if o == nil {
+		return ""
+	}
+
+	var when string
+
+	switch o.DaysLeft {
+	case 0:
+		when = "today"
+	case 1:
+		when = "tomorrow"
+	default:
+		when = fmt.Sprintf("in %d days", o.DaysLeft)
+	}
+
+	if o.Years > 0 {
+		when += fmt.Sprintf(", %d %s", o.Years, utils.P(o.Years, "year", "years"))
+	}
+
+	return fmt.Sprintf("%s (%s)", o.Date.Format("Jan 2, 2006"), when)
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/roma-glushko/frens/internal/history"
+	"github.com/roma-glushko/frens/internal/log"
+)
+
+func init() {
+	log.RegisterFormatter(log.FormatText, history.Revision{}, RevisionTextFormatter{})
+}
+
+// shortCommit is how many characters of commit hashes are shown, like git does
+const shortCommit = 7
+
+type RevisionTextFormatter struct{}
+
+var _ log.Formatter = (*RevisionTextFormatter)(nil)
+
+func (f RevisionTextFormatter) FormatSingle(ctx log.FormatterContext, e any) (string, error) {
+	var rev history.Revision
+
+	switch v := e.(type) {
+	case history.Revision:
+		rev = v
+	case *history.Revision:
+		rev = *v
+	default:
+		return "", ErrInvalidEntity
+	}
+
+	if ctx.Density == log.DensityCompact {
+		return fmt.Sprintf("%s %s %s %s\n",
+			idStyle.Render(renderCommit(rev.Commit)),
+			rev.Date.Format("2006-01-02"),
+			rev.Kind.Verb(),
+			rev.Message,
+		), nil
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("[%s] %s %s\n",
+		idStyle.Render(renderCommit(rev.Commit)),
+		labelStyle.Render(rev.Date.Format("Mon Jan 2, 2006 15:04")),
+		rev.Kind.Verb(),
+	))
+
+	sb.WriteString(" " + log.BulletChar + " " + rev.Message + countLabel.Render("by "+rev.Author) + "\n")
+
+	for _, c := range rev.Changes {
+		sb.WriteString("   " + c.String() + "\n")
+	}
+
+	return sb.String(), nil
+}
+
+func (f RevisionTextFormatter) FormatList(ctx log.FormatterContext, el any) (string, error) {
+	revisions, ok := el.([]history.Revision)
+	if !ok {
+		return "", ErrInvalidEntity
+	}
+
+	var sb strings.Builder
+
+	for i, rev := range revisions {
+		if i > 0 && ctx.Density != log.DensityCompact {
+			sb.WriteString("\n")
+		}
+
+		out, err := f.FormatSingle(ctx, rev)
+		if err != nil {
+			return "", err
+		}
+
+		sb.WriteString(out)
+	}
+
+	return sb.String(), nil
+}
+
+func renderCommit(hash string) string {
+	if len(hash) > shortCommit {
+		return hash[:shortCommit]
+	}
+
+	return hash
+}
@@ -18,7 +18,9 @@ import (
 	"encoding/json"
 
 	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/history"
 	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/store"
 )
 
 func init() {
@@ -28,6 +30,9 @@ func init() {
 	log.RegisterFormatter(log.FormatJSON, friend.Location{}, LocationJSONFormatter{})
 	log.RegisterFormatter(log.FormatJSON, friend.Date{}, DateJSONFormatter{})
 	log.RegisterFormatter(log.FormatJSON, friend.WishlistItem{}, WishlistItemJSONFormatter{})
+	log.RegisterFormatter(log.FormatJSON, friend.Reminder{}, ReminderJSONFormatter{})
+	log.RegisterFormatter(log.FormatJSON, history.Revision{}, RevisionJSONFormatter{})
+	log.RegisterFormatter(log.FormatJSON, store.Operation{}, OperationJSONFormatter{})
 }
 
 // ============================================================================
@@ -209,7 +214,7 @@ func (f DateJSONFormatter) FormatSingle(_ log.FormatterContext, e any) (string,
 }
 
 func (f DateJSONFormatter) FormatList(_ log.FormatterContext, el any) (string, error) {
-	dates, ok := el.([]*friend.Date)
+	dates, ok := el.([]friend.Date)
 	if !ok {
 		return "", ErrInvalidEntity
 	}
@@ -263,3 +268,117 @@ func (f WishlistItemJSONFormatter) FormatList(_ log.FormatterContext, el any) (s
 
 	return string(data) + "\n", nil
 }
+
+// ============================================================================
+// Reminder JSON Formatter
+// ============================================================================
+
+type ReminderJSONFormatter struct{}
+
+var _ log.Formatter = (*ReminderJSONFormatter)(nil)
+
+func (f ReminderJSONFormatter) FormatSingle(_ log.FormatterContext, e any) (string, error) {
+	var r friend.Reminder
+
+	switch v := e.(type) {
+	case friend.Reminder:
+		r = v
+	case *friend.Reminder:
+		r = *v
+	default:
+		return "", ErrInvalidEntity
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data) + "\n", nil
+}
+
+func (f ReminderJSONFormatter) FormatList(_ log.FormatterContext, el any) (string, error) {
+	reminders, ok := el.([]friend.Reminder)
+	if !ok {
+		return "", ErrInvalidEntity
+	}
+
+	data, err := json.MarshalIndent(reminders, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data) + "\n", nil
+}
+
+// ============================================================================
+// Revision JSON Formatter
+// ============================================================================
+
+type RevisionJSONFormatter struct{}
+
+var _ log.Formatter = (*RevisionJSONFormatter)(nil)
+
+func (f RevisionJSONFormatter) FormatSingle(_ log.FormatterContext, e any) (string, error) {
+	rev, ok := e.(history.Revision)
+	if !ok {
+		return "", ErrInvalidEntity
+	}
+
+	data, err := json.MarshalIndent(rev, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data) + "\n", nil
+}
+
+func (f RevisionJSONFormatter) FormatList(_ log.FormatterContext, el any) (string, error) {
+	revisions, ok := el.([]history.Revision)
+	if !ok {
+		return "", ErrInvalidEntity
+	}
+
+	data, err := json.MarshalIndent(revisions, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data) + "\n", nil
+}
+
+// ============================================================================
+// Operation JSON Formatter
+// ============================================================================
+
+type OperationJSONFormatter struct{}
+
+var _ log.Formatter = (*OperationJSONFormatter)(nil)
+
+func (f OperationJSONFormatter) FormatSingle(_ log.FormatterContext, e any) (string, error) {
+	op, ok := e.(store.Operation)
+	if !ok {
+		return "", ErrInvalidEntity
+	}
+
+	data, err := json.MarshalIndent(op, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data) + "\n", nil
+}
+
+func (f OperationJSONFormatter) FormatList(_ log.FormatterContext, el any) (string, error) {
+	ops, ok := el.([]store.Operation)
+	if !ok {
+		return "", ErrInvalidEntity
+	}
+
+	data, err := json.MarshalIndent(ops, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data) + "\n", nil
+}
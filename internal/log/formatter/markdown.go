@@ -19,7 +19,9 @@ import (
 	"strings"
 
 	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/history"
 	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/store"
 )
 
 func init() {
@@ -33,6 +35,9 @@ func init() {
 		friend.WishlistItem{},
 		WishlistItemMarkdownFormatter{},
 	)
+	log.RegisterFormatter(log.FormatMarkdown, friend.Reminder{}, ReminderMarkdownFormatter{})
+	log.RegisterFormatter(log.FormatMarkdown, history.Revision{}, RevisionMarkdownFormatter{})
+	log.RegisterFormatter(log.FormatMarkdown, store.Operation{}, OperationMarkdownFormatter{})
 }
 
 // Helper to render tags as markdown
@@ -429,6 +434,10 @@ func (f DateMarkdownFormatter) FormatSingle(_ log.FormatterContext, e any) (stri
 		sb.WriteString(fmt.Sprintf("- **Calendar:** %s\n", dt.Calendar))
 	}
 
+	if dt.Next != nil {
+		sb.WriteString(fmt.Sprintf("- **Next:** %s\n", renderOccurrence(dt.Next)))
+	}
+
 	if len(dt.Tags) > 0 {
 		sb.WriteString(fmt.Sprintf("- **Tags:** %s\n", renderTagsMd(dt.Tags)))
 	}
@@ -441,21 +450,22 @@ func (f DateMarkdownFormatter) FormatSingle(_ log.FormatterContext, e any) (stri
 }
 
 func (f DateMarkdownFormatter) FormatList(_ log.FormatterContext, el any) (string, error) {
-	dates, ok := el.([]*friend.Date)
+	dates, ok := el.([]friend.Date)
 	if !ok {
 		return "", ErrInvalidEntity
 	}
 
 	var sb strings.Builder
 
-	sb.WriteString("| ID | Person | Date | Tags |\n")
-	sb.WriteString("|---|---|---|---|\n")
+	sb.WriteString("| ID | Person | Date | Next | Tags |\n")
+	sb.WriteString("|---|---|---|---|---|\n")
 
 	for _, dt := range dates {
-		sb.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n",
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s | %s |\n",
 			dt.ID,
 			dt.Person,
 			dt.DateExpr,
+			renderOccurrence(dt.Next),
 			renderTagsMd(dt.Tags),
 		))
 	}
@@ -540,3 +550,178 @@ func (f WishlistItemMarkdownFormatter) FormatList(_ log.FormatterContext, el any
 
 	return sb.String(), nil
 }
+
+// ============================================================================
+// Reminder Markdown Formatter
+// ============================================================================
+
+type ReminderMarkdownFormatter struct{}
+
+var _ log.Formatter = (*ReminderMarkdownFormatter)(nil)
+
+func (f ReminderMarkdownFormatter) FormatSingle(_ log.FormatterContext, e any) (string, error) {
+	var r friend.Reminder
+
+	switch v := e.(type) {
+	case friend.Reminder:
+		r = v
+	case *friend.Reminder:
+		r = *v
+	default:
+		return "", ErrInvalidEntity
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("## %s\n\n", r.Label()))
+	sb.WriteString(fmt.Sprintf("- **ID:** `%s`\n", r.ID))
+
+	if r.Person != "" {
+		sb.WriteString(fmt.Sprintf("- **Person:** %s\n", r.Person))
+	}
+
+	if r.Anchor != nil && r.Anchor.DateExpr != "" {
+		sb.WriteString(fmt.Sprintf("- **Date:** %s\n", r.Anchor.DateExpr))
+	}
+
+	sb.WriteString(fmt.Sprintf("- **Schedule:** %s\n", renderSchedule(r)))
+
+	if !r.NextAt.IsZero() {
+		sb.WriteString(fmt.Sprintf("- **Next:** %s\n", r.NextAt.Format("2006-01-02")))
+	}
+
+	if len(r.Tags) > 0 {
+		sb.WriteString(fmt.Sprintf("- **Tags:** %s\n", renderTagsMd(r.Tags)))
+	}
+
+	return sb.String(), nil
+}
+
+func (f ReminderMarkdownFormatter) FormatList(_ log.FormatterContext, el any) (string, error) {
+	reminders, ok := el.([]friend.Reminder)
+	if !ok {
+		return "", ErrInvalidEntity
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("| ID | Next | Person | Reminder | Schedule | Tags |\n")
+	sb.WriteString("|---|---|---|---|---|---|\n")
+
+	for _, r := range reminders {
+		nextAt := ""
+
+		if !r.NextAt.IsZero() {
+			nextAt = r.NextAt.Format("2006-01-02")
+		}
+
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s | %s | %s |\n",
+			r.ID,
+			nextAt,
+			r.Person,
+			r.Label(),
+			renderSchedule(r),
+			renderTagsMd(r.Tags),
+		))
+	}
+
+	return sb.String(), nil
+}
+
+// ============================================================================
+// Revision Markdown Formatter
+// ============================================================================
+
+type RevisionMarkdownFormatter struct{}
+
+var _ log.Formatter = (*RevisionMarkdownFormatter)(nil)
+
+func (f RevisionMarkdownFormatter) FormatSingle(_ log.FormatterContext, e any) (string, error) {
+	rev, ok := e.(history.Revision)
+	if !ok {
+		return "", ErrInvalidEntity
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("## %s: %s\n\n", rev.Date.Format("2006-01-02 15:04"), rev.Kind.Verb()))
+	sb.WriteString(fmt.Sprintf("- **Commit:** `%s` %s\n", renderCommit(rev.Commit), rev.Message))
+	sb.WriteString(fmt.Sprintf("- **Author:** %s\n", rev.Author))
+
+	for _, c := range rev.Changes {
+		sb.WriteString(fmt.Sprintf("- **%s:** %s\n", c.Field, c.Diff()))
+	}
+
+	return sb.String(), nil
+}
+
+func (f RevisionMarkdownFormatter) FormatList(ctx log.FormatterContext, el any) (string, error) {
+	revisions, ok := el.([]history.Revision)
+	if !ok {
+		return "", ErrInvalidEntity
+	}
+
+	sections := make([]string, 0, len(revisions))
+
+	for _, rev := range revisions {
+		out, err := f.FormatSingle(ctx, rev)
+		if err != nil {
+			return "", err
+		}
+
+		sections = append(sections, out)
+	}
+
+	return strings.Join(sections, "\n"), nil
+}
+
+// ============================================================================
+// Operation Markdown Formatter
+// ============================================================================
+
+type OperationMarkdownFormatter struct{}
+
+var _ log.Formatter = (*OperationMarkdownFormatter)(nil)
+
+func (f OperationMarkdownFormatter) FormatSingle(_ log.FormatterContext, e any) (string, error) {
+	op, ok := e.(store.Operation)
+	if !ok {
+		return "", ErrInvalidEntity
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("## %d: %s", op.ID, op.At.Format("2006-01-02 15:04")))
+
+	if op.Undone {
+		sb.WriteString(" (undone)")
+	}
+
+	sb.WriteString("\n\n")
+
+	for _, c := range op.Changes {
+		sb.WriteString("- " + c.String() + "\n")
+	}
+
+	return sb.String(), nil
+}
+
+func (f OperationMarkdownFormatter) FormatList(ctx log.FormatterContext, el any) (string, error) {
+	ops, ok := el.([]store.Operation)
+	if !ok {
+		return "", ErrInvalidEntity
+	}
+
+	sections := make([]string, 0, len(ops))
+
+	for _, op := range ops {
+		out, err := f.FormatSingle(ctx, op)
+		if err != nil {
+			return "", err
+		}
+
+		sections = append(sections, out)
+	}
+
+	return strings.Join(sections, "\n"), nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formatter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/store"
+)
+
+func init() {
+	log.RegisterFormatter(log.FormatText, store.Operation{}, OperationTextFormatter{})
+}
+
+type OperationTextFormatter struct{}
+
+var _ log.Formatter = (*OperationTextFormatter)(nil)
+
+func (f OperationTextFormatter) FormatSingle(ctx log.FormatterContext, e any) (string, error) {
+	var op store.Operation
+
+	switch v := e.(type) {
+	case store.Operation:
+		op = v
+	case *store.Operation:
+		op = *v
+	default:
+		return "", ErrInvalidEntity
+	}
+
+	status := ""
+
+	if op.Undone {
+		status = countLabel.Render("(undone)")
+	}
+
+	if ctx.Density == log.DensityCompact {
+		summary := "no changes"
+
+		if len(op.Changes) > 0 {
+			summary = op.Changes[0].String()
+		}
+
+		if len(op.Changes) > 1 {
+			summary += countLabel.Render(fmt.Sprintf("+%d more", len(op.Changes)-1))
+		}
+
+		return fmt.Sprintf("%s %s %s%s\n",
+			idStyle.Render(strconv.Itoa(op.ID)),
+			op.At.Format("2006-01-02 15:04"),
+			summary,
+			status,
+		), nil
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("[%s] %s%s\n",
+		idStyle.Render(strconv.Itoa(op.ID)),
+		labelStyle.Render(op.At.Format("Mon Jan 2, 2006 15:04")),
+		status,
+	))
+
+	for _, c := range op.Changes {
+		sb.WriteString(" " + log.BulletChar + " " + c.String() + "\n")
+	}
+
+	return sb.String(), nil
+}
+
+func (f OperationTextFormatter) FormatList(ctx log.FormatterContext, el any) (string, error) {
+	ops, ok := el.([]store.Operation)
+	if !ok {
+		return "", ErrInvalidEntity
+	}
+
+	var sb strings.Builder
+
+	for i, op := range ops {
+		if i > 0 && ctx.Density != log.DensityCompact {
+			sb.WriteString("\n")
+		}
+
+		out, err := f.FormatSingle(ctx, op)
+		if err != nil {
+			return "", err
+		}
+
+		sb.WriteString(out)
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formatter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/lang"
+	"github.com/roma-glushko/frens/internal/log"
+)
+
+func init() {
+	log.RegisterFormatter(log.FormatText, friend.Reminder{}, ReminderTextFormatter{})
+}
+
+type ReminderTextFormatter struct{}
+
+var _ log.Formatter = (*ReminderTextFormatter)(nil)
+
+func (f ReminderTextFormatter) FormatSingle(ctx log.FormatterContext, e any) (string, error) {
+	var r friend.Reminder
+
+	switch v := e.(type) {
+	case friend.Reminder:
+		r = v
+	case *friend.Reminder:
+		r = *v
+	default:
+		return "", ErrInvalidEntity
+	}
+
+	if ctx.Density == log.DensityCompact {
+		return f.formatCompact(r), nil
+	}
+
+	return f.formatRegular(r), nil
+}
+
+func (f ReminderTextFormatter) formatCompact(r friend.Reminder) string {
+	parts := []string{idStyle.Render(r.ID), r.Label()}
+
+	if !r.NextAt.IsZero() {
+		parts = append(parts, r.NextAt.Format("2006-01-02"))
+	}
+
+	if r.Person != "" {
+		parts = append(parts, r.Person)
+	}
+
+	return strings.Join(parts, " ") + "\n"
+}
+
+func (f ReminderTextFormatter) formatRegular(r friend.Reminder) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("[%s] %s\n", idStyle.Render(r.ID), labelStyle.Render(r.Label())))
+
+	if r.Person != "" {
+		sb.WriteString(" " + log.BulletChar + " " + friendStyle.Render(r.Person) + "\n")
+	}
+
+	if r.Anchor != nil && r.Anchor.DateExpr != "" {
+		sb.WriteString(" " + log.BulletChar + " " + r.Anchor.DateExpr + "\n")
+	}
+
+	sb.WriteString(" " + log.BulletChar + " " + renderSchedule(r) + "\n")
+
+	if !r.NextAt.IsZero() {
+		sb.WriteString(" " + log.BulletChar + " next: " + r.NextAt.Format("Mon Jan 2, 2006") + "\n")
+	}
+
+	if len(r.Tags) > 0 {
+		sb.WriteString(" " + log.BulletChar + " " + tagStyle.Render(lang.RenderTags(r.Tags)) + "\n")
+	}
+
+	return sb.String()
+}
+
+func (f ReminderTextFormatter) FormatList(ctx log.FormatterContext, el any) (string, error) {
+	reminders, ok := el.([]friend.Reminder)
+
+	if !ok {
+		return "", ErrInvalidEntity
+	}
+
+	var buf bytes.Buffer
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', 0)
+
+	for _, r := range reminders {
+		nextAt := ""
+
+		if !r.NextAt.IsZero() {
+			nextAt = r.NextAt.Format("2006-01-02")
+		}
+
+		if ctx.Density == log.DensityCompact {
+			_, _ = fmt.Fprintf(
+				w,
+				"%s\t%s\t%s\t%s\n",
+				idStyle.Render(r.ID),
+				nextAt,
+				r.Person,
+				r.Label(),
+			)
+		} else {
+			_, _ = fmt.Fprintf(
+				w,
+				" %s\t%s\t%s\t%s\t%s\t%s\n",
+				idStyle.Render(r.ID),
+				labelStyle.Render(nextAt),
+				labelStyle.Render(r.Person),
+				r.Label(),
+				renderSchedule(r),
+				tagStyle.Render(lang.RenderTags(r.Tags)),
+			)
+		}
+	}
+
+	_ = w.Flush()
+
+	return buf.String(), nil
+}
+
+// renderSchedule describes how often and when the reminder fires e.g. "yearly, 3d before"
+func renderSchedule(r friend.Reminder) string {
+	schedule := r.Recurrence
+
+	if schedule == "" {
+		schedule = friend.RecurrenceOnce
+	}
+
+	if r.Offset != nil && *r.Offset > 0 {
+		direction := r.OffsetDirection
+
+		if direction == "" {
+			direction = friend.OffsetDirectionBefore
+		}
+
+		schedule += ", " + lang.RenderDuration(*r.Offset) + " " + direction
+	}
+
+	return schedule
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcher
+
+import "slices"
+
+// automaton is an Aho-Corasick automaton over lower-cased references.
+// References are added and removed incrementally, only the links of nodes affected by the change are updated.
+type automaton struct {
+	nodes []acNode
+}
+
+type acNode struct {
+	next map[byte]int32
+	fail int32
+	// failKids are the nodes that fail to this one, so links are updated without walking the whole trie
+	failKids []int32
+	// kidPos is the node position among failKids of its failure
+	kidPos int
+	// out links to the closest node on the failure chain that ends a reference (or -1)
+	out   int32
+	depth int32
+	key   string // the reference that ends at this node
+	end   bool
+}
+
+type occurrence struct {
+	key        string
+	start, end int
+}
+
+func newAutomaton() *automaton {
+	return &automaton{
+		nodes: []acNode{{out: -1}},
+	}
+}
+
+func (a *automaton) insert(key string) {
+	n := int32(0)
+
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+
+		child, ok := a.nodes[n].next[c]
+		if !ok {
+			child = a.addNode(n, c)
+		}
+
+		n = child
+	}
+
+	if !a.nodes[n].end {
+		a.nodes[n].key = key
+		a.nodes[n].end = true
+		a.updateOut(n)
+	}
+}
+
+// remove unmarks the reference. Its trie nodes are kept as they may be shared with other references.
+func (a *automaton) remove(key string) {
+	n := int32(0)
+
+	for i := 0; i < len(key); i++ {
+		child, ok := a.nodes[n].next[key[i]]
+		if !ok {
+			return
+		}
+
+		n = child
+	}
+
+	if a.nodes[n].end {
+		a.nodes[n].end = false
+		a.nodes[n].key = ""
+		a.updateOut(n)
+	}
+}
+
+// addNode appends a trie node and links it. Deeper nodes that end with the new node's string
+// but failed to a shorter suffix so far are relinked to it.
+func (a *automaton) addNode(parent int32, c byte) int32 {
+	n := int32(len(a.nodes))
+
+	a.nodes = append(a.nodes, acNode{out: -1, depth: a.nodes[parent].depth + 1})
+
+	if a.nodes[parent].next == nil {
+		a.nodes[parent].next = make(map[byte]int32)
+	}
+
+	a.nodes[parent].next[c] = n
+
+	fail := int32(0)
+
+	if parent != 0 {
+		fail = a.step(a.nodes[parent].fail, c)
+	}
+
+	a.link(n, fail)
+
+	// only nodes failing to the parent (directly or not) can get the new node as their failure
+	queue := slices.Clone(a.nodes[parent].failKids)
+
+	for len(queue) > 0 {
+		w := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+
+		child, ok := a.nodes[w].next[c]
+		if !ok {
+			queue = append(queue, a.nodes[w].failKids...)
+			continue
+		}
+
+		// nodes deeper in the failure subtree of w fail to child or to a longer suffix
+		if a.nodes[a.nodes[child].fail].depth < a.nodes[n].depth {
+			a.unlink(child)
+			a.link(child, n)
+		}
+	}
+
+	return n
+}
+
+// link sets the failure of the node and refreshes the output links that depend on it
+func (a *automaton) link(n, fail int32) {
+	a.nodes[n].fail = fail
+	a.nodes[n].kidPos = len(a.nodes[fail].failKids)
+	a.nodes[fail].failKids = append(a.nodes[fail].failKids, n)
+
+	a.nodes[n].out = a.outOf(fail)
+	a.updateOut(n)
+}
+
+// unlink drops the node from failKids of its failure moving the last kid into its place
+func (a *automaton) unlink(n int32) {
+	kids := a.nodes[a.nodes[n].fail].failKids
+	last := kids[len(kids)-1]
+
+	kids[a.nodes[n].kidPos] = last
+	a.nodes[last].kidPos = a.nodes[n].kidPos
+
+	a.nodes[a.nodes[n].fail].failKids = kids[:len(kids)-1]
+}
+
+// outOf tells what output link nodes failing to n should have
+func (a *automaton) outOf(n int32) int32 {
+	if n != 0 && a.nodes[n].end {
+		return n
+	}
+
+	return a.nodes[n].out
+}
+
+// updateOut propagates the output link of the node to its failure subtree, stopping at unchanged nodes
+func (a *automaton) updateOut(n int32) {
+	stack := []int32{n}
+
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		out := a.outOf(p)
+
+		for _, k := range a.nodes[p].failKids {
+			if a.nodes[k].out != out {
+				a.nodes[k].out = out
+				stack = append(stack, k)
+			}
+		}
+	}
+}
+
+// step follows the goto function falling back along failure links
+func (a *automaton) step(n int32, c byte) int32 {
+	for {
+		if next, ok := a.nodes[n].next[c]; ok {
+			return next
+		}
+
+		if n == 0 {
+			return 0
+		}
+
+		n = a.nodes[n].fail
+	}
+}
+
+// search reports every occurrence of every reference in the text, including overlapping ones
+func (a *automaton) search(text string) []occurrence {
+	var found []occurrence
+
+	n := int32(0)
+
+	for i := 0; i < len(text); i++ {
+		n = a.step(n, text[i])
+
+		for o := n; o > 0; o = a.nodes[o].out {
+			if a.nodes[o].end {
+				key := a.nodes[o].key
+				found = append(found, occurrence{key: key, start: i + 1 - len(key), end: i + 1})
+			}
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcher
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomaton_Search(t *testing.T) {
+	t.Parallel()
+
+	a := newAutomaton()
+
+	for _, key := range []string{"he", "she", "his", "hers"} {
+		a.insert(key)
+	}
+
+	require.ElementsMatch(t, []occurrence{
+		{key: "she", start: 1, end: 4},
+		{key: "he", start: 2, end: 4},
+		{key: "hers", start: 2, end: 6},
+	}, a.search("ushers"))
+
+	a.remove("he")
+	a.insert("us")
+
+	require.ElementsMatch(t, []occurrence{
+		{key: "us", start: 0, end: 2},
+		{key: "she", start: 1, end: 4},
+		{key: "hers", start: 2, end: 6},
+	}, a.search("ushers"))
+
+	a.remove("unknown")
+
+	require.Empty(t, a.search("nothing to find"))
+}
+
+// searchNaive finds every occurrence of every key one by one
+func searchNaive(keys map[string]bool, text string) []occurrence {
+	var found []occurrence
+
+	for key := range keys {
+		for i := 0; i+len(key) <= len(text); i++ {
+			if strings.HasPrefix(text[i:], key) {
+				found = append(found, occurrence{key: key, start: i, end: i + len(key)})
+			}
+		}
+	}
+
+	return found
+}
+
+func TestAutomaton_IncrementalUpdates(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+	randomText := func(n int) string {
+		b := make([]byte, n)
+
+		for i := range b {
+			b[i] = "abc"[rnd.IntN(3)]
+		}
+
+		return string(b)
+	}
+
+	a := newAutomaton()
+	keys := make(map[string]bool)
+
+	for range 500 {
+		key := randomText(1 + rnd.IntN(5))
+
+		if keys[key] && rnd.IntN(2) == 0 {
+			a.remove(key)
+			delete(keys, key)
+		} else {
+			a.insert(key)
+			keys[key] = true
+		}
+
+		text := randomText(30)
+
+		require.ElementsMatch(t, searchNaive(keys, text), a.search(text), text)
+	}
+}
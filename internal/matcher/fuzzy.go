@@ -0,0 +1,253 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcher
+
+import (
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+const (
+	// ExactConfidence is the confidence of exact case-insensitive matches
+	ExactConfidence = 1.0
+	// normalizedConfidence is the confidence of matches that differ in accents or script only
+	normalizedConfidence = 0.9
+)
+
+type Option func(m *options)
+
+type options struct {
+	fuzzy       bool
+	maxDistance int
+}
+
+// WithFuzzy enables typo-tolerant matching of normalized text.
+// The allowed edit distance also depends on the reference length, so short references have to match exactly.
+func WithFuzzy(maxDistance int) Option {
+	return func(o *options) {
+		o.fuzzy = true
+		o.maxDistance = max(maxDistance, 0)
+	}
+}
+
+// maxEdits bounds the number of typos by the reference length
+func maxEdits(refLen, limit int) int {
+	switch {
+	case refLen <= 4:
+		return 0
+	case refLen <= 8:
+		return min(1, limit)
+	default:
+		return min(2, limit)
+	}
+}
+
+// fuzzyConfidence scores a fuzzy match, so it always ranks below exact ones
+func fuzzyConfidence(distance, refLen int) float64 {
+	return normalizedConfidence * (1 - float64(distance)/float64(refLen))
+}
+
+type fuzzyKey struct {
+	words, normLen int
+}
+
+// fuzzyRef keeps what fuzzy matching checks inline, so references are skipped without looking up their patterns
+type fuzzyRef struct {
+	ref  string
+	norm string
+	mask uint32
+}
+
+func (p Pattern[T]) fuzzyKey() fuzzyKey {
+	return fuzzyKey{words: p.words, normLen: p.normLen}
+}
+
+type fuzzyHit[T Matchable] struct {
+	start, end int
+	pattern    Pattern[T]
+	confidence float64
+}
+
+// matchFuzzy finds references in the normalized text within the allowed edit distance,
+// skipping ranges that have already been matched exactly
+func (m *Matcher[T]) matchFuzzy(input string, matchedRanges [][2]int) []Match[T] { //nolint:cyclop
+	words := splitWords(input)
+
+	if len(words) == 0 {
+		return nil
+	}
+
+	var hits []fuzzyHit[T]
+
+	// an edit changes the length by one at most and no reference allows more than two edits
+	lenDiff := min(m.opts.maxDistance, 2)
+
+	for i := range words {
+		textLen, textMask := -1, uint32(0)
+
+		for j := i; j < len(words) && j-i < m.fuzzyWords; j++ {
+			k := j - i + 1
+			textLen += words[j].normLen + 1
+			textMask |= words[j].mask
+
+			start, end := words[i].start, words[j].end
+
+			if !validBoundaries(input, start, end) || overlapsAny(matchedRanges, start, end) {
+				continue
+			}
+
+			text := ""
+
+			for refLen := max(textLen-lenDiff, 1); refLen <= textLen+lenDiff; refLen++ {
+				limit := maxEdits(refLen, m.opts.maxDistance)
+
+				if textLen < refLen-limit || textLen > refLen+limit {
+					continue
+				}
+
+				for _, ref := range m.fuzzyRefs[fuzzyKey{words: k, normLen: refLen}] {
+					// the edit distance is bound by the rune set differences, so most references are skipped right away
+					if bits.OnesCount32(textMask^ref.mask) > 2*limit {
+						continue
+					}
+
+					if text == "" {
+						text = joinNorms(words[i : j+1])
+					}
+
+					d := editDistance(text, ref.norm, limit)
+
+					if d > limit {
+						continue
+					}
+
+					hits = append(hits, fuzzyHit[T]{
+						start:      start,
+						end:        end,
+						pattern:    m.EntityPatterns[ref.ref],
+						confidence: fuzzyConfidence(d, refLen),
+					})
+				}
+			}
+		}
+	}
+
+	// the most confident and then the longest hits win overlapping ranges
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].confidence != hits[j].confidence {
+			return hits[i].confidence > hits[j].confidence
+		}
+
+		if li, lj := hits[i].end-hits[i].start, hits[j].end-hits[j].start; li != lj {
+			return li > lj
+		}
+
+		if hits[i].start != hits[j].start {
+			return hits[i].start < hits[j].start
+		}
+
+		return hits[i].pattern.Ref < hits[j].pattern.Ref
+	})
+
+	var found []Match[T]
+
+	for _, h := range hits {
+		if overlapsAny(matchedRanges, h.start, h.end) {
+			continue
+		}
+
+		matchedRanges = append(matchedRanges, [2]int{h.start, h.end})
+
+		found = append(found, Match[T]{
+			Entities:   h.pattern.Entities,
+			MatchedRef: h.pattern.Ref,
+			Confidence: h.confidence,
+		})
+	}
+
+	return found
+}
+
+func joinNorms(words []word) string {
+	if len(words) == 1 {
+		return words[0].norm
+	}
+
+	norms := make([]string, 0, len(words))
+
+	for _, w := range words {
+		norms = append(norms, w.norm)
+	}
+
+	return strings.Join(norms, " ")
+}
+
+func overlapsAny(ranges [][2]int, start, end int) bool {
+	for _, r := range ranges {
+		if start < r[1] && end > r[0] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// editDistance computes the optimal string alignment distance (Levenshtein with transpositions),
+// giving up as soon as it exceeds the limit
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+
+	if diff := len(ra) - len(rb); diff > limit || -diff > limit {
+		return limit + 1
+	}
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+
+			rowMin = min(rowMin, curr[j])
+		}
+
+		if rowMin > limit {
+			return limit + 1
+		}
+
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(rb)]
+}
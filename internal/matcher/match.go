@@ -15,10 +15,10 @@
 package matcher
 
 import (
-	"maps"
 	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"unicode"
 	"unicode/utf8"
 )
@@ -30,24 +30,55 @@ type Matchable interface {
 type Pattern[T Matchable] struct {
 	Ref      string
 	Entities []*T
+
+	norm    string // normalized reference used by fuzzy matching
+	normLen int
+	mask    uint32
+	words   int
 }
 
 type Matcher[T Matchable] struct {
 	EntityPatterns map[string]Pattern[T]
+
+	opts options
+
+	mu        sync.Mutex
+	automaton *automaton
+	// lowered maps case-folded search keys to the references they come from
+	lowered map[string][]string
+	// fuzzyRefs groups references by their word count and normalized length,
+	// so fuzzy matching only checks references of about the same length as the text
+	fuzzyRefs map[fuzzyKey][]fuzzyRef
+	// fuzzyWords is the most words a reference has had, it bounds the text windows checked by fuzzy matching
+	fuzzyWords int
 }
 
 type Match[T any] struct {
 	Entities   []*T
 	MatchedRef string
+	// Confidence is 1 for exact matches and lower for fuzzy ones
+	Confidence float64
 }
 
-func NewMatcher[T Matchable]() *Matcher[T] {
-	return &Matcher[T]{
+func NewMatcher[T Matchable](opts ...Option) *Matcher[T] {
+	m := &Matcher[T]{
 		EntityPatterns: make(map[string]Pattern[T]),
+		automaton:      newAutomaton(),
+		lowered:        make(map[string][]string),
+		fuzzyRefs:      make(map[fuzzyKey][]fuzzyRef),
+	}
+
+	for _, opt := range opts {
+		opt(&m.opts)
 	}
+
+	return m
 }
 
 func (m *Matcher[T]) Add(entity *T) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	for _, ref := range (*entity).Refs() {
 		if pattern, exists := m.EntityPatterns[ref]; exists {
 			pattern.Entities = append(pattern.Entities, entity)
@@ -57,81 +88,142 @@ func (m *Matcher[T]) Add(entity *T) {
 			continue
 		}
 
-		m.EntityPatterns[ref] = Pattern[T]{Ref: ref, Entities: []*T{entity}}
+		normRef, words := normalizeRef(ref)
+
+		pattern := Pattern[T]{
+			Ref:      ref,
+			Entities: []*T{entity},
+			norm:     normRef,
+			normLen:  utf8.RuneCountInString(normRef),
+			mask:     runeMask(normRef),
+			words:    words,
+		}
+
+		m.EntityPatterns[ref] = pattern
+
+		if words > 0 {
+			fk := pattern.fuzzyKey()
+			m.fuzzyRefs[fk] = append(m.fuzzyRefs[fk], fuzzyRef{ref: ref, norm: normRef, mask: pattern.mask})
+			m.fuzzyWords = max(m.fuzzyWords, words)
+		}
+
+		key := strings.ToLower(ref)
+
+		if len(m.lowered[key]) == 0 {
+			m.automaton.insert(key)
+		}
+
+		m.lowered[key] = append(m.lowered[key], ref)
 	}
 }
 
-func (m *Matcher[T]) Match(input string) []Match[T] { //nolint:cyclop
-	searchKeys := slices.Collect(maps.Keys(m.EntityPatterns))
+// Remove forgets the entity. It must be called before the entity references are changed.
+func (m *Matcher[T]) Remove(entity *T) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	sort.SliceStable(searchKeys, func(i, j int) bool {
-		return len(searchKeys[i]) > len(searchKeys[j])
-	})
+	for _, ref := range (*entity).Refs() {
+		pattern, exists := m.EntityPatterns[ref]
+		if !exists {
+			continue
+		}
 
-	var found []Match[T]
+		pattern.Entities = slices.DeleteFunc(slices.Clone(pattern.Entities), func(e *T) bool {
+			return e == entity
+		})
 
-	var matchedRanges [][2]int
+		if len(pattern.Entities) > 0 {
+			m.EntityPatterns[ref] = pattern
+			continue
+		}
 
-	lowerInput := strings.ToLower(input)
+		delete(m.EntityPatterns, ref)
 
-	for _, searchKey := range searchKeys {
-		idx := 0
-		pattern := m.EntityPatterns[searchKey]
-		lowerKey := strings.ToLower(pattern.Ref)
+		fk := pattern.fuzzyKey()
+		m.fuzzyRefs[fk] = slices.DeleteFunc(m.fuzzyRefs[fk], func(r fuzzyRef) bool { return r.ref == ref })
 
-		for {
-			start := strings.Index(lowerInput[idx:], lowerKey)
+		key := strings.ToLower(ref)
+		refs := slices.DeleteFunc(m.lowered[key], func(r string) bool { return r == ref })
 
-			if start == -1 {
-				break
-			}
+		if len(refs) > 0 {
+			m.lowered[key] = refs
+			continue
+		}
 
-			start += idx
-			end := start + len(lowerKey)
+		delete(m.lowered, key)
+		m.automaton.remove(key)
+	}
+}
 
-			// Check forbidden leading characters
-			if start > 0 {
-				r, _ := utf8.DecodeLastRuneInString(input[:start])
-				if r == '\\' || r == '_' || r == '*' || unicode.IsLetter(r) {
-					idx = start + 1
-					continue
-				}
-			}
+func (m *Matcher[T]) Match(input string) []Match[T] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-			// Check forbidden trailing characters
-			if end < len(input) {
-				r, _ := utf8.DecodeRuneInString(input[end:])
+	lowerInput := strings.ToLower(input)
+	occurrences := m.automaton.search(lowerInput)
 
-				if r == '_' || r == '*' || unicode.IsLetter(r) {
-					idx = start + 1
-					continue
-				}
-			}
+	// longer references win overlapping ranges, the earlier ones win among the equally long
+	sort.Slice(occurrences, func(i, j int) bool {
+		oi, oj := occurrences[i], occurrences[j]
 
-			overlaps := false
+		if len(oi.key) != len(oj.key) {
+			return len(oi.key) > len(oj.key)
+		}
 
-			for _, r := range matchedRanges {
-				if start < r[1] && end > r[0] { // Overlaps if ranges intersect
-					overlaps = true
-					break
-				}
-			}
+		if oi.key != oj.key {
+			return oi.key < oj.key
+		}
+
+		return oi.start < oj.start
+	})
+
+	var found []Match[T]
+
+	var matchedRanges [][2]int
+
+	for _, o := range occurrences {
+		if !validBoundaries(input, o.start, o.end) || overlapsAny(matchedRanges, o.start, o.end) {
+			continue
+		}
 
-			if overlaps {
-				idx = start + 1
-				continue
-			}
+		matchedRanges = append(matchedRanges, [2]int{o.start, o.end})
 
-			matchedRanges = append(matchedRanges, [2]int{start, end})
+		for _, ref := range m.lowered[o.key] {
+			pattern := m.EntityPatterns[ref]
 
 			found = append(found, Match[T]{
 				Entities:   pattern.Entities,
 				MatchedRef: pattern.Ref,
+				Confidence: ExactConfidence,
 			})
-
-			idx = end
 		}
 	}
 
+	if m.opts.fuzzy {
+		found = append(found, m.matchFuzzy(input, matchedRanges)...)
+	}
+
 	return found
 }
+
+// validBoundaries checks that the match is not a part of a word or an escaped/emphasized text
+func validBoundaries(input string, start, end int) bool {
+	// Check forbidden leading characters
+	if start > 0 {
+		r, _ := utf8.DecodeLastRuneInString(input[:start])
+		if r == '\\' || r == '_' || r == '*' || unicode.IsLetter(r) {
+			return false
+		}
+	}
+
+	// Check forbidden trailing characters
+	if end < len(input) {
+		r, _ := utf8.DecodeRuneInString(input[end:])
+
+		if r == '_' || r == '*' || unicode.IsLetter(r) {
+			return false
+		}
+	}
+
+	return true
+}
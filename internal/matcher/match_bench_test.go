@@ -0,0 +1,160 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcher
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	benchFirstNames = []string{
+		"michael", "dwight", "jim", "pam", "ryan", "andy", "angela", "kevin", "oscar", "stanley",
+		"phyllis", "meredith", "creed", "kelly", "toby", "darryl", "erin", "gabe", "holly", "jan",
+	}
+	benchLastNames = []string{
+		"scott", "schrute", "halpert", "beesly", "howard", "bernard", "martin", "malone", "martinez",
+		"hudson", "vance", "palmer", "bratton", "kapoor", "flenderson", "philbin", "hannon", "lewis",
+	}
+)
+
+// newBenchMatcher registers friends with realistic refs: a full name, a first name, a last name and an ID
+func newBenchMatcher(friends int, opts ...Option) *Matcher[testEntity] {
+	m := NewMatcher[testEntity](opts...)
+
+	for i := range friends {
+		first := benchFirstNames[i%len(benchFirstNames)]
+		last := fmt.Sprintf("%s%c%c", benchLastNames[i%len(benchLastNames)], 'a'+i/26%26, 'a'+i%26)
+		id := fmt.Sprintf("%s-%s-%d", first, last, i)
+
+		m.Add(&testEntity{ID: i, References: []string{first + " " + last, first, last, id}})
+	}
+
+	return m
+}
+
+const benchInput = "Had a long lunch with michael scottbc and dwight at the farm, " +
+	"then jim halpertzz dropped by with pam beeslyaa and kevin-maloneqq-1234 #work @scranton"
+
+// naiveMatch is the former matching algorithm that scans the input for every reference one by one
+func naiveMatch[T Matchable](m *Matcher[T], input string) []Match[T] {
+	searchKeys := slices.Collect(maps.Keys(m.EntityPatterns))
+
+	sort.SliceStable(searchKeys, func(i, j int) bool {
+		if len(searchKeys[i]) != len(searchKeys[j]) {
+			return len(searchKeys[i]) > len(searchKeys[j])
+		}
+
+		return searchKeys[i] < searchKeys[j]
+	})
+
+	var (
+		found         []Match[T]
+		matchedRanges [][2]int
+	)
+
+	lowerInput := strings.ToLower(input)
+
+	for _, searchKey := range searchKeys {
+		idx := 0
+		pattern := m.EntityPatterns[searchKey]
+		lowerKey := strings.ToLower(pattern.Ref)
+
+		for {
+			start := strings.Index(lowerInput[idx:], lowerKey)
+			if start == -1 {
+				break
+			}
+
+			start += idx
+			end := start + len(lowerKey)
+
+			if !validBoundaries(input, start, end) || overlapsAny(matchedRanges, start, end) {
+				idx = start + 1
+				continue
+			}
+
+			matchedRanges = append(matchedRanges, [2]int{start, end})
+			found = append(found, Match[T]{
+				Entities:   pattern.Entities,
+				MatchedRef: pattern.Ref,
+				Confidence: ExactConfidence,
+			})
+
+			idx = end
+		}
+	}
+
+	return found
+}
+
+func TestMatcher_SameAsNaive(t *testing.T) {
+	t.Parallel()
+
+	m := newBenchMatcher(2_000)
+
+	inputs := []string{
+		benchInput,
+		"Michael and Michael Scottab met Jim",
+		"jimjim jim_ *pam* \\andy angela-kevin",
+		"",
+	}
+
+	for _, input := range inputs {
+		require.Equal(t, naiveMatch(m, input), m.Match(input), input)
+	}
+}
+
+func BenchmarkMatcher_Match(b *testing.B) {
+	m := newBenchMatcher(10_000)
+
+	b.ResetTimer()
+
+	for b.Loop() {
+		m.Match(benchInput)
+	}
+}
+
+func BenchmarkMatcher_MatchNaive(b *testing.B) {
+	m := newBenchMatcher(10_000)
+
+	b.ResetTimer()
+
+	for b.Loop() {
+		naiveMatch(m, benchInput)
+	}
+}
+
+func BenchmarkMatcher_MatchFuzzy(b *testing.B) {
+	m := newBenchMatcher(10_000, WithFuzzy(2))
+
+	b.ResetTimer()
+
+	for b.Loop() {
+		m.Match(benchInput)
+	}
+}
+
+func BenchmarkMatcher_Add(b *testing.B) {
+	for b.Loop() {
+		newBenchMatcher(10_000)
+	}
+}
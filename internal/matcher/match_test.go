@@ -86,3 +86,125 @@ func TestMatcher_LongestPatternShouldWin(t *testing.T) {
 	require.Len(t, matches, 1)
 	require.Equal(t, ref, matches[0].MatchedRef)
 }
+
+func TestMatcher_Fuzzy(t *testing.T) {
+	t.Parallel()
+
+	matcher := NewMatcher[testEntity](WithFuzzy(2))
+
+	matcher.Add(&testEntity{ID: 1, References: []string{"michael scott", "michael"}})
+	matcher.Add(&testEntity{ID: 2, References: []string{"dwight schrute", "dwight"}})
+	matcher.Add(&testEntity{ID: 3, References: []string{"müller"}})
+	matcher.Add(&testEntity{ID: 4, References: []string{"микола", "jim"}})
+
+	tests := []struct {
+		input   string
+		wantIDs []int
+		exact   bool
+	}{
+		{"Michael and Dwight went fishing", []int{1, 2}, true},
+		{"Micheal called me", []int{1}, false},
+		{"Dwigth Schrute sold beets", []int{2}, false},
+		{"Had a beer with Muller", []int{3}, false},
+		{"Mykola is back from Kyiv", []int{4}, false},
+		{"Tim and Kim are not Jim", []int{4}, true},
+		{"Nobody here", nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+
+			matches := matcher.Match(test.input)
+
+			ids := make([]int, 0, len(matches))
+
+			for _, m := range matches {
+				require.Len(t, m.Entities, 1)
+
+				ids = append(ids, m.Entities[0].ID)
+
+				if test.exact {
+					require.InDelta(t, ExactConfidence, m.Confidence, 0.001)
+				} else {
+					require.Less(t, m.Confidence, ExactConfidence)
+					require.Positive(t, m.Confidence)
+				}
+			}
+
+			require.ElementsMatch(t, test.wantIDs, ids)
+		})
+	}
+}
+
+func TestMatcher_FuzzyDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	matcher := NewMatcher[testEntity]()
+	matcher.Add(&testEntity{ID: 1, References: []string{"michael"}})
+
+	require.Empty(t, matcher.Match("Micheal called me"))
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "muller", Normalize("Müller"))
+	require.Equal(t, "strasse", Normalize("Straße"))
+	require.Equal(t, "mykola", Normalize("Микола"))
+	require.Equal(t, "olha", Normalize("Ольга"))
+	require.Equal(t, "zofia", Normalize("Zófia"))
+}
+
+func TestMatcher_Remove(t *testing.T) {
+	t.Parallel()
+
+	matcher := NewMatcher[testEntity]()
+
+	jim := &testEntity{ID: 1, References: []string{"Jim Halpert", "Jim"}}
+	james := &testEntity{ID: 2, References: []string{"James", "Jim"}}
+
+	matcher.Add(jim)
+	matcher.Add(james)
+
+	matches := matcher.Match("Jim Halpert and Jim")
+	require.Len(t, matches, 2)
+	require.Equal(t, "Jim Halpert", matches[0].MatchedRef)
+	require.Len(t, matches[1].Entities, 2)
+
+	matcher.Remove(jim)
+
+	matches = matcher.Match("Jim Halpert and Jim")
+	require.Len(t, matches, 2)
+	require.Equal(t, "Jim", matches[0].MatchedRef)
+	require.Equal(t, []*testEntity{james}, matches[0].Entities)
+
+	matcher.Remove(james)
+
+	require.Empty(t, matcher.Match("Jim Halpert and James"))
+	require.Empty(t, matcher.EntityPatterns)
+
+	matcher.Add(jim)
+
+	matches = matcher.Match("Jim Halpert")
+	require.Len(t, matches, 1)
+	require.Equal(t, []*testEntity{jim}, matches[0].Entities)
+}
+
+func TestMatcher_FuzzyRemove(t *testing.T) {
+	t.Parallel()
+
+	matcher := NewMatcher[testEntity](WithFuzzy(2))
+
+	dwight := &testEntity{ID: 1, References: []string{"Dwight Schrute"}}
+
+	matcher.Add(dwight)
+
+	matches := matcher.Match("Met Dwigth Schrute today")
+	require.Len(t, matches, 1)
+	require.Equal(t, "Dwight Schrute", matches[0].MatchedRef)
+
+	matcher.Remove(dwight)
+
+	require.Empty(t, matcher.Match("Met Dwigth Schrute today"))
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcher
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// latinFolds covers Latin letters that don't decompose into a base letter and a diacritic
+var latinFolds = map[rune]string{
+	'ß': "ss",
+	'æ': "ae",
+	'œ': "oe",
+	'ø': "o",
+	'ł': "l",
+	'đ': "d",
+	'ð': "d",
+	'þ': "th",
+	'ı': "i",
+}
+
+// cyrillicTranslit follows the Ukrainian national transliteration with additions for Russian letters
+var cyrillicTranslit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "h", 'ґ': "g", 'д': "d", 'е': "e", 'є': "ye",
+	'ж': "zh", 'з': "z", 'и': "y", 'і': "i", 'ї': "yi", 'й': "y", 'к': "k", 'л': "l",
+	'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ь': "", 'ю': "yu",
+	'я': "ya", 'ё': "e", 'ъ': "", 'ы': "y", 'э': "e",
+}
+
+// Normalize brings text to a comparable form: lower-cased Latin letters without accents.
+// Cyrillic text is transliterated, so "Микола", "Mykola" and "Mýkola" are normalized the same way.
+func Normalize(s string) string {
+	var sb strings.Builder
+
+	sb.Grow(len(s))
+
+	for _, r := range strings.ToLower(s) {
+		if t, ok := cyrillicTranslit[r]; ok {
+			sb.WriteString(t)
+			continue
+		}
+
+		if t, ok := latinFolds[r]; ok {
+			sb.WriteString(t)
+			continue
+		}
+
+		for _, d := range norm.NFKD.String(string(r)) {
+			// drop combining marks left after decomposition (e.g. accents)
+			if !unicode.Is(unicode.Mn, d) {
+				sb.WriteRune(d)
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+type word struct {
+	start, end int
+	norm       string
+	normLen    int
+	mask       uint32
+}
+
+// splitWords breaks text into normalized words remembering their byte positions in the original text
+func splitWords(s string) []word {
+	var words []word
+
+	start := -1
+
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+
+		if n := Normalize(s[start:end]); n != "" {
+			words = append(words, word{
+				start:   start,
+				end:     end,
+				norm:    n,
+				normLen: utf8.RuneCountInString(n),
+				mask:    runeMask(n),
+			})
+		}
+
+		start = -1
+	}
+
+	for i, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) {
+			if start < 0 {
+				start = i
+			}
+
+			continue
+		}
+
+		flush(i)
+	}
+
+	flush(len(s))
+
+	return words
+}
+
+// normalizeRef turns a reference into normalized words joined by single spaces
+func normalizeRef(ref string) (string, int) {
+	words := splitWords(ref)
+	norms := make([]string, 0, len(words))
+
+	for _, w := range words {
+		norms = append(norms, w.norm)
+	}
+
+	return strings.Join(norms, " "), len(words)
+}
+
+// runeMask sets a bit per distinct rune (modulo 32) ignoring spaces.
+// An edit changes at most two bits, which gives a cheap lower bound for the edit distance.
+func runeMask(s string) uint32 {
+	var mask uint32
+
+	for _, r := range s {
+		if r != ' ' {
+			mask |= 1 << (uint32(r) % 32)
+		}
+	}
+
+	return mask
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reminder
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/calendar"
+	"github.com/roma-glushko/frens/internal/friend"
+)
+
+var (
+	ErrNoAnchor            = errors.New("reminder has no date to anchor to")
+	ErrExpired             = errors.New("reminder will not fire anymore")
+	ErrUnsupportedCalendar = errors.New("calendar is not supported by the scheduler")
+)
+
+const day = 24 * time.Hour
+
+// Next works out when the reminder fires next. Reminders firing on the same day as `from` are included.
+func Next(r friend.Reminder, from time.Time) (time.Time, error) { //nolint:cyclop
+	if r.Anchor == nil || r.Anchor.DateExpr == "" {
+		return time.Time{}, ErrNoAnchor
+	}
+
+	expr, err := calendar.Parse(*r.Anchor)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	today := truncateDay(from)
+
+	// journals edited by hand may spell recurrences in any case
+	switch strings.ToLower(r.Recurrence) {
+	case friend.RecurrenceYearly:
+		// look for the first occurrence that is still ahead once the offset is applied
+		occ := expr.Next(unshift(r, today))
+
+		return shift(r, occ.Date), nil
+	case friend.RecurrenceMonthly:
+		if expr.Calendar != friend.CalendarGregorian {
+			return time.Time{}, fmt.Errorf("%w: monthly reminders on %s dates", ErrUnsupportedCalendar, expr.Calendar)
+		}
+
+		for m := -2; m <= 14; m++ {
+			month := time.Date(today.Year(), today.Month()+time.Month(m), 1, 0, 0, 0, 0, today.Location())
+			occ := dateIn(month.Year(), month.Month(), expr.Day, today.Location())
+
+			if expr.HasYear() && occ.Before(expr.Date(today.Location())) {
+				continue
+			}
+
+			if fireAt := shift(r, occ); !fireAt.Before(today) {
+				return fireAt, nil
+			}
+		}
+	default:
+		occ := expr.Date(today.Location())
+
+		if !expr.HasYear() {
+			// reminders without a year fire once on the first occurrence after they were created
+			origin := today
+
+			if !r.CreatedAt.IsZero() {
+				origin = truncateDay(r.CreatedAt.In(today.Location()))
+			}
+
+			occ = expr.Next(origin).Date
+		}
+
+		if fireAt := shift(r, occ); !fireAt.Before(today) {
+			return fireAt, nil
+		}
+	}
+
+	return time.Time{}, ErrExpired
+}
+
+// Schedule resolves the next fire time of each reminder and returns them sorted by it.
+// Reminders that will not fire anymore are left out.
+func Schedule(reminders []friend.Reminder, from time.Time) ([]friend.Reminder, error) {
+	var errs []error
+
+	scheduled := make([]friend.Reminder, 0, len(reminders))
+
+	for _, r := range reminders {
+		nextAt, err := Next(r, from)
+		if errors.Is(err, ErrExpired) {
+			continue
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to schedule reminder %s: %w", r.ID, err))
+			continue
+		}
+
+		r.NextAt = nextAt
+
+		scheduled = append(scheduled, r)
+	}
+
+	sort.SliceStable(scheduled, func(i, j int) bool {
+		return scheduled[i].NextAt.Before(scheduled[j].NextAt)
+	})
+
+	return scheduled, errors.Join(errs...)
+}
+
+// Due returns reminders that fire on the given day.
+func Due(reminders []friend.Reminder, on time.Time) ([]friend.Reminder, error) {
+	scheduled, err := Schedule(reminders, on)
+
+	today := truncateDay(on)
+	due := make([]friend.Reminder, 0, len(scheduled))
+
+	for _, r := range scheduled {
+		if r.NextAt.Equal(today) {
+			due = append(due, r)
+		}
+	}
+
+	return due, err
+}
+
+// shift applies the reminder offset to the given date occurrence.
+func shift(r friend.Reminder, occ time.Time) time.Time {
+	if r.Offset == nil || *r.Offset == 0 {
+		return occ
+	}
+
+	offset := *r.Offset
+
+	if r.OffsetDirection != friend.OffsetDirectionAfter {
+		offset = -offset
+	}
+
+	if offset%day == 0 {
+		return occ.AddDate(0, 0, int(offset/day))
+	}
+
+	return truncateDay(occ.Add(offset))
+}
+
+// unshift reverts the reminder offset, so it finds the date occurrence the reminder fires for on the given day.
+func unshift(r friend.Reminder, fireAt time.Time) time.Time {
+	if r.Offset == nil {
+		return fireAt
+	}
+
+	reverted := r
+	reverted.OffsetDirection = friend.OffsetDirectionBefore
+
+	if r.OffsetDirection != friend.OffsetDirectionAfter {
+		reverted.OffsetDirection = friend.OffsetDirectionAfter
+	}
+
+	return shift(reverted, fireAt)
+}
+
+// dateIn builds a date clamping the day to the length of the month (e.g. Feb 29 on non-leap years)
+func dateIn(year int, month time.Month, d int, loc *time.Location) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+
+	return time.Date(year, month, min(d, lastDay), 0, 0, 0, 0, loc)
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
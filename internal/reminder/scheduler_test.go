@@ -0,0 +1,195 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reminder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/stretchr/testify/require"
+)
+
+func offset(d time.Duration) *time.Duration {
+	return &d
+}
+
+func TestNext(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.October, 16, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		reminder friend.Reminder
+		want     time.Time
+	}{
+		{
+			name: "yearly upcoming",
+			reminder: friend.Reminder{
+				Recurrence: friend.RecurrenceYearly,
+				Anchor:     &friend.Date{DateExpr: "1990-11-02"},
+			},
+			want: time.Date(2025, time.November, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "yearly spelled in another case",
+			reminder: friend.Reminder{
+				Recurrence: "Yearly",
+				Anchor:     &friend.Date{DateExpr: "May 13"},
+			},
+			want: time.Date(2026, time.May, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "yearly passed this year",
+			reminder: friend.Reminder{
+				Recurrence: friend.RecurrenceYearly,
+				Anchor:     &friend.Date{DateExpr: "May 13"},
+			},
+			want: time.Date(2026, time.May, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "yearly fires today",
+			reminder: friend.Reminder{
+				Recurrence: friend.RecurrenceYearly,
+				Anchor:     &friend.Date{DateExpr: "1985-10-16"},
+			},
+			want: time.Date(2025, time.October, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "yearly with offset before",
+			reminder: friend.Reminder{
+				Recurrence:      friend.RecurrenceYearly,
+				OffsetDirection: friend.OffsetDirectionBefore,
+				Offset:          offset(3 * 24 * time.Hour),
+				Anchor:          &friend.Date{DateExpr: "1990-10-20"},
+			},
+			want: time.Date(2025, time.October, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "yearly offset before crosses the year",
+			reminder: friend.Reminder{
+				Recurrence:      friend.RecurrenceYearly,
+				OffsetDirection: friend.OffsetDirectionBefore,
+				Offset:          offset(7 * 24 * time.Hour),
+				Anchor:          &friend.Date{DateExpr: "2001-01-03"},
+			},
+			want: time.Date(2025, time.December, 27, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "leap day on a regular year",
+			reminder: friend.Reminder{
+				Recurrence: friend.RecurrenceYearly,
+				Anchor:     &friend.Date{DateExpr: "2004-02-29"},
+			},
+			want: time.Date(2026, time.February, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "yearly on a hebrew date",
+			reminder: friend.Reminder{
+				Recurrence: friend.RecurrenceYearly,
+				Anchor:     &friend.Date{Calendar: friend.CalendarHebrew, DateExpr: "15 Nisan"},
+			},
+			want: time.Date(2026, time.April, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "monthly clamps to the month length",
+			reminder: friend.Reminder{
+				Recurrence: friend.RecurrenceMonthly,
+				Anchor:     &friend.Date{DateExpr: "2025-01-31"},
+			},
+			want: time.Date(2025, time.October, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "once with offset after",
+			reminder: friend.Reminder{
+				Recurrence:      friend.RecurrenceOnce,
+				OffsetDirection: friend.OffsetDirectionAfter,
+				Offset:          offset(24 * time.Hour),
+				Anchor:          &friend.Date{DateExpr: "2025-10-20"},
+			},
+			want: time.Date(2025, time.October, 21, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Next(tt.reminder, now)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNext_Expired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.October, 16, 0, 0, 0, 0, time.UTC)
+
+	_, err := Next(friend.Reminder{
+		Recurrence: friend.RecurrenceOnce,
+		Anchor:     &friend.Date{DateExpr: "2025-10-01"},
+	}, now)
+
+	require.ErrorIs(t, err, ErrExpired)
+
+	_, err = Next(friend.Reminder{Recurrence: friend.RecurrenceOnce}, now)
+
+	require.ErrorIs(t, err, ErrNoAnchor)
+}
+
+func TestDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.October, 16, 9, 0, 0, 0, time.UTC)
+
+	reminders := []friend.Reminder{
+		{
+			ID:         "bday",
+			Recurrence: friend.RecurrenceYearly,
+			Anchor:     &friend.Date{DateExpr: "1990-10-16"},
+		},
+		{
+			ID:              "gift",
+			Recurrence:      friend.RecurrenceYearly,
+			OffsetDirection: friend.OffsetDirectionBefore,
+			Offset:          offset(2 * 24 * time.Hour),
+			Anchor:          &friend.Date{DateExpr: "1990-10-18"},
+		},
+		{
+			ID:         "later",
+			Recurrence: friend.RecurrenceYearly,
+			Anchor:     &friend.Date{DateExpr: "1990-12-01"},
+		},
+		{
+			ID:         "expired",
+			Recurrence: friend.RecurrenceOnce,
+			Anchor:     &friend.Date{DateExpr: "2020-10-16"},
+		},
+	}
+
+	due, err := Due(reminders, now)
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(due))
+
+	for _, r := range due {
+		ids = append(ids, r.ID)
+	}
+
+	require.ElementsMatch(t, []string{"bday", "gift"}, ids)
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"fmt"
+
+	"github.com/roma-glushko/frens/internal/config"
+	"github.com/roma-glushko/frens/internal/store"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/roma-glushko/frens/internal/store/sqlite"
+)
+
+// New creates the journal store described by the journal settings
+func New(dir string, jCfg config.Config) (store.Store, error) {
+	cfg := jCfg.Store
+
+	switch cfg.Type {
+	case "", config.StoreTypeTOML:
+		var opts []file.Option
+
+		lockTimeout, err := cfg.LockTimeoutDuration()
+		if err != nil {
+			return nil, err
+		}
+
+		if lockTimeout > 0 {
+			opts = append(opts, file.WithLockTimeout(lockTimeout))
+		}
+
+		if cfg.UndoDepth != nil {
+			opts = append(opts, file.WithUndoDepth(*cfg.UndoDepth))
+		}
+
+		if jCfg.Match.Fuzzy {
+			opts = append(opts, file.WithFuzzyLookup())
+		}
+
+		return file.NewTOMLFileStore(dir, opts...), nil
+	case config.StoreTypeSQLite:
+		var opts []sqlite.Option
+
+		if jCfg.Match.Fuzzy {
+			opts = append(opts, sqlite.WithFuzzyLookup())
+		}
+
+		return sqlite.NewSQLiteStore(dir, opts...), nil
+	default:
+		return nil, fmt.Errorf("unsupported store type '%s'", cfg.Type)
+	}
+}
+
+// FromConfig creates the journal store configured for the journal directory
+func FromConfig(dir string) (store.Store, error) {
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(dir, cfg)
+}
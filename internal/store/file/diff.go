@@ -0,0 +1,161 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/BurntSushi/toml"
+)
+
+var ErrUnknownEntity = errors.New("unknown entity kind")
+
+// FieldChange is a change of the entity field between two versions of the journal file.
+// Changes of entity lists (e.g. contacts) are reported per entity: Old is empty for added ones, New for removed ones.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+func (c FieldChange) String() string {
+	return c.Field + ": " + c.Diff()
+}
+
+// Diff renders the change of the value e.g. "a prankster → Pam's husband"
+func (c FieldChange) Diff() string {
+	switch {
+	case c.Old == "":
+		return "+ " + c.New
+	case c.New == "":
+		return "- " + c.Old
+	default:
+		return c.Old + " → " + c.New
+	}
+}
+
+// EntityFile tells which journal file keeps entities of the kind (e.g. "friend" or "activity")
+func EntityFile(kind string) (string, error) {
+	switch kind {
+	case "friend", "location", "reminder":
+		return FileNameFriends, nil
+	case "activity", "note":
+		return FileNameActivities, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownEntity, kind)
+	}
+}
+
+// DecodeEntity finds the entity of the kind in the content of its journal file.
+// It's nil if the file has no such entity.
+func DecodeEntity(kind, id string, content []byte) (any, error) {
+	var (
+		friends FriendsFile
+		events  EventsFile
+		list    any
+	)
+
+	switch kind {
+	case "friend", "location", "reminder":
+		if _, err := toml.Decode(string(content), &friends); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", FileNameFriends, err)
+		}
+
+		list = map[string]any{"friend": friends.Friends, "location": friends.Locations, "reminder": friends.Reminders}[kind]
+	case "activity", "note":
+		if _, err := toml.Decode(string(content), &events); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", FileNameActivities, err)
+		}
+
+		list = map[string]any{"activity": events.Activities, "note": events.Notes}[kind]
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEntity, kind)
+	}
+
+	if e, ok := indexByID(reflect.ValueOf(list))[id]; ok {
+		return e.Interface(), nil
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
+// DiffEntities compares two versions of the entity field by field. Entities added since the before version are nil.
+// Cached fields (e.g. activity counters) follow changes of other entities, so they are left out.
+func DiffEntities(before, after any) []FieldChange {
+	a := reflect.ValueOf(after)
+	b := reflect.ValueOf(before)
+
+	if before == nil {
+		b = reflect.New(a.Type().Elem())
+	}
+
+	var changes []FieldChange
+
+	st := a.Type().Elem()
+
+	for i := range st.NumField() {
+		f := st.Field(i)
+		name := tomlName(f)
+
+		if !f.IsExported() || name == "-" || cachedCounters[name] || cachedLatest[name] {
+			continue
+		}
+
+		bf, af := b.Elem().Field(i), a.Elem().Field(i)
+
+		if equal(bf, af) {
+			continue
+		}
+
+		if isEntityList(af.Type()) {
+			changes = append(changes, diffEntityList(name, bf, af)...)
+			continue
+		}
+
+		changes = append(changes, FieldChange{Field: name, Old: render(bf), New: render(af)})
+	}
+
+	return changes
+}
+
+func diffEntityList(name string, before, after reflect.Value) []FieldChange {
+	inBefore, inAfter := indexByID(before), indexByID(after)
+
+	var changes []FieldChange
+
+	for i := range after.Len() {
+		a := after.Index(i)
+		b, ok := inBefore[entityID(a)]
+
+		switch {
+		case !ok:
+			changes = append(changes, FieldChange{Field: name, New: render(a)})
+		case !equal(a, b):
+			changes = append(changes, FieldChange{Field: name, Old: render(b), New: render(a)})
+		}
+	}
+
+	for i := range before.Len() {
+		b := before.Index(i)
+
+		if _, ok := inAfter[entityID(b)]; !ok {
+			changes = append(changes, FieldChange{Field: name, Old: render(b)})
+		}
+	}
+
+	return changes
+}
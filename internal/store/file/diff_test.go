@@ -0,0 +1,98 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffEntities(t *testing.T) {
+	t.Parallel()
+
+	before := `
+[[friends]]
+id = "jim"
+name = "Jim Halpert"
+desc = "a prankster"
+tags = ["office"]
+activities = 3
+
+[[friends.contacts]]
+id = "c1"
+type = "email"
+value = "jim@dm.com"
+
+[[friends.dates]]
+id = "d1"
+calendar = "gregorian"
+date_expr = "10-01"
+desc = "birthday"
+`
+
+	after := `
+[[friends]]
+id = "jim"
+name = "Jim Halpert"
+desc = "Pam's husband"
+tags = ["office", "family"]
+activities = 4
+
+[[friends.contacts]]
+id = "c2"
+type = "telegram"
+value = "@bigtuna"
+
+[[friends.dates]]
+id = "d1"
+calendar = "gregorian"
+date_expr = "10-01"
+desc = "Jim's birthday"
+`
+
+	old, err := DecodeEntity("friend", "jim", []byte(before))
+	require.NoError(t, err)
+
+	jim, err := DecodeEntity("friend", "jim", []byte(after))
+	require.NoError(t, err)
+	require.Equal(t, "Pam's husband", jim.(*friend.Person).Desc)
+
+	missing, err := DecodeEntity("friend", "pam", []byte(after))
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	_, err = DecodeEntity("friend", "jim", []byte("<<<<<<< ours"))
+	require.Error(t, err)
+
+	_, err = DecodeEntity("meeting", "jim", []byte(after))
+	require.ErrorIs(t, err, ErrUnknownEntity)
+
+	// the activity counter follows activities, so it's not a change of Jim
+	require.Equal(t, []FieldChange{
+		{Field: "desc", Old: "a prankster", New: "Pam's husband"},
+		{Field: "tags", Old: "office", New: "office, family"},
+		{Field: "contacts", New: "telegram: @bigtuna"},
+		{Field: "contacts", Old: "email: jim@dm.com"},
+		{Field: "dates", Old: "calendar: gregorian; date_expr: 10-01; desc: birthday",
+			New: "calendar: gregorian; date_expr: 10-01; desc: Jim's birthday"},
+	}, DiffEntities(old, jim))
+
+	added := DiffEntities(nil, old)
+	require.Len(t, added, 6)
+	require.Equal(t, "id: + jim", added[0].String())
+	require.Equal(t, "desc: a prankster → Pam's husband", DiffEntities(old, jim)[0].String())
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	FileNameLock = "frens.lock"
+
+	DefaultLockTimeout = 10 * time.Second
+
+	lockPollRate = 50 * time.Millisecond
+)
+
+var (
+	ErrLocked = errors.New("journal is locked by another process")
+
+	// errLockHeld is returned by lockFile when another open lock file holds the OS lock
+	errLockHeld = errors.New("lock file is held")
+)
+
+// LockHolder describes the process that holds the journal lock
+type LockHolder struct {
+	PID        int       `json:"pid"`
+	Host       string    `json:"host"`
+	Command    string    `json:"command"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+func (h LockHolder) String() string {
+	return fmt.Sprintf(
+		"'%s' (pid %d on %s) since %s",
+		h.Command,
+		h.PID,
+		h.Host,
+		h.AcquiredAt.Format(time.RFC3339),
+	)
+}
+
+// LockError is returned when the journal lock could not be acquired in time
+type LockError struct {
+	Path   string
+	Holder *LockHolder
+}
+
+func (e *LockError) Error() string {
+	if e.Holder == nil {
+		return fmt.Sprintf("%s (lock file: %s)", ErrLocked, e.Path)
+	}
+
+	return fmt.Sprintf("%s: held by %s (lock file: %s)", ErrLocked, e.Holder, e.Path)
+}
+
+func (e *LockError) Unwrap() error {
+	return ErrLocked
+}
+
+// fileLock is an OS lock on the lock file in the journal directory.
+// The OS drops it together with the process, so locks of crashed processes never need to be taken over.
+// The lock file itself is never removed, so all processes lock the same file.
+type fileLock struct {
+	path string
+	f    *os.File
+}
+
+func lockPath(dir string) string {
+	return filepath.Join(dir, FileNameLock)
+}
+
+// acquireLock waits until the journal lock is free or gives up after the timeout
+func acquireLock(ctx context.Context, dir string, timeout time.Duration) (*fileLock, error) {
+	path := lockPath(dir)
+	deadline := time.Now().Add(timeout)
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file %s: %w", path, err)
+	}
+
+	for {
+		err := lockFile(f)
+		if err == nil {
+			break
+		}
+
+		if !errors.Is(err, errLockHeld) {
+			_ = f.Close()
+
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+
+		if !time.Now().Before(deadline) {
+			_ = f.Close()
+
+			return nil, &LockError{Path: path, Holder: readLockHolder(path)}
+		}
+
+		select {
+		case <-ctx.Done():
+			_ = f.Close()
+
+			return nil, ctx.Err()
+		case <-time.After(lockPollRate):
+		}
+	}
+
+	if err := writeLockHolder(f); err != nil {
+		return nil, errors.Join(
+			fmt.Errorf("failed to write lock file %s: %w", path, err),
+			unlockFile(f),
+			f.Close(),
+		)
+	}
+
+	return &fileLock{path: path, f: f}, nil
+}
+
+func (l *fileLock) Release() error {
+	// the holder is cleared first, so it's never reported for a released lock
+	if err := errors.Join(l.f.Truncate(0), unlockFile(l.f), l.f.Close()); err != nil {
+		return fmt.Errorf("failed to release lock file %s: %w", l.path, err)
+	}
+
+	return nil
+}
+
+func writeLockHolder(f *os.File) error {
+	host, _ := os.Hostname()
+
+	data, err := json.Marshal(LockHolder{
+		PID:        os.Getpid(),
+		Host:       host,
+		Command:    strings.Join(os.Args, " "),
+		AcquiredAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+
+	_, err = f.WriteAt(append(data, '\n'), 0)
+
+	return err
+}
+
+// readLockHolder tells who holds the lock. The holder may be unknown if it's still writing the lock file.
+func readLockHolder(path string) *LockHolder {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var holder LockHolder
+
+	if err := json.Unmarshal(data, &holder); err != nil {
+		return nil
+	}
+
+	return &holder
+}
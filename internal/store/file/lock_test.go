@@ -0,0 +1,194 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/stretchr/testify/require"
+)
+
+func writeLockFile(t *testing.T, dir string, holder LockHolder) {
+	t.Helper()
+
+	data, err := json.Marshal(holder)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(lockPath(dir), data, 0o644))
+}
+
+// holdLock locks the journal the way another process would, until the test ends
+func holdLock(t *testing.T, dir string, holder LockHolder) {
+	t.Helper()
+
+	writeLockFile(t, dir, holder)
+
+	f, err := os.OpenFile(lockPath(dir), os.O_RDWR, 0o644)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = f.Close() })
+
+	require.NoError(t, lockFile(f))
+}
+
+// tryLock tells whether the journal lock is free by taking and dropping it
+func tryLock(t *testing.T, dir string) bool {
+	t.Helper()
+
+	f, err := os.OpenFile(lockPath(dir), os.O_RDWR, 0o644)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	err = lockFile(f)
+	if errors.Is(err, errLockHeld) {
+		return false
+	}
+
+	require.NoError(t, err)
+	require.NoError(t, unlockFile(f))
+
+	return true
+}
+
+func TestTOMLFileStore_TxReleasesLock(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	dir := t.TempDir()
+	s := NewTOMLFileStore(dir)
+
+	require.NoError(t, s.Init(ctx))
+
+	err := s.Tx(ctx, func(_ *journal.Journal) error {
+		require.False(t, tryLock(t, dir))
+		require.Equal(t, os.Getpid(), readLockHolder(lockPath(dir)).PID)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.True(t, tryLock(t, dir))
+	require.Nil(t, readLockHolder(lockPath(dir)))
+}
+
+func TestTOMLFileStore_TxLockedByAnotherProcess(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	dir := t.TempDir()
+	host, _ := os.Hostname()
+
+	s := NewTOMLFileStore(dir, WithLockTimeout(100*time.Millisecond))
+
+	require.NoError(t, s.Init(ctx))
+
+	holdLock(t, dir, LockHolder{
+		PID:        os.Getppid(),
+		Host:       host,
+		Command:    "frens serve",
+		AcquiredAt: time.Now(),
+	})
+
+	err := s.Tx(ctx, func(_ *journal.Journal) error {
+		t.Fatal("transaction must not run while the journal is locked")
+
+		return nil
+	})
+
+	var lockErr *LockError
+
+	require.ErrorIs(t, err, ErrLocked)
+	require.ErrorAs(t, err, &lockErr)
+	require.Equal(t, "frens serve", lockErr.Holder.Command)
+	require.Contains(t, err.Error(), "frens serve")
+}
+
+func TestTOMLFileStore_TxIgnoresLockFileOfGoneProcess(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	s := NewTOMLFileStore(dir, WithLockTimeout(100*time.Millisecond))
+
+	require.NoError(t, s.Init(ctx))
+
+	// e.g. left by a crashed process, the OS has dropped its lock
+	writeLockFile(t, dir, LockHolder{
+		PID:        1,
+		Host:       "another-host",
+		Command:    "frens telegram bot",
+		AcquiredAt: time.Now().Add(-time.Hour),
+	})
+
+	require.NoError(t, s.Tx(ctx, func(_ *journal.Journal) error { return nil }))
+}
+
+func TestAcquireLock_OneHolderAtATime(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeLockFile(t, dir, LockHolder{
+		PID:        1,
+		Host:       "another-host",
+		Command:    "frens telegram bot",
+		AcquiredAt: time.Now().Add(-time.Hour),
+	})
+
+	var (
+		wg      sync.WaitGroup
+		holders atomic.Int32
+		overlap atomic.Bool
+	)
+
+	errs := make(chan error, 8)
+
+	for range 8 {
+		wg.Go(func() {
+			lock, err := acquireLock(t.Context(), dir, 5*time.Second)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			if holders.Add(1) > 1 {
+				overlap.Store(true)
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			holders.Add(-1)
+
+			errs <- lock.Release()
+		})
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.False(t, overlap.Load(), "the lock must be held by one waiter at a time")
+}
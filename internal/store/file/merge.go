@@ -0,0 +1,420 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var ErrUnsupportedMergeFile = errors.New("file is not a part of the journal")
+
+// Conflict is a change of the entity made on both sides in different ways.
+// The merged file keeps our version of the changed field or entity.
+type Conflict struct {
+	Entity string
+	// Field is empty when one side removed the entity the other side changed
+	Field  string
+	Ours   string
+	Theirs string
+}
+
+func (c Conflict) String() string {
+	if c.Field == "" {
+		return fmt.Sprintf("%s: %s by us, %s by them", c.Entity, c.Ours, c.Theirs)
+	}
+
+	return fmt.Sprintf("%s: %s changed to %q by us and to %q by them", c.Entity, c.Field, c.Ours, c.Theirs)
+}
+
+var (
+	// cachedCounters are counted by both sides, so their increments add up
+	cachedCounters = map[string]bool{"activities": true, "notes": true}
+	// cachedLatest keep the latest time of both sides
+	cachedLatest = map[string]bool{"most_recent_activity": true}
+
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// MergeFile merges changes made to the journal file on two sides the way git merge drivers do:
+// the base, ours and theirs versions are read from the given paths and the result is written to the ours one.
+// The name is the path of the file in the journal, it tells which journal file is merged.
+func MergeFile(ctx context.Context, name, basePath, oursPath, theirsPath string) ([]Conflict, error) {
+	switch filepath.Base(name) {
+	case FileNameFriends:
+		return mergeFile(ctx, basePath, oursPath, theirsPath, MergeFriends)
+	case FileNameActivities:
+		return mergeFile(ctx, basePath, oursPath, theirsPath, MergeEvents)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedMergeFile, name)
+	}
+}
+
+func mergeFile[T Files](
+	ctx context.Context,
+	basePath, oursPath, theirsPath string,
+	merge func(base, ours, theirs T) (T, []Conflict),
+) ([]Conflict, error) {
+	var versions [3]T
+
+	for i, path := range []string{basePath, oursPath, theirsPath} {
+		content, err := loadFile[T](ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", path, err)
+		}
+
+		versions[i] = *content
+	}
+
+	merged, conflicts := merge(versions[0], versions[1], versions[2])
+
+	if err := saveFile(ctx, filepath.Dir(oursPath), filepath.Base(oursPath), merged); err != nil {
+		return nil, err
+	}
+
+	return conflicts, nil
+}
+
+// MergeFriends merges changes of friends, locations, reminders and tags made on two sides since the base version.
+// Entities are matched by their IDs and merged field by field. Lists of values (e.g. tags) are merged as sets.
+func MergeFriends(base, ours, theirs FriendsFile) (FriendsFile, []Conflict) {
+	m := &merger{}
+
+	return FriendsFile{
+		Tags:      mergeSet(m, base.Tags, ours.Tags, theirs.Tags),
+		Friends:   mergeEntities(m, "friend", base.Friends, ours.Friends, theirs.Friends),
+		Locations: mergeEntities(m, "location", base.Locations, ours.Locations, theirs.Locations),
+		Reminders: mergeEntities(m, "reminder", base.Reminders, ours.Reminders, theirs.Reminders),
+	}, m.conflicts
+}
+
+// MergeEvents merges changes of activities and notes made on two sides since the base version.
+// Events added on both sides are kept, edited events are merged field by field.
+func MergeEvents(base, ours, theirs EventsFile) (EventsFile, []Conflict) {
+	m := &merger{}
+
+	return EventsFile{
+		Activities: mergeEntities(m, "activity", base.Activities, ours.Activities, theirs.Activities),
+		Notes:      mergeEntities(m, "note", base.Notes, ours.Notes, theirs.Notes),
+	}, m.conflicts
+}
+
+func mergeEntities[T any](m *merger, kind string, base, ours, theirs []*T) []*T {
+	merged := m.entities(kind, reflect.ValueOf(base), reflect.ValueOf(ours), reflect.ValueOf(theirs))
+
+	return merged.Interface().([]*T)
+}
+
+func mergeSet[T comparable](m *merger, base, ours, theirs []T) []T {
+	return m.set(reflect.ValueOf(base), reflect.ValueOf(ours), reflect.ValueOf(theirs)).Interface().([]T)
+}
+
+// merger merges values of journal entities by reflection, so new fields are merged without extra code
+type merger struct {
+	conflicts []Conflict
+}
+
+// entities merges slices of pointers to structs with the ID field.
+// Merged entities follow our order, entities added by them go after.
+func (m *merger) entities(kind string, base, ours, theirs reflect.Value) reflect.Value {
+	baseIdx, theirsIdx, oursIdx := indexByID(base), indexByID(theirs), indexByID(ours)
+	merged := reflect.MakeSlice(ours.Type(), 0, ours.Len()+theirs.Len())
+
+	for i := range ours.Len() {
+		o := ours.Index(i)
+		id := entityID(o)
+		entity := kind + " " + id
+		b, inBase := baseIdx[id]
+		t, inTheirs := theirsIdx[id]
+
+		switch {
+		case inTheirs:
+			merged = reflect.Append(merged, m.entity(entity, b, o, t))
+		case !inBase:
+			// added by us
+			merged = reflect.Append(merged, o)
+		case !equal(b, o):
+			m.conflicts = append(m.conflicts, Conflict{Entity: entity, Ours: "changed", Theirs: "removed"})
+			merged = reflect.Append(merged, o)
+		}
+	}
+
+	for i := range theirs.Len() {
+		t := theirs.Index(i)
+		id := entityID(t)
+
+		if _, inOurs := oursIdx[id]; inOurs {
+			continue
+		}
+
+		b, inBase := baseIdx[id]
+
+		switch {
+		case !inBase:
+			// added by them
+			merged = reflect.Append(merged, t)
+		case !equal(b, t):
+			m.conflicts = append(m.conflicts, Conflict{Entity: kind + " " + id, Ours: "removed", Theirs: "changed"})
+			merged = reflect.Append(merged, t)
+		}
+	}
+
+	return merged
+}
+
+// entity merges pointers to structs field by field. The base is invalid for entities added on both sides.
+// Entities both sides changed the same way are still merged field by field, as their counters add up.
+func (m *merger) entity(entity string, base, ours, theirs reflect.Value) reflect.Value {
+	switch {
+	case !base.IsValid() && equal(ours, theirs):
+		return ours
+	case !base.IsValid():
+		base = reflect.New(ours.Type().Elem())
+	case equal(base, ours):
+		return theirs
+	case equal(base, theirs):
+		return ours
+	}
+
+	merged := reflect.New(ours.Type().Elem())
+	merged.Elem().Set(ours.Elem())
+
+	st := ours.Type().Elem()
+
+	for i := range st.NumField() {
+		f := st.Field(i)
+		name := tomlName(f)
+
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+
+		v := m.field(entity, name, base.Elem().Field(i), ours.Elem().Field(i), theirs.Elem().Field(i))
+		merged.Elem().Field(i).Set(v)
+	}
+
+	return merged
+}
+
+func (m *merger) field(entity, name string, base, ours, theirs reflect.Value) reflect.Value {
+	// counters go first: both sides bumping them by one are two separate changes, not the same one
+	if cachedCounters[name] && ours.CanInt() {
+		v := reflect.New(ours.Type()).Elem()
+		v.SetInt(ours.Int() + theirs.Int() - base.Int())
+
+		return v
+	}
+
+	switch {
+	case equal(ours, theirs), equal(base, theirs):
+		return ours
+	case equal(base, ours):
+		return theirs
+	case cachedLatest[name] && ours.Type() == timeType:
+		if theirs.Interface().(time.Time).After(ours.Interface().(time.Time)) {
+			return theirs
+		}
+
+		return ours
+	case isEntityList(ours.Type()):
+		return m.entities(entity+" "+name, base, ours, theirs)
+	case ours.Kind() == reflect.Slice && ours.Type().Elem().Comparable():
+		return m.set(base, ours, theirs)
+	}
+
+	m.conflicts = append(m.conflicts, Conflict{
+		Entity: entity,
+		Field:  name,
+		Ours:   render(ours),
+		Theirs: render(theirs),
+	})
+
+	return ours
+}
+
+// set merges lists of values: values added by them are appended to ours, values removed by them are dropped
+func (m *merger) set(base, ours, theirs reflect.Value) reflect.Value {
+	inBase, inOurs, inTheirs := valueSet(base), valueSet(ours), valueSet(theirs)
+	merged := reflect.MakeSlice(ours.Type(), 0, ours.Len()+theirs.Len())
+
+	for i := range ours.Len() {
+		v := ours.Index(i)
+
+		if inBase[v.Interface()] && !inTheirs[v.Interface()] {
+			continue
+		}
+
+		merged = reflect.Append(merged, v)
+	}
+
+	for i := range theirs.Len() {
+		v := theirs.Index(i)
+
+		if !inBase[v.Interface()] && !inOurs[v.Interface()] {
+			merged = reflect.Append(merged, v)
+		}
+	}
+
+	if merged.Len() == 0 && ours.IsNil() {
+		return ours
+	}
+
+	return merged
+}
+
+func valueSet(list reflect.Value) map[any]bool {
+	set := make(map[any]bool, list.Len())
+
+	for i := range list.Len() {
+		set[list.Index(i).Interface()] = true
+	}
+
+	return set
+}
+
+func indexByID(list reflect.Value) map[string]reflect.Value {
+	idx := make(map[string]reflect.Value, list.Len())
+
+	for i := range list.Len() {
+		idx[entityID(list.Index(i))] = list.Index(i)
+	}
+
+	return idx
+}
+
+func entityID(v reflect.Value) string {
+	if v.IsNil() {
+		return ""
+	}
+
+	return v.Elem().FieldByName("ID").String()
+}
+
+// isEntityList tells whether the type is a slice of pointers to structs identified by IDs (e.g. contacts)
+func isEntityList(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice || t.Elem().Kind() != reflect.Pointer || t.Elem().Elem().Kind() != reflect.Struct {
+		return false
+	}
+
+	f, ok := t.Elem().Elem().FieldByName("ID")
+
+	return ok && f.Type.Kind() == reflect.String
+}
+
+func tomlName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("toml"), ",")
+
+	if name == "" {
+		return strings.ToLower(f.Name)
+	}
+
+	return name
+}
+
+// equal compares values like reflect.DeepEqual, but times by the instant they represent,
+// as decoded times don't share locations. Nil and empty slices are equal, as they are encoded the same way.
+func equal(a, b reflect.Value) bool {
+	if a.Type() == timeType {
+		return a.Interface().(time.Time).Equal(b.Interface().(time.Time))
+	}
+
+	switch a.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+
+		return equal(a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := range a.NumField() {
+			if a.Type().Field(i).IsExported() && !equal(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+
+		for i := range a.Len() {
+			if !equal(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
+
+func render(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Type() == timeType {
+		if t := v.Interface().(time.Time); !t.IsZero() {
+			return t.Format(time.RFC3339)
+		}
+
+		return ""
+	}
+
+	// entities without their own string form (e.g. dates) are rendered by fields set
+	if v.Kind() == reflect.Struct {
+		fields := make([]string, 0, v.NumField())
+
+		for i := range v.NumField() {
+			f := v.Type().Field(i)
+			name := tomlName(f)
+
+			if !f.IsExported() || name == "-" || name == "id" || v.Field(i).IsZero() {
+				continue
+			}
+
+			fields = append(fields, name+": "+render(v.Field(i)))
+		}
+
+		return strings.Join(fields, "; ")
+	}
+
+	if v.Kind() == reflect.Slice {
+		items := make([]string, 0, v.Len())
+
+		for i := range v.Len() {
+			items = append(items, render(v.Index(i)))
+		}
+
+		return strings.Join(items, ", ")
+	}
+
+	return fmt.Sprint(v.Interface())
+}
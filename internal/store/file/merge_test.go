@@ -0,0 +1,199 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/tag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeFriends(t *testing.T) {
+	t.Parallel()
+
+	lastWeek := time.Date(2026, 10, 9, 18, 0, 0, 0, time.UTC)
+	yesterday := lastWeek.AddDate(0, 0, 6)
+	today := yesterday.AddDate(0, 0, 1)
+
+	base := FriendsFile{
+		Tags: []tag.Tag{{Name: "office"}},
+		Friends: []*friend.Person{
+			{
+				ID:                 "jim",
+				Name:               "Jim Halpert",
+				Desc:               "a prankster",
+				Tags:               []string{"office", "sales"},
+				Contacts:           []*friend.Contact{{ID: "c1", Type: friend.ContactTypeEmail, Value: "jim@dm.com"}},
+				Activities:         3,
+				MostRecentActivity: lastWeek,
+			},
+			{ID: "dwight", Name: "Dwight Schrute"},
+			{ID: "toby", Name: "Toby Flenderson"},
+		},
+	}
+
+	ours := FriendsFile{
+		Tags: []tag.Tag{{Name: "office"}, {Name: "family"}},
+		Friends: []*friend.Person{
+			{
+				ID:                 "jim",
+				Name:               "Jim Halpert",
+				Desc:               "Pam's husband",
+				Tags:               []string{"office", "sales", "family"},
+				Contacts:           []*friend.Contact{{ID: "c1", Type: friend.ContactTypeEmail, Value: "jim@dm.com"}},
+				Activities:         4,
+				MostRecentActivity: yesterday,
+			},
+			{ID: "dwight", Name: "Dwight K. Schrute", Notes: 1},
+			{ID: "pam", Name: "Pam Beesly"},
+		},
+	}
+
+	theirs := FriendsFile{
+		Tags: []tag.Tag{{Name: "office"}, {Name: "beets"}},
+		Friends: []*friend.Person{
+			{
+				ID:   "jim",
+				Name: "Jim Halpert",
+				Desc: "a salesman",
+				Tags: []string{"office"},
+				Contacts: []*friend.Contact{
+					{ID: "c1", Type: friend.ContactTypeEmail, Value: "jim.halpert@dm.com"},
+					{ID: "c2", Type: friend.ContactTypePhone, Value: "555-0101"},
+				},
+				Activities:         5,
+				MostRecentActivity: today,
+			},
+			{ID: "dwight", Name: "Dwight Schrute", Tags: []string{"beets"}, Notes: 1},
+			{ID: "toby", Name: "Toby Flenderson", Desc: "HR"},
+			{ID: "michael", Name: "Michael Scott"},
+		},
+	}
+
+	merged, conflicts := MergeFriends(base, ours, theirs)
+
+	require.Equal(t, []tag.Tag{{Name: "office"}, {Name: "family"}, {Name: "beets"}}, merged.Tags)
+	require.Len(t, merged.Friends, 5)
+
+	jim := merged.Friends[0]
+	require.Equal(t, "Pam's husband", jim.Desc)
+	require.Equal(t, []string{"office", "family"}, jim.Tags)
+	require.Equal(t, 6, jim.Activities)
+	require.Equal(t, today, jim.MostRecentActivity)
+	require.Len(t, jim.Contacts, 2)
+	require.Equal(t, "jim.halpert@dm.com", jim.Contacts[0].Value)
+
+	dwight := merged.Friends[1]
+	require.Equal(t, "Dwight K. Schrute", dwight.Name)
+	require.Equal(t, []string{"beets"}, dwight.Tags)
+	require.Equal(t, 2, dwight.Notes, "both sides noted Dwight down once")
+
+	// removed by us, but changed by them
+	require.Equal(t, "HR", merged.Friends[3].Desc)
+
+	require.Equal(t, "pam", merged.Friends[2].ID)
+	require.Equal(t, "michael", merged.Friends[4].ID)
+
+	require.Equal(t, []Conflict{
+		{Entity: "friend jim", Field: "desc", Ours: "Pam's husband", Theirs: "a salesman"},
+		{Entity: "friend toby", Ours: "removed", Theirs: "changed"},
+	}, conflicts)
+}
+
+func TestMergeFriends_SameCounterChanges(t *testing.T) {
+	t.Parallel()
+
+	base := FriendsFile{Friends: []*friend.Person{{ID: "jim", Name: "Jim Halpert", Activities: 3}}}
+	ours := FriendsFile{Friends: []*friend.Person{{ID: "jim", Name: "Jim Halpert", Activities: 4}}}
+	theirs := FriendsFile{Friends: []*friend.Person{{ID: "jim", Name: "Jim Halpert", Activities: 4}}}
+
+	merged, conflicts := MergeFriends(base, ours, theirs)
+
+	require.Empty(t, conflicts)
+	require.Len(t, merged.Friends, 1)
+	require.Equal(t, 5, merged.Friends[0].Activities, "both sides added an activity with Jim")
+}
+
+func TestMergeEvents(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	dinner := &friend.Event{ID: "e1", Type: friend.EventTypeActivity, Date: day, Desc: "Dinner with Jim"}
+
+	base := EventsFile{Activities: []*friend.Event{dinner}}
+	ours := EventsFile{
+		Activities: []*friend.Event{
+			dinner,
+			{ID: "e2", Type: friend.EventTypeActivity, Date: day, Desc: "Lunch with Pam"},
+		},
+	}
+	theirs := EventsFile{
+		Activities: []*friend.Event{
+			{ID: "e1", Type: friend.EventTypeActivity, Date: day.In(time.FixedZone("EEST", 3*3600)), Desc: "Dinner"},
+			{ID: "e3", Type: friend.EventTypeActivity, Date: day, Desc: "Coffee with Dwight"},
+		},
+		Notes: []*friend.Event{{ID: "n1", Type: friend.EventTypeNote, Date: day, Desc: "Dwight loves beets"}},
+	}
+
+	merged, conflicts := MergeEvents(base, ours, theirs)
+
+	require.Empty(t, conflicts)
+	require.Len(t, merged.Activities, 3)
+	require.Equal(t, "Dinner", merged.Activities[0].Desc)
+	require.Equal(t, "e2", merged.Activities[1].ID)
+	require.Equal(t, "e3", merged.Activities[2].ID)
+	require.Len(t, merged.Notes, 1)
+}
+
+func TestMergeFile(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	write := func(name string, content EventsFile) string {
+		require.NoError(t, saveFile(ctx, dir, name, content))
+		return filepath.Join(dir, name)
+	}
+
+	day := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	base := write("base", EventsFile{})
+	ours := write("ours", EventsFile{Activities: []*friend.Event{{ID: "e1", Date: day, Desc: "Dinner with Jim"}}})
+	theirs := write("theirs", EventsFile{Notes: []*friend.Event{{ID: "n1", Date: day, Desc: "Jim likes pizza"}}})
+
+	conflicts, err := MergeFile(ctx, FileNameActivities, base, ours, theirs)
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+
+	merged, err := loadFile[EventsFile](ctx, ours)
+	require.NoError(t, err)
+	require.Len(t, merged.Activities, 1)
+	require.Len(t, merged.Notes, 1)
+
+	// git passes an empty base file when both sides added the file
+	require.NoError(t, os.WriteFile(base, nil, 0o600))
+
+	_, err = MergeFile(ctx, FileNameActivities, base, ours, theirs)
+	require.NoError(t, err)
+
+	_, err = MergeFile(ctx, "config.toml", base, ours, theirs)
+	require.ErrorIs(t, err, ErrUnsupportedMergeFile)
+}
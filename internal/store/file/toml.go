@@ -15,16 +15,19 @@
 package file
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/roma-glushko/frens/internal/friend"
 	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/log"
 	"github.com/roma-glushko/frens/internal/store"
 	"github.com/roma-glushko/frens/internal/tag"
 )
@@ -42,6 +45,7 @@ type FriendsFile struct {
 	Tags      []tag.Tag          `toml:"tags"`
 	Friends   []*friend.Person   `toml:"friends"`
 	Locations []*friend.Location `toml:"locations"`
+	Reminders []*friend.Reminder `toml:"reminders,omitempty"`
 }
 
 type EventsFile struct {
@@ -50,18 +54,65 @@ type EventsFile struct {
 }
 
 type TOMLFileStore struct {
-	dir string
-	mu  sync.Mutex // TODO: use file locking instead
+	dir         string
+	lockTimeout time.Duration
+	undoDepth   int
+	fuzzyLookup bool
+	onSave      store.SaveHook
+	mu          sync.Mutex // guards transactions within the process, the lock file guards them across processes
 }
 
-var _ store.Store = (*TOMLFileStore)(nil)
+var (
+	_ store.Store    = (*TOMLFileStore)(nil)
+	_ store.Locker   = (*TOMLFileStore)(nil)
+	_ store.Notifier = (*TOMLFileStore)(nil)
+)
+
+type Option func(s *TOMLFileStore)
+
+// WithLockTimeout sets how long transactions wait for other processes to release the journal
+func WithLockTimeout(timeout time.Duration) Option {
+	return func(s *TOMLFileStore) {
+		s.lockTimeout = timeout
+	}
+}
+
+// WithUndoDepth sets how many transactions are kept to undo. Zero turns undo off.
+func WithUndoDepth(depth int) Option {
+	return func(s *TOMLFileStore) {
+		s.undoDepth = depth
+	}
+}
 
-func NewTOMLFileStore(dir string) *TOMLFileStore {
-	return &TOMLFileStore{
-		dir: dir,
+// WithFuzzyLookup lets loaded journals look friends and locations up by names with typos
+func WithFuzzyLookup() Option {
+	return func(s *TOMLFileStore) {
+		s.fuzzyLookup = true
 	}
 }
 
+func NewTOMLFileStore(dir string, opts ...Option) *TOMLFileStore {
+	s := &TOMLFileStore{
+		dir:         dir,
+		lockTimeout: DefaultLockTimeout,
+		undoDepth:   DefaultUndoDepth,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// OnSave sets the hook called after transactions, undos and redos save the journal
+func (s *TOMLFileStore) OnSave(hook store.SaveHook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onSave = hook
+}
+
 func (s *TOMLFileStore) Path() string {
 	return s.dir
 }
@@ -116,17 +167,28 @@ func (s *TOMLFileStore) Load(ctx context.Context) (*journal.Journal, error) {
 		return nil, errors.Join(errs...)
 	}
 
+	j := newJournal(*entities, *events)
+
+	if s.fuzzyLookup {
+		j.EnableFuzzyLookup()
+	}
+
+	return j, nil
+}
+
+func newJournal(entities FriendsFile, events EventsFile) *journal.Journal {
 	j := &journal.Journal{
 		Tags:       entities.Tags,
 		Friends:    entities.Friends,
 		Locations:  entities.Locations,
+		Reminders:  entities.Reminders,
 		Activities: events.Activities,
 		Notes:      events.Notes,
 	}
 
 	j.Init()
 
-	return j, nil
+	return j
 }
 
 func (s *TOMLFileStore) Save(ctx context.Context, j *journal.Journal) error {
@@ -136,6 +198,7 @@ func (s *TOMLFileStore) Save(ctx context.Context, j *journal.Journal) error {
 		Tags:      j.Tags,
 		Friends:   j.Friends,
 		Locations: j.Locations,
+		Reminders: j.Reminders,
 	}
 
 	if err := saveFile(ctx, s.dir, FileNameFriends, entities); err != nil {
@@ -155,32 +218,87 @@ func (s *TOMLFileStore) Save(ctx context.Context, j *journal.Journal) error {
 }
 
 func (s *TOMLFileStore) Tx(ctx context.Context, fn store.JournalUpdater) error {
+	return s.Locked(ctx, func() error {
+		j, err := s.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load journal: %w", err)
+		}
+
+		var snapshot journal.Snapshot
+
+		if s.onSave != nil {
+			snapshot = j.Snapshot()
+		}
+
+		if err := fn(j); err != nil {
+			return fmt.Errorf("failed to execute transaction function: %w", err)
+		}
+
+		if !j.IsDirty() {
+			return nil
+		}
+
+		var before map[string][]byte
+
+		// files are not saved yet, so they still hold the journal the transaction started with
+		if s.undoDepth > 0 {
+			if before, err = s.readJournalFiles(); err != nil {
+				return fmt.Errorf("failed to read journal: %w", err)
+			}
+		}
+
+		if err := s.Save(ctx, j); err != nil {
+			return fmt.Errorf("failed to save journal: %w", err)
+		}
+
+		j.SetDirty(false)
+
+		// the transaction is saved already, so it's not failed because of that
+		if before != nil {
+			if err := s.record(before); err != nil {
+				log.Warnf("the change can't be undone: %v", err)
+			}
+		}
+
+		if s.onSave != nil {
+			s.onSave(ctx, "", snapshot.Changes(j.Snapshot()))
+		}
+
+		return nil
+	})
+}
+
+// Locked runs fn holding the journal for this process and others
+func (s *TOMLFileStore) Locked(ctx context.Context, fn func() error) (err error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	j, err := s.Load(ctx)
+	lock, err := acquireLock(ctx, s.dir, s.lockTimeout)
 	if err != nil {
-		return fmt.Errorf("failed to load journal: %w", err)
+		return fmt.Errorf("failed to lock journal: %w", err)
 	}
 
-	if err := fn(j); err != nil {
-		return fmt.Errorf("failed to execute transaction function: %w", err)
-	}
+	defer func() {
+		if releaseErr := lock.Release(); releaseErr != nil {
+			err = errors.Join(err, releaseErr)
+		}
+	}()
 
-	if !j.IsDirty() {
-		return nil
-	}
+	return fn()
+}
 
-	if err := s.Save(ctx, j); err != nil {
-		return fmt.Errorf("failed to save journal: %w", err)
-	}
+func saveFile[T Files](_ context.Context, dirPath, fileName string, content T) error {
+	var buf bytes.Buffer
 
-	j.SetDirty(false)
+	if err := toml.NewEncoder(&buf).Encode(content); err != nil {
+		return fmt.Errorf("failed to encode content of %s: %w", filepath.Join(dirPath, fileName), err)
+	}
 
-	return nil
+	return writeFile(dirPath, fileName, buf.Bytes())
 }
 
-func saveFile[T Files](_ context.Context, dirPath, fileName string, content T) (err error) {
+// writeFile replaces the file at once, so readers never see it half-written
+func writeFile(dirPath, fileName string, content []byte) error {
 	path := filepath.Join(dirPath, fileName)
 
 	tmpFile, err := os.CreateTemp(dirPath, fileName+".*.tmp")
@@ -188,13 +306,11 @@ func saveFile[T Files](_ context.Context, dirPath, fileName string, content T) (
 		return fmt.Errorf("failed to create a temp file for %s: %w", path, err)
 	}
 
-	encoder := toml.NewEncoder(tmpFile)
-
-	if err = encoder.Encode(content); err != nil {
+	if _, err := tmpFile.Write(content); err != nil {
 		_ = tmpFile.Close()
 		_ = os.Remove(tmpFile.Name())
 
-		return fmt.Errorf("failed to encode content of %s: %w", path, err)
+		return fmt.Errorf("failed to write content of %s: %w", path, err)
 	}
 
 	if err := tmpFile.Sync(); err != nil {
@@ -0,0 +1,466 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/store"
+)
+
+const (
+	// DirNameUndo keeps transactions to undo. They are local to the device, so the directory is ignored by git.
+	DirNameUndo = ".undo"
+
+	// DefaultUndoDepth is how many transactions are kept to undo
+	DefaultUndoDepth = 50
+
+	undoIndexFile = "index.json"
+)
+
+var _ store.Undoer = (*TOMLFileStore)(nil)
+
+// undoIndex lists kept operations, oldest first. Undone operations go last.
+type undoIndex struct {
+	LastID     int         `json:"last_id"`
+	Operations []undoEntry `json:"operations"`
+}
+
+type undoEntry struct {
+	ID     int       `json:"id"`
+	At     time.Time `json:"at"`
+	Undone bool      `json:"undone,omitempty"`
+}
+
+// undoRecord keeps journal files changed by the operation as they were before and after it
+type undoRecord struct {
+	Files map[string]fileChange `json:"files"`
+}
+
+type fileChange struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Undo reverts the last transaction that hasn't been undone yet.
+// Changes made since then (e.g. pulled from other devices) are kept.
+func (s *TOMLFileStore) Undo(ctx context.Context) (store.Operation, error) {
+	return s.step(ctx, true)
+}
+
+// Redo applies the last undone transaction again
+func (s *TOMLFileStore) Redo(ctx context.Context) (store.Operation, error) {
+	return s.step(ctx, false)
+}
+
+// Operations lists transactions kept to undo, oldest first
+func (s *TOMLFileStore) Operations(ctx context.Context) ([]store.Operation, error) {
+	var ops []store.Operation
+
+	err := s.Locked(ctx, func() error {
+		idx, err := s.loadUndoIndex()
+		if err != nil {
+			return err
+		}
+
+		ops = make([]store.Operation, 0, len(idx.Operations))
+
+		for _, e := range idx.Operations {
+			op, err := s.operation(e)
+			if err != nil {
+				return err
+			}
+
+			ops = append(ops, op)
+		}
+
+		return nil
+	})
+
+	return ops, err
+}
+
+func (s *TOMLFileStore) step(ctx context.Context, undo bool) (store.Operation, error) {
+	var op store.Operation
+
+	if s.undoDepth <= 0 {
+		return op, store.ErrUndoUnsupported
+	}
+
+	err := s.Locked(ctx, func() error {
+		idx, err := s.loadUndoIndex()
+		if err != nil {
+			return err
+		}
+
+		i := slices.IndexFunc(idx.Operations, func(e undoEntry) bool { return e.Undone })
+
+		if undo {
+			// the last operation that isn't undone goes right before undone ones
+			if i == -1 {
+				i = len(idx.Operations)
+			}
+
+			if i--; i < 0 {
+				return store.ErrNothingToUndo
+			}
+		} else if i == -1 {
+			return store.ErrNothingToRedo
+		}
+
+		rec, err := s.loadUndoRecord(idx.Operations[i].ID)
+		if err != nil {
+			return err
+		}
+
+		conflicts, err := s.revert(ctx, rec, undo)
+		if err != nil {
+			return err
+		}
+
+		idx.Operations[i].Undone = undo
+
+		if err := s.saveUndoIndex(idx); err != nil {
+			return err
+		}
+
+		if op, err = s.operation(idx.Operations[i]); err != nil {
+			return err
+		}
+
+		op.Conflicts = conflicts
+
+		if s.onSave != nil {
+			s.onSave(ctx, stepAction(undo), op.Changes)
+		}
+
+		return nil
+	})
+
+	return op, err
+}
+
+func stepAction(undo bool) string {
+	if undo {
+		return "undo"
+	}
+
+	return "redo"
+}
+
+// revert brings journal files from one side of the operation to the other.
+// Files changed since are merged, so only changes of the operation are reverted.
+func (s *TOMLFileStore) revert(ctx context.Context, rec undoRecord, undo bool) ([]string, error) {
+	var conflicts []Conflict
+
+	for _, name := range slices.Sorted(maps.Keys(rec.Files)) {
+		from, to := rec.Files[name].Before, rec.Files[name].After
+
+		if undo {
+			from, to = to, from
+		}
+
+		current, err := os.ReadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		if string(current) == from {
+			if err := writeFile(s.dir, name, []byte(to)); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		var c []Conflict
+
+		switch name {
+		case FileNameFriends:
+			c, err = mergeVersions(ctx, s.dir, name, from, string(current), to, MergeFriends)
+		case FileNameActivities:
+			c, err = mergeVersions(ctx, s.dir, name, from, string(current), to, MergeEvents)
+		default:
+			err = fmt.Errorf("%w: %s", ErrUnsupportedMergeFile, name)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		conflicts = append(conflicts, c...)
+	}
+
+	descs := make([]string, 0, len(conflicts))
+
+	for _, c := range conflicts {
+		descs = append(descs, c.String())
+	}
+
+	return descs, nil
+}
+
+// mergeVersions merges changes from the base to theirs into ours and saves the result
+func mergeVersions[T Files](
+	ctx context.Context,
+	dir, name, base, ours, theirs string,
+	merge func(base, ours, theirs T) (T, []Conflict),
+) ([]Conflict, error) {
+	var versions [3]T
+
+	for i, content := range []string{base, ours, theirs} {
+		if _, err := toml.Decode(content, &versions[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+	}
+
+	merged, conflicts := merge(versions[0], versions[1], versions[2])
+
+	return conflicts, saveFile(ctx, dir, name, merged)
+}
+
+// readJournalFiles reads journal files as they are, so they can be restored byte by byte
+func (s *TOMLFileStore) readJournalFiles() (map[string][]byte, error) {
+	files := make(map[string][]byte, 2)
+
+	for _, name := range []string{FileNameFriends, FileNameActivities} {
+		content, err := os.ReadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		files[name] = content
+	}
+
+	return files, nil
+}
+
+// record keeps journal files changed by the transaction to undo it.
+// Undone operations can't be redone after new transactions, so they are dropped.
+func (s *TOMLFileStore) record(before map[string][]byte) error {
+	after, err := s.readJournalFiles()
+	if err != nil {
+		return err
+	}
+
+	rec := undoRecord{Files: make(map[string]fileChange, len(after))}
+
+	for name, content := range after {
+		if !bytes.Equal(before[name], content) {
+			rec.Files[name] = fileChange{Before: string(before[name]), After: string(content)}
+		}
+	}
+
+	if len(rec.Files) == 0 {
+		return nil
+	}
+
+	idx, err := s.loadUndoIndex()
+	if err != nil {
+		return err
+	}
+
+	var dropped []undoEntry
+
+	idx.Operations = slices.DeleteFunc(idx.Operations, func(e undoEntry) bool {
+		if e.Undone {
+			dropped = append(dropped, e)
+		}
+
+		return e.Undone
+	})
+
+	idx.LastID++
+	entry := undoEntry{ID: idx.LastID, At: time.Now()}
+
+	if err := s.saveUndoRecord(entry.ID, rec); err != nil {
+		return err
+	}
+
+	idx.Operations = append(idx.Operations, entry)
+
+	if over := len(idx.Operations) - s.undoDepth; over > 0 {
+		dropped = append(dropped, idx.Operations[:over]...)
+		idx.Operations = slices.Clone(idx.Operations[over:])
+	}
+
+	if err := s.saveUndoIndex(idx); err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, e := range dropped {
+		if err := os.Remove(s.undoRecordPath(e.ID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("failed to drop operation %d: %w", e.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// operation describes the kept operation with entities it changed
+func (s *TOMLFileStore) operation(e undoEntry) (store.Operation, error) {
+	rec, err := s.loadUndoRecord(e.ID)
+	if err != nil {
+		return store.Operation{}, err
+	}
+
+	before, err := rec.journal(func(c fileChange) string { return c.Before })
+	if err != nil {
+		return store.Operation{}, err
+	}
+
+	after, err := rec.journal(func(c fileChange) string { return c.After })
+	if err != nil {
+		return store.Operation{}, err
+	}
+
+	return store.Operation{
+		ID:      e.ID,
+		At:      e.At,
+		Changes: before.Snapshot().Changes(after.Snapshot()),
+		Undone:  e.Undone,
+	}, nil
+}
+
+// journal decodes one side of the operation. Files it didn't change are left empty on both sides.
+func (r undoRecord) journal(side func(c fileChange) string) (*journal.Journal, error) {
+	var (
+		entities FriendsFile
+		events   EventsFile
+	)
+
+	if c, ok := r.Files[FileNameFriends]; ok {
+		if _, err := toml.Decode(side(c), &entities); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", FileNameFriends, err)
+		}
+	}
+
+	if c, ok := r.Files[FileNameActivities]; ok {
+		if _, err := toml.Decode(side(c), &events); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", FileNameActivities, err)
+		}
+	}
+
+	return newJournal(entities, events), nil
+}
+
+func (s *TOMLFileStore) undoDir() string {
+	return filepath.Join(s.dir, DirNameUndo)
+}
+
+func (s *TOMLFileStore) undoRecordPath(id int) string {
+	return filepath.Join(s.undoDir(), strconv.Itoa(id)+".json.gz")
+}
+
+func (s *TOMLFileStore) loadUndoIndex() (undoIndex, error) {
+	var idx undoIndex
+
+	data, err := os.ReadFile(filepath.Join(s.undoDir(), undoIndexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return idx, nil
+	}
+
+	if err != nil {
+		return idx, fmt.Errorf("failed to read undo index: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return idx, fmt.Errorf("failed to decode undo index: %w", err)
+	}
+
+	return idx, nil
+}
+
+func (s *TOMLFileStore) saveUndoIndex(idx undoIndex) error {
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("failed to encode undo index: %w", err)
+	}
+
+	return writeFile(s.undoDir(), undoIndexFile, data)
+}
+
+func (s *TOMLFileStore) loadUndoRecord(id int) (undoRecord, error) {
+	var rec undoRecord
+
+	f, err := os.Open(s.undoRecordPath(id))
+	if err != nil {
+		return rec, fmt.Errorf("failed to open operation %d: %w", id, err)
+	}
+
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return rec, fmt.Errorf("failed to read operation %d: %w", id, err)
+	}
+
+	if err := json.NewDecoder(r).Decode(&rec); err != nil {
+		return rec, fmt.Errorf("failed to decode operation %d: %w", id, err)
+	}
+
+	return rec, nil
+}
+
+// saveUndoRecord compresses the record, as journal files are mostly the same text on both sides
+func (s *TOMLFileStore) saveUndoRecord(id int, rec undoRecord) error {
+	if err := s.initUndoDir(); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	if err := json.NewEncoder(w).Encode(rec); err != nil {
+		return fmt.Errorf("failed to encode operation %d: %w", id, err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to compress operation %d: %w", id, err)
+	}
+
+	return writeFile(s.undoDir(), filepath.Base(s.undoRecordPath(id)), buf.Bytes())
+}
+
+// initUndoDir creates the directory for kept operations, which ignores itself in git
+func (s *TOMLFileStore) initUndoDir() error {
+	if err := os.MkdirAll(s.undoDir(), 0o700); err != nil {
+		return fmt.Errorf("failed to create undo directory: %w", err)
+	}
+
+	ignore := filepath.Join(s.undoDir(), ".gitignore")
+
+	if _, err := os.Stat(ignore); err == nil {
+		return nil
+	}
+
+	return writeFile(s.undoDir(), ".gitignore", []byte("*\n"))
+}
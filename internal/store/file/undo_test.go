@@ -0,0 +1,161 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/store"
+	"github.com/stretchr/testify/require"
+)
+
+func addFriend(t *testing.T, s *TOMLFileStore, name string) {
+	t.Helper()
+
+	require.NoError(t, s.Tx(t.Context(), func(j *journal.Journal) error {
+		j.AddFriend(friend.Person{Name: name})
+
+		return nil
+	}))
+}
+
+func friendIDs(t *testing.T, s *TOMLFileStore) []string {
+	t.Helper()
+
+	j, err := s.Load(t.Context())
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(j.Friends))
+
+	for _, f := range j.Friends {
+		ids = append(ids, f.ID)
+	}
+
+	return ids
+}
+
+func TestTOMLFileStore_UndoRedo(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	s := NewTOMLFileStore(t.TempDir())
+
+	require.NoError(t, s.Init(ctx))
+
+	_, err := s.Undo(ctx)
+	require.ErrorIs(t, err, store.ErrNothingToUndo)
+
+	addFriend(t, s, "Jim Halpert")
+	addFriend(t, s, "Pam Beesly")
+
+	op, err := s.Undo(ctx)
+	require.NoError(t, err)
+	require.True(t, op.Undone)
+	require.Equal(t, []journal.EntityChange{
+		{Kind: journal.ChangeAdded, Entity: "friend", ID: "pam-beesly", Label: "Pam Beesly"},
+	}, op.Changes)
+	require.Equal(t, []string{"jim-halpert"}, friendIDs(t, s))
+
+	_, err = s.Undo(ctx)
+	require.NoError(t, err)
+	require.Empty(t, friendIDs(t, s))
+
+	_, err = s.Undo(ctx)
+	require.ErrorIs(t, err, store.ErrNothingToUndo)
+
+	op, err = s.Redo(ctx)
+	require.NoError(t, err)
+	require.False(t, op.Undone)
+	require.Equal(t, "jim-halpert", op.Changes[0].ID)
+	require.Equal(t, []string{"jim-halpert"}, friendIDs(t, s))
+
+	// a new transaction drops undone ones, as they can't be redone on top of it
+	addFriend(t, s, "Dwight Schrute")
+
+	_, err = s.Redo(ctx)
+	require.ErrorIs(t, err, store.ErrNothingToRedo)
+
+	ops, err := s.Operations(ctx)
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+	require.Equal(t, "jim-halpert", ops[0].Changes[0].ID)
+	require.Equal(t, "dwight-schrute", ops[1].Changes[0].ID)
+
+	entries, err := os.ReadDir(filepath.Join(s.Path(), DirNameUndo))
+	require.NoError(t, err)
+	require.Len(t, entries, 4) // .gitignore, the index and two operations
+}
+
+func TestTOMLFileStore_UndoKeepsLaterChanges(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	dir := t.TempDir()
+	s := NewTOMLFileStore(dir)
+
+	require.NoError(t, s.Init(ctx))
+
+	addFriend(t, s, "Jim Halpert")
+
+	// e.g. pulled from another device
+	other := NewTOMLFileStore(dir, WithUndoDepth(0))
+	addFriend(t, other, "Pam Beesly")
+
+	op, err := s.Undo(ctx)
+	require.NoError(t, err)
+	require.Empty(t, op.Conflicts)
+	require.Equal(t, []string{"pam-beesly"}, friendIDs(t, s))
+
+	_, err = s.Redo(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"jim-halpert", "pam-beesly"}, friendIDs(t, s))
+
+	_, err = other.Undo(ctx)
+	require.ErrorIs(t, err, store.ErrUndoUnsupported)
+}
+
+func TestTOMLFileStore_UndoDepth(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	s := NewTOMLFileStore(t.TempDir(), WithUndoDepth(2))
+
+	require.NoError(t, s.Init(ctx))
+
+	addFriend(t, s, "Jim Halpert")
+	addFriend(t, s, "Pam Beesly")
+	addFriend(t, s, "Dwight Schrute")
+
+	ops, err := s.Operations(ctx)
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+	require.Equal(t, "pam-beesly", ops[0].Changes[0].ID)
+
+	_, err = os.Stat(s.undoRecordPath(ops[0].ID - 1))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	for range ops {
+		_, err = s.Undo(ctx)
+		require.NoError(t, err)
+	}
+
+	_, err = s.Undo(ctx)
+	require.ErrorIs(t, err, store.ErrNothingToUndo)
+	require.Equal(t, []string{"jim-halpert"}, friendIDs(t, s))
+}
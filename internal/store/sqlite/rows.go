@@ -0,0 +1,487 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/tag"
+)
+
+type row []any
+
+func insertTag(w *writer, pos int, t tag.Tag) error {
+	_, err := w.insert(tableTags, pos, t.Name)
+
+	return err
+}
+
+// insertPerson writes the friend along with their contacts, dates and wishlist
+func insertPerson(w *writer, pos int, p *friend.Person) error {
+	key, err := w.insert(tablePersons,
+		pos, p.ID, p.Name, p.Desc, encodeList(p.Nicknames), encodeList(p.Tags), encodeTime(p.CreatedAt),
+		p.Activities, p.Notes, encodeTime(p.MostRecentActivity),
+	)
+	if err != nil {
+		return err
+	}
+
+	for k, lID := range p.Locations {
+		if _, err := w.insert(tablePersonLocations, key, k, lID); err != nil {
+			return err
+		}
+	}
+
+	for k, c := range p.Contacts {
+		if _, err := w.insert(tableContacts, key, k, c.ID, string(c.Type), c.Value, encodeList(c.Tags)); err != nil {
+			return err
+		}
+	}
+
+	for k, d := range p.Dates {
+		_, err := w.insert(tableDates, key, k, d.ID, d.Calendar, d.DateExpr, d.Desc, encodeList(d.Tags))
+		if err != nil {
+			return err
+		}
+
+		for n, r := range d.Reminders {
+			_, err := w.insert(tableDateReminders,
+				key, k, n, r.ID, r.Desc, r.DateExpr, r.Recurrence,
+				r.OffsetDirection, encodeDuration(r.Offset), encodeList(r.Tags), encodeTime(r.CreatedAt),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for k, wi := range p.Wishlist {
+		_, err := w.insert(tableWishlistItems,
+			key, k, wi.ID, encodeTime(wi.CreatedAt), wi.Desc, wi.Link, wi.Price,
+			encodeList(wi.Tags), encodeList(wi.Location),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertLocation(w *writer, pos int, l *friend.Location) error {
+	_, err := w.insert(tableLocations,
+		pos, l.ID, l.Name, l.Country, l.Desc, encodeList(l.Aliases), encodeList(l.Tags),
+		encodeFloat(l.Lat), encodeFloat(l.Lng), encodeTime(l.CreatedAt),
+		l.Notes, l.Activities, encodeTime(l.MostRecentActivity),
+	)
+
+	return err
+}
+
+// insertEvent writes the event along with its friend and location references
+func insertEvent(w *writer, eType friend.EventType, pos int, e *friend.Event) error {
+	key, err := w.insert(tableEvents, string(eType), pos, e.ID, encodeTime(e.Date), e.Desc, encodeList(e.Tags))
+	if err != nil {
+		return err
+	}
+
+	for k, fID := range e.FriendIDs {
+		if _, err := w.insert(tableEventFriends, key, k, fID); err != nil {
+			return err
+		}
+	}
+
+	for k, lID := range e.LocationIDs {
+		if _, err := w.insert(tableEventLocations, key, k, lID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertReminder(w *writer, pos int, r *friend.Reminder) error {
+	_, err := w.insert(tableReminders,
+		pos, r.ID, r.Desc, r.DateExpr, r.Recurrence,
+		r.OffsetDirection, encodeDuration(r.Offset), encodeList(r.Tags), encodeTime(r.CreatedAt),
+	)
+
+	return err
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// decode reads table rows back into the journal
+func decode(ctx context.Context, q querier) (*journal.Journal, error) { //nolint:cyclop,gocognit
+	j := &journal.Journal{}
+	d := decoder{}
+
+	err := queryRows(ctx, q, tableTags, func(r row) {
+		j.Tags = append(j.Tags, tag.Tag{Name: d.str(r[2])})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	persons := make(map[int64]*friend.Person)
+
+	err = queryRows(ctx, q, tablePersons, func(r row) {
+		p := &friend.Person{
+			ID:                 d.str(r[2]),
+			Name:               d.str(r[3]),
+			Desc:               d.str(r[4]),
+			Nicknames:          d.list(r[5]),
+			Tags:               d.list(r[6]),
+			CreatedAt:          d.time(r[7]),
+			Activities:         d.int(r[8]),
+			Notes:              d.int(r[9]),
+			MostRecentActivity: d.time(r[10]),
+		}
+
+		persons[d.key(r[0])] = p
+		j.Friends = append(j.Friends, p)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	person := func(v any) *friend.Person {
+		p, ok := persons[d.key(v)]
+		if !ok {
+			d.fail(fmt.Errorf("row refers to unknown person %v", v))
+			return &friend.Person{}
+		}
+
+		return p
+	}
+
+	err = queryRows(ctx, q, tablePersonLocations, func(r row) {
+		p := person(r[0])
+		p.Locations = append(p.Locations, d.str(r[2]))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryRows(ctx, q, tableContacts, func(r row) {
+		p := person(r[0])
+		p.Contacts = append(p.Contacts, &friend.Contact{
+			ID:    d.str(r[2]),
+			Type:  friend.ContactType(d.str(r[3])),
+			Value: d.str(r[4]),
+			Tags:  d.list(r[5]),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryRows(ctx, q, tableDates, func(r row) {
+		p := person(r[0])
+		p.Dates = append(p.Dates, &friend.Date{
+			ID:       d.str(r[2]),
+			Calendar: d.str(r[3]),
+			DateExpr: d.str(r[4]),
+			Desc:     d.str(r[5]),
+			Tags:     d.list(r[6]),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryRows(ctx, q, tableDateReminders, func(r row) {
+		p := person(r[0])
+		i := d.int(r[1])
+
+		if i < 0 || i >= len(p.Dates) {
+			d.fail(fmt.Errorf("reminder refers to unknown date at position %d", i))
+			return
+		}
+
+		p.Dates[i].Reminders = append(p.Dates[i].Reminders, d.reminder(r[3:]))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryRows(ctx, q, tableWishlistItems, func(r row) {
+		p := person(r[0])
+		p.Wishlist = append(p.Wishlist, &friend.WishlistItem{
+			ID:        d.str(r[2]),
+			CreatedAt: d.time(r[3]),
+			Desc:      d.str(r[4]),
+			Link:      d.str(r[5]),
+			Price:     d.str(r[6]),
+			Tags:      d.list(r[7]),
+			Location:  d.list(r[8]),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryRows(ctx, q, tableLocations, func(r row) {
+		j.Locations = append(j.Locations, &friend.Location{
+			ID:                 d.str(r[2]),
+			Name:               d.str(r[3]),
+			Country:            d.str(r[4]),
+			Desc:               d.str(r[5]),
+			Aliases:            d.list(r[6]),
+			Tags:               d.list(r[7]),
+			Lat:                d.float(r[8]),
+			Lng:                d.float(r[9]),
+			CreatedAt:          d.time(r[10]),
+			Notes:              d.int(r[11]),
+			Activities:         d.int(r[12]),
+			MostRecentActivity: d.time(r[13]),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(map[int64]*friend.Event)
+
+	err = queryRows(ctx, q, tableEvents, func(r row) {
+		e := &friend.Event{
+			ID:   d.str(r[3]),
+			Type: friend.EventType(d.str(r[1])),
+			Date: d.time(r[4]),
+			Desc: d.str(r[5]),
+			Tags: d.list(r[6]),
+		}
+
+		events[d.key(r[0])] = e
+
+		if e.Type == friend.EventTypeNote {
+			j.Notes = append(j.Notes, e)
+		} else {
+			j.Activities = append(j.Activities, e)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event := func(v any) *friend.Event {
+		e, ok := events[d.key(v)]
+		if !ok {
+			d.fail(fmt.Errorf("row refers to unknown event %v", v))
+			return &friend.Event{}
+		}
+
+		return e
+	}
+
+	err = queryRows(ctx, q, tableEventFriends, func(r row) {
+		e := event(r[0])
+		e.FriendIDs = append(e.FriendIDs, d.str(r[2]))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryRows(ctx, q, tableEventLocations, func(r row) {
+		e := event(r[0])
+		e.LocationIDs = append(e.LocationIDs, d.str(r[2]))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryRows(ctx, q, tableReminders, func(r row) {
+		j.Reminders = append(j.Reminders, d.reminder(r[2:]))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return j, nil
+}
+
+func queryRows(ctx context.Context, q querier, t table, fn func(r row)) (err error) {
+	rows, err := q.QueryContext(ctx, t.selectQuery())
+	if err != nil {
+		return fmt.Errorf("failed to query %s: %w", t.name, err)
+	}
+
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close %s rows: %w", t.name, closeErr)
+		}
+	}()
+
+	for rows.Next() {
+		r := make(row, len(t.columns))
+		ptrs := make([]any, len(t.columns))
+
+		for i := range r {
+			ptrs[i] = &r[i]
+		}
+
+		if err := rows.Scan(ptrs...); err != nil {
+			return fmt.Errorf("failed to scan %s: %w", t.name, err)
+		}
+
+		fn(r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", t.name, err)
+	}
+
+	return nil
+}
+
+// decoder converts column values back into Go types remembering the first failure
+type decoder struct {
+	err error
+}
+
+func (d *decoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *decoder) str(v any) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case []byte:
+		return string(s)
+	default:
+		return ""
+	}
+}
+
+func (d *decoder) int(v any) int {
+	return int(d.key(v))
+}
+
+func (d *decoder) key(v any) int64 {
+	if i, ok := v.(int64); ok {
+		return i
+	}
+
+	return 0
+}
+
+func (d *decoder) float(v any) *float64 {
+	if f, ok := v.(float64); ok {
+		return &f
+	}
+
+	return nil
+}
+
+func (d *decoder) list(v any) []string {
+	if v == nil {
+		return nil
+	}
+
+	var l []string
+
+	if err := json.Unmarshal([]byte(d.str(v)), &l); err != nil {
+		d.fail(fmt.Errorf("failed to decode list: %w", err))
+	}
+
+	return l
+}
+
+func (d *decoder) time(v any) time.Time {
+	if v == nil {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, d.str(v))
+	if err != nil {
+		d.fail(fmt.Errorf("failed to decode time: %w", err))
+	}
+
+	return t
+}
+
+func (d *decoder) duration(v any) *time.Duration {
+	i, ok := v.(int64)
+	if !ok {
+		return nil
+	}
+
+	dur := time.Duration(i)
+
+	return &dur
+}
+
+// reminder decodes reminder columns starting from the reminder ID
+func (d *decoder) reminder(r row) *friend.Reminder {
+	return &friend.Reminder{
+		ID:              d.str(r[0]),
+		Desc:            d.str(r[1]),
+		DateExpr:        d.str(r[2]),
+		Recurrence:      d.str(r[3]),
+		OffsetDirection: d.str(r[4]),
+		Offset:          d.duration(r[5]),
+		Tags:            d.list(r[6]),
+		CreatedAt:       d.time(r[7]),
+	}
+}
+
+func encodeList(l []string) any {
+	if l == nil {
+		return nil
+	}
+
+	data, _ := json.Marshal(l)
+
+	return string(data)
+}
+
+func encodeTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
+
+func encodeFloat(f *float64) any {
+	if f == nil {
+		return nil
+	}
+
+	return *f
+}
+
+func encodeDuration(d *time.Duration) any {
+	if d == nil {
+		return nil
+	}
+
+	return int64(*d)
+}
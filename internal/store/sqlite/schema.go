@@ -0,0 +1,325 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"strings"
+)
+
+// schemaVersion is stored in the user_version pragma of the database
+const schemaVersion = 1
+
+// table describes how journal entities are laid out in the database.
+// Friends, locations, events, reminders and tags are keyed by internal row keys rather than their IDs,
+// so journals with duplicated IDs are stored as is. Their order is kept in position columns.
+// Contacts, dates, wishlist items and event references belong to rows of their entities.
+type table struct {
+	name string
+	// key tells that the first column is the row key assigned by the database
+	key     bool
+	columns []string
+	order   []string
+}
+
+var (
+	tableTags = table{
+		name:    "tags",
+		key:     true,
+		columns: []string{"key", "position", "name"},
+		order:   []string{"position"},
+	}
+	tablePersons = table{
+		name: "persons",
+		key:  true,
+		columns: []string{
+			"key", "position", "id", "name", "desc", "nicknames", "tags", "created_at",
+			"activities", "notes", "most_recent_activity",
+		},
+		order: []string{"position"},
+	}
+	tablePersonLocations = table{
+		name:    "person_locations",
+		columns: []string{"person", "position", "location_id"},
+		order:   []string{"person", "position"},
+	}
+	tableContacts = table{
+		name:    "contacts",
+		columns: []string{"person", "position", "id", "type", "value", "tags"},
+		order:   []string{"person", "position"},
+	}
+	tableDates = table{
+		name:    "dates",
+		columns: []string{"person", "position", "id", "calendar", "date_expr", "desc", "tags"},
+		order:   []string{"person", "position"},
+	}
+	tableDateReminders = table{
+		name: "date_reminders",
+		columns: []string{
+			"person", "date", "position", "id", "desc", "date_expr", "recurrence",
+			"offset_direction", "offset", "tags", "created_at",
+		},
+		order: []string{"person", "date", "position"},
+	}
+	tableWishlistItems = table{
+		name: "wishlist_items",
+		columns: []string{
+			"person", "position", "id", "created_at", "desc", "link", "price", "tags", "locations",
+		},
+		order: []string{"person", "position"},
+	}
+	tableLocations = table{
+		name: "locations",
+		key:  true,
+		columns: []string{
+			"key", "position", "id", "name", "country", "desc", "aliases", "tags", "lat", "lng",
+			"created_at", "notes", "activities", "most_recent_activity",
+		},
+		order: []string{"position"},
+	}
+	tableEvents = table{
+		name:    "events",
+		key:     true,
+		columns: []string{"key", "type", "position", "id", "date", "desc", "tags"},
+		order:   []string{"type", "position"},
+	}
+	tableEventFriends = table{
+		name:    "event_friends",
+		columns: []string{"event", "position", "friend_id"},
+		order:   []string{"event", "position"},
+	}
+	tableEventLocations = table{
+		name:    "event_locations",
+		columns: []string{"event", "position", "location_id"},
+		order:   []string{"event", "position"},
+	}
+	tableReminders = table{
+		name: "reminders",
+		key:  true,
+		columns: []string{
+			"key", "position", "id", "desc", "date_expr", "recurrence",
+			"offset_direction", "offset", "tags", "created_at",
+		},
+		order: []string{"position"},
+	}
+)
+
+var tables = []table{
+	tableTags,
+	tablePersons,
+	tablePersonLocations,
+	tableContacts,
+	tableDates,
+	tableDateReminders,
+	tableWishlistItems,
+	tableLocations,
+	tableEvents,
+	tableEventFriends,
+	tableEventLocations,
+	tableReminders,
+}
+
+// Lists (e.g. tags or nicknames) are kept as JSON arrays, timestamps as RFC3339 strings.
+// Rows that belong to friends and events are deleted along with them.
+// The revision is bumped by every write, so cached journals are told apart from changed ones.
+const schema = `
+CREATE TABLE IF NOT EXISTS revision (
+	id    INTEGER PRIMARY KEY CHECK (id = 0),
+	value INTEGER NOT NULL
+);
+
+INSERT OR IGNORE INTO revision (id, value) VALUES (0, 0);
+
+CREATE TABLE IF NOT EXISTS tags (
+	"key"    INTEGER PRIMARY KEY,
+	position INTEGER NOT NULL,
+	name     TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS tags_name ON tags (name);
+
+CREATE TABLE IF NOT EXISTS persons (
+	"key"                INTEGER PRIMARY KEY,
+	position             INTEGER NOT NULL,
+	id                   TEXT NOT NULL,
+	name                 TEXT NOT NULL,
+	"desc"               TEXT NOT NULL DEFAULT '',
+	nicknames            TEXT,
+	tags                 TEXT,
+	created_at           TEXT,
+	activities           INTEGER NOT NULL DEFAULT 0,
+	notes                INTEGER NOT NULL DEFAULT 0,
+	most_recent_activity TEXT
+);
+
+CREATE INDEX IF NOT EXISTS persons_id ON persons (id);
+CREATE INDEX IF NOT EXISTS persons_position ON persons (position);
+
+CREATE TABLE IF NOT EXISTS person_locations (
+	person      INTEGER NOT NULL REFERENCES persons ON DELETE CASCADE,
+	position    INTEGER NOT NULL,
+	location_id TEXT NOT NULL,
+	PRIMARY KEY (person, position)
+);
+
+CREATE TABLE IF NOT EXISTS contacts (
+	person   INTEGER NOT NULL REFERENCES persons ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	id       TEXT NOT NULL,
+	"type"   TEXT NOT NULL,
+	value    TEXT NOT NULL,
+	tags     TEXT,
+	PRIMARY KEY (person, position)
+);
+
+CREATE TABLE IF NOT EXISTS dates (
+	person    INTEGER NOT NULL REFERENCES persons ON DELETE CASCADE,
+	position  INTEGER NOT NULL,
+	id        TEXT NOT NULL,
+	calendar  TEXT NOT NULL DEFAULT '',
+	date_expr TEXT NOT NULL,
+	"desc"    TEXT NOT NULL DEFAULT '',
+	tags      TEXT,
+	PRIMARY KEY (person, position)
+);
+
+CREATE TABLE IF NOT EXISTS date_reminders (
+	person           INTEGER NOT NULL REFERENCES persons ON DELETE CASCADE,
+	"date"           INTEGER NOT NULL,
+	position         INTEGER NOT NULL,
+	id               TEXT NOT NULL,
+	"desc"           TEXT NOT NULL DEFAULT '',
+	date_expr        TEXT NOT NULL DEFAULT '',
+	recurrence       TEXT NOT NULL DEFAULT '',
+	offset_direction TEXT NOT NULL DEFAULT '',
+	"offset"         INTEGER,
+	tags             TEXT,
+	created_at       TEXT,
+	PRIMARY KEY (person, date, position)
+);
+
+CREATE TABLE IF NOT EXISTS wishlist_items (
+	person     INTEGER NOT NULL REFERENCES persons ON DELETE CASCADE,
+	position   INTEGER NOT NULL,
+	id         TEXT NOT NULL,
+	created_at TEXT,
+	"desc"     TEXT NOT NULL DEFAULT '',
+	link       TEXT NOT NULL DEFAULT '',
+	price      TEXT NOT NULL DEFAULT '',
+	tags       TEXT,
+	locations  TEXT,
+	PRIMARY KEY (person, position)
+);
+
+CREATE TABLE IF NOT EXISTS locations (
+	"key"                INTEGER PRIMARY KEY,
+	position             INTEGER NOT NULL,
+	id                   TEXT NOT NULL,
+	name                 TEXT NOT NULL,
+	country              TEXT NOT NULL DEFAULT '',
+	"desc"               TEXT NOT NULL DEFAULT '',
+	aliases              TEXT,
+	tags                 TEXT,
+	lat                  REAL,
+	lng                  REAL,
+	created_at           TEXT,
+	notes                INTEGER NOT NULL DEFAULT 0,
+	activities           INTEGER NOT NULL DEFAULT 0,
+	most_recent_activity TEXT
+);
+
+CREATE INDEX IF NOT EXISTS locations_id ON locations (id);
+CREATE INDEX IF NOT EXISTS locations_position ON locations (position);
+
+CREATE TABLE IF NOT EXISTS events (
+	"key"    INTEGER PRIMARY KEY,
+	"type"   TEXT NOT NULL,
+	position INTEGER NOT NULL,
+	id       TEXT NOT NULL,
+	"date"   TEXT,
+	"desc"   TEXT NOT NULL DEFAULT '',
+	tags     TEXT
+);
+
+CREATE INDEX IF NOT EXISTS events_id ON events (id);
+CREATE INDEX IF NOT EXISTS events_position ON events (type, position);
+CREATE INDEX IF NOT EXISTS events_date ON events (date);
+
+CREATE TABLE IF NOT EXISTS event_friends (
+	event     INTEGER NOT NULL REFERENCES events ON DELETE CASCADE,
+	position  INTEGER NOT NULL,
+	friend_id TEXT NOT NULL,
+	PRIMARY KEY (event, position)
+);
+
+CREATE INDEX IF NOT EXISTS event_friends_friend_id ON event_friends (friend_id);
+
+CREATE TABLE IF NOT EXISTS event_locations (
+	event       INTEGER NOT NULL REFERENCES events ON DELETE CASCADE,
+	position    INTEGER NOT NULL,
+	location_id TEXT NOT NULL,
+	PRIMARY KEY (event, position)
+);
+
+CREATE INDEX IF NOT EXISTS event_locations_location_id ON event_locations (location_id);
+
+CREATE TABLE IF NOT EXISTS reminders (
+	"key"            INTEGER PRIMARY KEY,
+	position         INTEGER NOT NULL,
+	id               TEXT NOT NULL,
+	"desc"           TEXT NOT NULL DEFAULT '',
+	date_expr        TEXT NOT NULL DEFAULT '',
+	recurrence       TEXT NOT NULL DEFAULT '',
+	offset_direction TEXT NOT NULL DEFAULT '',
+	"offset"         INTEGER,
+	tags             TEXT,
+	created_at       TEXT
+);
+
+CREATE INDEX IF NOT EXISTS reminders_id ON reminders (id);
+CREATE INDEX IF NOT EXISTS reminders_position ON reminders (position);
+`
+
+func (t table) selectQuery() string {
+	return "SELECT " + quoteColumns(t.columns) +
+		" FROM " + t.name +
+		" ORDER BY " + quoteColumns(t.order)
+}
+
+// insertQuery leaves the row key out, so the database assigns it
+func (t table) insertQuery() string {
+	columns := t.columns
+
+	if t.key {
+		columns = columns[1:]
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+
+	return "INSERT INTO " + t.name + " (" + quoteColumns(columns) + ") VALUES (" + placeholders + ")"
+}
+
+func quote(c string) string {
+	return `"` + c + `"`
+}
+
+func quoteColumns(cs []string) string {
+	quoted := make([]string, 0, len(cs))
+
+	for _, c := range cs {
+		quoted = append(quoted, quote(c))
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,333 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/store"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+const FileName = "frens.db"
+
+// SQLiteStore keeps the journal in a local SQLite database.
+// Transactions write only entities changed through journal methods, so large journals stay fast to update.
+// Journals changed some other way have to be marked dirty as a whole to be written entirely.
+type SQLiteStore struct {
+	dir         string
+	fuzzyLookup bool
+
+	mu     sync.Mutex
+	openMu sync.Mutex
+	db     *sql.DB
+
+	// journal is kept between transactions until another process changes the database
+	journal  *journal.Journal
+	revision int64
+}
+
+var _ store.Store = (*SQLiteStore)(nil)
+
+type Option func(s *SQLiteStore)
+
+// WithFuzzyLookup lets loaded journals look friends and locations up by names with typos
+func WithFuzzyLookup() Option {
+	return func(s *SQLiteStore) {
+		s.fuzzyLookup = true
+	}
+}
+
+func NewSQLiteStore(dir string, opts ...Option) *SQLiteStore {
+	s := &SQLiteStore{
+		dir: dir,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *SQLiteStore) Path() string {
+	return s.dir
+}
+
+func (s *SQLiteStore) dbPath() string {
+	return filepath.Join(s.dir, FileName)
+}
+
+func (s *SQLiteStore) Init(ctx context.Context) error {
+	return s.Save(ctx, &journal.Journal{})
+}
+
+func (s *SQLiteStore) Exist(_ context.Context) bool {
+	_, err := os.Stat(s.dbPath())
+
+	return err == nil
+}
+
+func (s *SQLiteStore) Load(ctx context.Context) (*journal.Journal, error) {
+	db, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := decode(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load journal: %w", err)
+	}
+
+	s.init(j)
+
+	return j, nil
+}
+
+func (s *SQLiteStore) init(j *journal.Journal) {
+	j.Init()
+
+	if s.fuzzyLookup {
+		j.EnableFuzzyLookup()
+	}
+}
+
+// Save replaces the stored journal with the given one
+func (s *SQLiteStore) Save(ctx context.Context, j *journal.Journal) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	db, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.journal = nil
+
+	return withTx(ctx, db, func(tx *sql.Tx) error {
+		w := newWriter(ctx, tx)
+
+		if err := errors.Join(writeAll(w, j), w.Close()); err != nil {
+			return fmt.Errorf("failed to save journal: %w", err)
+		}
+
+		_, err := bumpRevision(ctx, tx)
+
+		return err
+	})
+}
+
+func (s *SQLiteStore) Tx(ctx context.Context, fn store.JournalUpdater) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	db, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		j        *journal.Journal
+		revision int64
+	)
+
+	err = withTx(ctx, db, func(tx *sql.Tx) error {
+		j, revision, err = s.cached(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(j); err != nil {
+			return fmt.Errorf("failed to execute transaction function: %w", err)
+		}
+
+		if !j.IsDirty() {
+			return nil
+		}
+
+		w := newWriter(ctx, tx)
+
+		if touched, ok := j.Touched(); ok {
+			err = writeTouched(w, j, touched)
+		} else {
+			err = writeAll(w, j)
+		}
+
+		if err := errors.Join(err, w.Close()); err != nil {
+			return fmt.Errorf("failed to save journal: %w", err)
+		}
+
+		revision, err = bumpRevision(ctx, tx)
+
+		return err
+	})
+	if err != nil {
+		// the journal may have been changed by the transaction, so it's loaded again next time
+		s.journal = nil
+
+		return err
+	}
+
+	j.SetDirty(false)
+
+	s.journal, s.revision = j, revision
+
+	return nil
+}
+
+// cached returns the journal kept from the previous transaction unless the database has changed since then
+func (s *SQLiteStore) cached(ctx context.Context, tx *sql.Tx) (*journal.Journal, int64, error) {
+	var revision int64
+
+	if err := tx.QueryRowContext(ctx, "SELECT value FROM revision").Scan(&revision); err != nil {
+		return nil, 0, fmt.Errorf("failed to read journal revision: %w", err)
+	}
+
+	if s.journal != nil && s.revision == revision {
+		return s.journal, revision, nil
+	}
+
+	j, err := decode(ctx, tx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load journal: %w", err)
+	}
+
+	s.init(j)
+
+	return j, revision, nil
+}
+
+func bumpRevision(ctx context.Context, tx *sql.Tx) (int64, error) {
+	var revision int64
+
+	err := tx.QueryRowContext(ctx, "UPDATE revision SET value = value + 1 RETURNING value").Scan(&revision)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update journal revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// Close releases the database connection
+func (s *SQLiteStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.journal = nil
+
+	s.openMu.Lock()
+	defer s.openMu.Unlock()
+
+	if s.db == nil {
+		return nil
+	}
+
+	err := s.db.Close()
+	s.db = nil
+
+	return err
+}
+
+// conn lazily opens the database and brings its schema up to date
+func (s *SQLiteStore) conn(ctx context.Context) (*sql.DB, error) {
+	s.openMu.Lock()
+	defer s.openMu.Unlock()
+
+	if s.db != nil {
+		return s.db, nil
+	}
+
+	// immediate transactions take the write lock upfront, so concurrent processes wait for each other
+	// instead of failing on lock upgrades
+	dsn := "file:" + s.dbPath() + "?_txlock=immediate&_pragma=busy_timeout(10000)&_pragma=foreign_keys(1)"
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal database at %s: %w", s.dbPath(), err)
+	}
+
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(ctx, db); err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("failed to prepare journal database at %s: %w", s.dbPath(), err)
+	}
+
+	s.db = db
+
+	return db, nil
+}
+
+func migrate(ctx context.Context, db *sql.DB) error {
+	var version int
+
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if version > schemaVersion {
+		return fmt.Errorf(
+			"database schema version %d is newer than supported %d, please upgrade frens",
+			version,
+			schemaVersion,
+		)
+	}
+
+	if version == schemaVersion {
+		return nil
+	}
+
+	return withTx(ctx, db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, schema); err != nil {
+			return fmt.Errorf("failed to create schema: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", schemaVersion)); err != nil {
+			return fmt.Errorf("failed to set schema version: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			return errors.Join(err, rbErr)
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,394 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/tag"
+	"github.com/stretchr/testify/require"
+)
+
+var errTxFailed = errors.New("transaction failed")
+
+func newJournal() *journal.Journal {
+	createdAt := time.Date(2025, time.May, 13, 10, 30, 0, 123, time.UTC)
+	lat, lng := 50.45, 30.52
+	offset := 72 * time.Hour
+
+	return &journal.Journal{
+		Tags: tag.Tags{{Name: "friends"}, {Name: "coffee"}},
+		Friends: []*friend.Person{
+			{
+				ID:        "john_doe",
+				Name:      "John Doe",
+				Desc:      "A good friend",
+				Nicknames: []string{"Johnny", "JD"},
+				Tags:      []string{"friends"},
+				Locations: []string{"kyiv"},
+				Contacts: []*friend.Contact{
+					{ID: "c1", Type: friend.ContactTypeEmail, Value: "john@example.com", Tags: []string{"work"}},
+				},
+				Dates: []*friend.Date{
+					{
+						ID:       "d1",
+						Calendar: friend.CalendarGregorian,
+						DateExpr: "1990-10-20",
+						Desc:     "birthday",
+						Tags:     []string{},
+						Reminders: []*friend.Reminder{
+							{
+								ID:              "r1",
+								Desc:            "buy a gift",
+								Recurrence:      friend.RecurrenceYearly,
+								OffsetDirection: friend.OffsetDirectionBefore,
+								Offset:          &offset,
+								CreatedAt:       createdAt,
+							},
+						},
+					},
+				},
+				Wishlist: []*friend.WishlistItem{
+					{ID: "w1", CreatedAt: createdAt, Desc: "keyboard", Link: "https://example.com", Price: "100USD"},
+				},
+				CreatedAt:          createdAt,
+				Activities:         1,
+				Notes:              1,
+				MostRecentActivity: createdAt,
+			},
+			// friends with duplicated IDs should be kept as is
+			{ID: "john_doe", Name: "John Doe", CreatedAt: createdAt},
+		},
+		Locations: friend.Locations{
+			{
+				ID:         "kyiv",
+				Name:       "Kyiv",
+				Country:    "Ukraine",
+				Aliases:    []string{"Kiev"},
+				Lat:        &lat,
+				Lng:        &lng,
+				CreatedAt:  createdAt,
+				Activities: 1,
+			},
+		},
+		Activities: []*friend.Event{
+			{
+				ID:          "a1",
+				Type:        friend.EventTypeActivity,
+				Date:        createdAt,
+				Desc:        "Had coffee with John in Kyiv #coffee",
+				FriendIDs:   []string{"john_doe"},
+				LocationIDs: []string{"kyiv"},
+				Tags:        []string{"coffee"},
+			},
+		},
+		Notes: []*friend.Event{
+			{ID: "n1", Type: friend.EventTypeNote, Date: createdAt, Desc: "John likes tea", FriendIDs: []string{"john_doe"}},
+		},
+		Reminders: []*friend.Reminder{
+			{ID: "r2", Desc: "renew passport", DateExpr: "2026-12-01", Recurrence: friend.RecurrenceOnce},
+		},
+	}
+}
+
+func requireSameJournal(t *testing.T, expected, actual *journal.Journal) {
+	t.Helper()
+
+	require.Equal(t, expected.Tags, actual.Tags)
+	require.Equal(t, expected.Friends, actual.Friends)
+	require.Equal(t, expected.Locations, actual.Locations)
+	require.Equal(t, expected.Activities, actual.Activities)
+	require.Equal(t, expected.Notes, actual.Notes)
+	require.Equal(t, expected.Reminders, actual.Reminders)
+}
+
+func TestSQLiteStore_SaveLoad(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	s := NewSQLiteStore(t.TempDir())
+
+	t.Cleanup(func() { _ = s.Close() })
+
+	require.False(t, s.Exist(ctx))
+	require.NoError(t, s.Init(ctx))
+	require.True(t, s.Exist(ctx))
+
+	j := newJournal()
+
+	require.NoError(t, s.Save(ctx, j))
+
+	loaded, err := s.Load(ctx)
+	require.NoError(t, err)
+
+	requireSameJournal(t, j, loaded)
+}
+
+func TestSQLiteStore_Tx(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	s := NewSQLiteStore(t.TempDir())
+
+	t.Cleanup(func() { _ = s.Close() })
+
+	require.NoError(t, s.Init(ctx))
+	require.NoError(t, s.Save(ctx, newJournal()))
+
+	err := s.Tx(ctx, func(j *journal.Journal) error {
+		j.AddFriend(friend.Person{Name: "Jane Smith"})
+		j.RemoveEvents(friend.EventTypeActivity, []friend.Event{{ID: "a1"}})
+
+		return j.RemoveFriendContacts([]friend.Contact{{ID: "c1"}})
+	})
+	require.NoError(t, err)
+
+	loaded, err := s.Load(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, loaded.Friends, 3)
+	require.Equal(t, "jane-smith", loaded.Friends[2].ID)
+	require.Empty(t, loaded.Friends[0].Contacts)
+	require.Len(t, loaded.Friends[0].Dates, 1)
+	require.Empty(t, loaded.Activities)
+	require.Len(t, loaded.Notes, 1)
+}
+
+func TestSQLiteStore_TxRollback(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	s := NewSQLiteStore(t.TempDir())
+
+	t.Cleanup(func() { _ = s.Close() })
+
+	require.NoError(t, s.Init(ctx))
+	require.NoError(t, s.Save(ctx, newJournal()))
+
+	err := s.Tx(ctx, func(j *journal.Journal) error {
+		j.AddFriend(friend.Person{Name: "Jane Smith"})
+
+		return errTxFailed
+	})
+	require.ErrorIs(t, err, errTxFailed)
+
+	loaded, err := s.Load(ctx)
+	require.NoError(t, err)
+
+	requireSameJournal(t, newJournal(), loaded)
+}
+
+// requireStored checks that the stored journal is the same as the one in memory.
+// Entities are compared in the form they are saved in, so empty lists are the same as missing ones.
+func requireStored(t *testing.T, s *SQLiteStore, expected *journal.Journal) {
+	t.Helper()
+
+	loaded, err := s.Load(t.Context())
+	require.NoError(t, err)
+
+	require.Empty(t, expected.Snapshot().Changes(loaded.Snapshot()))
+
+	ids := func(j *journal.Journal) []string {
+		var ids []string
+
+		for _, f := range j.Friends {
+			ids = append(ids, f.ID)
+		}
+
+		for _, e := range append(j.Activities, j.Notes...) {
+			ids = append(ids, e.ID)
+		}
+
+		return ids
+	}
+
+	require.Equal(t, ids(expected), ids(loaded))
+}
+
+func TestSQLiteStore_TxWritesTouchedEntities(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	s := NewSQLiteStore(t.TempDir())
+
+	t.Cleanup(func() { _ = s.Close() })
+
+	require.NoError(t, s.Init(ctx))
+	require.NoError(t, s.Save(ctx, newJournal()))
+
+	db, err := s.conn(ctx)
+	require.NoError(t, err)
+
+	locationDesc := func() string {
+		var desc string
+
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT "desc" FROM locations WHERE id = 'kyiv'`).Scan(&desc))
+
+		return desc
+	}
+
+	var expected *journal.Journal
+
+	err = s.Tx(ctx, func(j *journal.Journal) error {
+		createdAt := time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC)
+		jane := friend.Person{ID: "jane", Name: "Jane Smith", CreatedAt: createdAt}
+
+		j.AddFriend(jane)
+		j.UpdateFriend(jane, friend.Person{ID: "jane_smith", Name: "Jane Smith"})
+		j.RemoveFriends([]friend.Person{{ID: "john_doe"}}, journal.RemoveModeDetach)
+		j.AddFriend(friend.Person{ID: "bob", Name: "Bob", CreatedAt: createdAt})
+
+		expected = j
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	requireStored(t, s, expected)
+
+	// only the first of friends with duplicated IDs is removed
+	require.Len(t, expected.Friends, 3)
+	require.Equal(t, "john_doe", expected.Friends[0].ID)
+	require.Equal(t, "bob", expected.Friends[2].ID)
+
+	// rows of untouched entities are not written again, so their changes made behind the store stay
+	_, err = db.ExecContext(ctx, `UPDATE locations SET "desc" = 'changed' WHERE id = 'kyiv'`)
+	require.NoError(t, err)
+
+	err = s.Tx(ctx, func(j *journal.Journal) error {
+		j.RemoveFriends([]friend.Person{{ID: "bob"}}, journal.RemoveModeDetach)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "changed", locationDesc())
+
+	err = s.Tx(ctx, func(j *journal.Journal) error {
+		_, err := j.AddEvent(friend.Event{Type: friend.EventTypeNote, Desc: "Jane is back from Kyiv #travel"})
+
+		return err
+	})
+	require.NoError(t, err)
+
+	requireStored(t, s, expected)
+}
+
+func TestSQLiteStore_TxWritesJournalsMarkedDirty(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	s := NewSQLiteStore(t.TempDir())
+
+	t.Cleanup(func() { _ = s.Close() })
+
+	require.NoError(t, s.Init(ctx))
+	require.NoError(t, s.Save(ctx, newJournal()))
+
+	var expected *journal.Journal
+
+	err := s.Tx(ctx, func(j *journal.Journal) error {
+		j.Friends[0].Contacts = nil
+		j.Activities = nil
+		j.SetDirty(true)
+
+		expected = j
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	requireStored(t, s, expected)
+}
+
+func TestSQLiteStore_TxSeesChangesOfOtherStores(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	s1, s2 := NewSQLiteStore(dir), NewSQLiteStore(dir)
+
+	t.Cleanup(func() { _ = errors.Join(s1.Close(), s2.Close()) })
+
+	require.NoError(t, s1.Init(ctx))
+
+	addFriend := func(s *SQLiteStore, name string) {
+		require.NoError(t, s.Tx(ctx, func(j *journal.Journal) error {
+			j.AddFriend(friend.Person{Name: name})
+
+			return nil
+		}))
+	}
+
+	addFriend(s1, "Jim")
+	addFriend(s2, "Pam")
+	addFriend(s1, "Dwight")
+
+	loaded, err := s2.Load(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, loaded.Friends, 3)
+	require.Equal(t, "pam", loaded.Friends[1].ID)
+}
+
+func TestSQLiteStore_TxKeepsOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	s := NewSQLiteStore(t.TempDir())
+
+	t.Cleanup(func() { _ = s.Close() })
+
+	require.NoError(t, s.Init(ctx))
+
+	tx := func(fn func(j *journal.Journal)) *journal.Journal {
+		var updated *journal.Journal
+
+		require.NoError(t, s.Tx(ctx, func(j *journal.Journal) error {
+			fn(j)
+
+			updated = j
+
+			return nil
+		}))
+
+		return updated
+	}
+
+	tx(func(j *journal.Journal) {
+		for _, name := range []string{"Angela", "Bob", "Creed", "Dwight"} {
+			j.AddFriend(friend.Person{Name: name, CreatedAt: time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)})
+		}
+	})
+
+	j := tx(func(j *journal.Journal) {
+		j.RemoveFriends([]friend.Person{{ID: "angela"}, {ID: "bob"}}, journal.RemoveModeDetach)
+		j.AddFriend(friend.Person{Name: "Erin", CreatedAt: time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)})
+	})
+
+	requireStored(t, s, j)
+
+	j = tx(func(j *journal.Journal) {
+		j.UpdateFriend(*j.Friends[1], friend.Person{ID: "dwight_schrute", Name: "Dwight Schrute"})
+	})
+
+	requireStored(t, s, j)
+	require.Equal(t, "dwight_schrute", j.Friends[1].ID)
+}
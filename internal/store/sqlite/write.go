@@ -0,0 +1,296 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+)
+
+// writer runs statements of a transaction reusing the prepared ones
+type writer struct {
+	ctx   context.Context
+	tx    *sql.Tx
+	stmts map[string]*sql.Stmt
+}
+
+func newWriter(ctx context.Context, tx *sql.Tx) *writer {
+	return &writer{ctx: ctx, tx: tx, stmts: make(map[string]*sql.Stmt)}
+}
+
+func (w *writer) exec(query string, args ...any) (sql.Result, error) {
+	stmt, ok := w.stmts[query]
+	if !ok {
+		var err error
+
+		stmt, err = w.tx.PrepareContext(w.ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare statement: %w", err)
+		}
+
+		w.stmts[query] = stmt
+	}
+
+	return stmt.ExecContext(w.ctx, args...)
+}
+
+// insert adds a row and returns its key
+func (w *writer) insert(t table, values ...any) (int64, error) {
+	res, err := w.exec(t.insertQuery(), values...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to write into %s: %w", t.name, err)
+	}
+
+	return res.LastInsertId()
+}
+
+func (w *writer) Close() error {
+	var errs []error
+
+	for _, stmt := range w.stmts {
+		errs = append(errs, stmt.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// collection maps a journal list onto its table
+type collection struct {
+	entity   string
+	table    table
+	idColumn string
+	// eventType tells activities and notes kept in the same table apart
+	eventType friend.EventType
+
+	size   func(j *journal.Journal) int
+	id     func(j *journal.Journal, i int) string
+	insert func(w *writer, j *journal.Journal, i int) error
+}
+
+var collections = []collection{
+	{
+		entity:   journal.EntityTag,
+		table:    tableTags,
+		idColumn: "name",
+		size:     func(j *journal.Journal) int { return len(j.Tags) },
+		id:       func(j *journal.Journal, i int) string { return j.Tags[i].Name },
+		insert:   func(w *writer, j *journal.Journal, i int) error { return insertTag(w, i, j.Tags[i]) },
+	},
+	{
+		entity:   journal.EntityFriend,
+		table:    tablePersons,
+		idColumn: "id",
+		size:     func(j *journal.Journal) int { return len(j.Friends) },
+		id:       func(j *journal.Journal, i int) string { return j.Friends[i].ID },
+		insert:   func(w *writer, j *journal.Journal, i int) error { return insertPerson(w, i, j.Friends[i]) },
+	},
+	{
+		entity:   journal.EntityLocation,
+		table:    tableLocations,
+		idColumn: "id",
+		size:     func(j *journal.Journal) int { return len(j.Locations) },
+		id:       func(j *journal.Journal, i int) string { return j.Locations[i].ID },
+		insert:   func(w *writer, j *journal.Journal, i int) error { return insertLocation(w, i, j.Locations[i]) },
+	},
+	eventCollection(journal.EntityActivity, friend.EventTypeActivity, func(j *journal.Journal) []*friend.Event {
+		return j.Activities
+	}),
+	eventCollection(journal.EntityNote, friend.EventTypeNote, func(j *journal.Journal) []*friend.Event {
+		return j.Notes
+	}),
+	{
+		entity:   journal.EntityReminder,
+		table:    tableReminders,
+		idColumn: "id",
+		size:     func(j *journal.Journal) int { return len(j.Reminders) },
+		id:       func(j *journal.Journal, i int) string { return j.Reminders[i].ID },
+		insert:   func(w *writer, j *journal.Journal, i int) error { return insertReminder(w, i, j.Reminders[i]) },
+	},
+}
+
+func eventCollection(
+	entity string,
+	eType friend.EventType,
+	events func(j *journal.Journal) []*friend.Event,
+) collection {
+	return collection{
+		entity:    entity,
+		table:     tableEvents,
+		idColumn:  "id",
+		eventType: eType,
+		size:      func(j *journal.Journal) int { return len(events(j)) },
+		id:        func(j *journal.Journal, i int) string { return events(j)[i].ID },
+		insert: func(w *writer, j *journal.Journal, i int) error {
+			return insertEvent(w, eType, i, events(j)[i])
+		},
+	}
+}
+
+// where narrows the condition down to the collection rows
+func (c collection) where(cond string, args ...any) (string, []any) {
+	if c.eventType == "" {
+		return " WHERE " + cond, args
+	}
+
+	return " WHERE " + cond + ` AND "type" = ?`, append(args, string(c.eventType))
+}
+
+// write replaces rows of the entities with the given IDs by their current state.
+// Positions of other rows are moved only if the entities were added, removed or have changed their IDs.
+func (c collection) write(w *writer, j *journal.Journal, ids map[string]struct{}) error {
+	var stored []int
+
+	for id := range ids {
+		positions, err := c.remove(w, id)
+		if err != nil {
+			return err
+		}
+
+		stored = append(stored, positions...)
+	}
+
+	var current []int
+
+	for i := range c.size(j) {
+		if _, ok := ids[c.id(j, i)]; ok {
+			current = append(current, i)
+		}
+	}
+
+	slices.Sort(stored)
+
+	shift := !slices.Equal(stored, current)
+
+	if shift {
+		for _, pos := range slices.Backward(stored) {
+			if err := c.shift(w, pos, -1); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, i := range current {
+		if shift {
+			if err := c.shift(w, i-1, 1); err != nil {
+				return err
+			}
+		}
+
+		if err := c.insert(w, j, i); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// remove deletes rows of the entity (and rows that belong to them) returning their positions
+func (c collection) remove(w *writer, id string) ([]int, error) {
+	cond, args := c.where(quote(c.idColumn)+" = ?", id)
+
+	rows, err := w.tx.QueryContext(w.ctx, `SELECT "key", position FROM `+c.table.name+cond, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", c.table.name, err)
+	}
+
+	var keys, positions []int
+
+	for rows.Next() {
+		var key, pos int
+
+		if err := rows.Scan(&key, &pos); err != nil {
+			_ = rows.Close()
+
+			return nil, fmt.Errorf("failed to scan %s: %w", c.table.name, err)
+		}
+
+		keys = append(keys, key)
+		positions = append(positions, pos)
+	}
+
+	if err := errors.Join(rows.Err(), rows.Close()); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", c.table.name, err)
+	}
+
+	for _, key := range keys {
+		if _, err := w.exec(`DELETE FROM `+c.table.name+` WHERE "key" = ?`, key); err != nil {
+			return nil, fmt.Errorf("failed to delete from %s: %w", c.table.name, err)
+		}
+	}
+
+	return positions, nil
+}
+
+// shift moves rows placed after the position by the delta
+func (c collection) shift(w *writer, after, delta int) error {
+	cond, args := c.where("position > ?", after)
+	query := `UPDATE ` + c.table.name + ` SET position = position + ?` + cond
+
+	if _, err := w.exec(query, append([]any{delta}, args...)...); err != nil {
+		return fmt.Errorf("failed to move %s: %w", c.table.name, err)
+	}
+
+	return nil
+}
+
+// writeAll replaces all journal rows
+func writeAll(w *writer, j *journal.Journal) error {
+	for _, t := range tables {
+		if _, err := w.exec("DELETE FROM " + t.name); err != nil {
+			return fmt.Errorf("failed to clean up %s: %w", t.name, err)
+		}
+	}
+
+	for _, c := range collections {
+		for i := range c.size(j) {
+			if err := c.insert(w, j, i); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeTouched replaces rows of the given entities only
+func writeTouched(w *writer, j *journal.Journal, touched []journal.EntityRef) error {
+	ids := make(map[string]map[string]struct{})
+
+	for _, ref := range touched {
+		if ids[ref.Entity] == nil {
+			ids[ref.Entity] = make(map[string]struct{})
+		}
+
+		ids[ref.Entity][ref.ID] = struct{}{}
+	}
+
+	for _, c := range collections {
+		if len(ids[c.entity]) == 0 {
+			continue
+		}
+
+		if err := c.write(w, j, ids[c.entity]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -16,10 +16,18 @@ package store
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/roma-glushko/frens/internal/journal"
 )
 
+var (
+	ErrUndoUnsupported = errors.New("the journal store doesn't keep transactions to undo")
+	ErrNothingToUndo   = errors.New("nothing to undo")
+	ErrNothingToRedo   = errors.New("nothing to redo")
+)
+
 type JournalUpdater = func(j *journal.Journal) error
 
 type Store interface {
@@ -30,3 +38,45 @@ type Store interface {
 	Tx(ctx context.Context, fn JournalUpdater) error
 	Path() string
 }
+
+// Locker is a store that holds its journal for other writers of the journal directory too (e.g. git pulls)
+type Locker interface {
+	Locked(ctx context.Context, fn func() error) error
+}
+
+// Locked runs fn holding the journal, if the store can lock it
+func Locked(ctx context.Context, s Store, fn func() error) error {
+	if l, ok := s.(Locker); ok {
+		return l.Locked(ctx, fn)
+	}
+
+	return fn()
+}
+
+// SaveHook is called right after the store saves a change of the journal, while the journal is still held.
+// The action is empty for transactions, "undo" or "redo" for operations undone or redone.
+type SaveHook func(ctx context.Context, action string, changes []journal.EntityChange)
+
+// Notifier is a store that calls the hook after every save
+type Notifier interface {
+	OnSave(hook SaveHook)
+}
+
+// Operation is a transaction kept by the store to be undone
+type Operation struct {
+	ID      int                    `json:"id"`
+	At      time.Time              `json:"at"`
+	Changes []journal.EntityChange `json:"changes"`
+	// Undone operations can be redone until a new transaction is made
+	Undone bool `json:"undone,omitempty"`
+	// Conflicts are changes made since the operation that were kept while undoing or redoing it
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+// Undoer is a store that reverts its transactions and applies them again
+type Undoer interface {
+	Undo(ctx context.Context) (Operation, error)
+	Redo(ctx context.Context) (Operation, error)
+	// Operations lists kept transactions, oldest first
+	Operations(ctx context.Context) ([]Operation, error)
+}
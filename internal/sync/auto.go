@@ -0,0 +1,438 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	gosync "sync"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/log"
+	"github.com/roma-glushko/frens/internal/store"
+)
+
+const (
+	// remoteTimeout keeps an unreachable remote from holding the journal
+	remoteTimeout = 30 * time.Second
+	// maxPushDelays bounds how many push delays steady changes can postpone pushes for
+	maxPushDelays = 10
+
+	pendingChangesMessage = "journal updated"
+)
+
+// AutoSync is the store that commits every change made by journal transactions.
+// Remote changes are pulled before the journal is read for the first time.
+// Commits are pushed when flushed or, in the background mode, once no changes come for the push delay.
+type AutoSync struct {
+	store.Store
+
+	repo   Repository
+	origin string
+
+	txMu     gosync.Mutex // keeps transactions apart from commits and pulls of other ones
+	pullOnce gosync.Once
+	disabled bool
+	// hooked stores commit changes while they still hold the journal
+	hooked bool
+
+	pushMu     gosync.Mutex
+	pushDelay  time.Duration
+	background bool
+	timer      *time.Timer
+	unpushed   time.Time // when the first commit that isn't pushed yet was made
+}
+
+var (
+	_ store.Store  = (*AutoSync)(nil)
+	_ store.Undoer = (*AutoSync)(nil)
+	_ store.Locker = (*AutoSync)(nil)
+)
+
+// NewAutoSync commits changes of the store to the repository and pushes them to its origin
+func NewAutoSync(s store.Store, repo Repository, pushDelay time.Duration) *AutoSync {
+	a := &AutoSync{
+		Store:     s,
+		repo:      repo,
+		origin:    "origin",
+		pushDelay: pushDelay,
+	}
+
+	if n, ok := s.(store.Notifier); ok {
+		n.OnSave(a.saved)
+		a.hooked = true
+	}
+
+	return a
+}
+
+// Load pulls remote changes before reading the journal for the first time
+func (s *AutoSync) Load(ctx context.Context) (*journal.Journal, error) {
+	s.Pull(ctx)
+
+	return s.Store.Load(ctx)
+}
+
+// Tx commits the change made by the transaction with the message describing it (e.g. "activity added: Dinner")
+func (s *AutoSync) Tx(ctx context.Context, fn store.JournalUpdater) error {
+	s.Pull(ctx)
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	if s.hooked {
+		return s.Store.Tx(ctx, fn)
+	}
+
+	// stores without save hooks get their changes committed right after transactions
+
+	var changes []journal.EntityChange
+
+	changed := false
+
+	err := s.Store.Tx(ctx, func(j *journal.Journal) error {
+		before := j.Snapshot()
+
+		if err := fn(j); err != nil {
+			return err
+		}
+
+		if j.IsDirty() {
+			changed = true
+			changes = before.Changes(j.Snapshot())
+		}
+
+		return nil
+	})
+
+	if err != nil || !changed || s.disabled {
+		return err
+	}
+
+	// the change is saved anyway, so the next commit picks it up if this one fails
+	if err := s.lockedCommit(ctx, commitMessage(changes)); err != nil {
+		log.Warnf("Failed to commit the journal change: %v", err)
+		return nil
+	}
+
+	s.schedulePush()
+
+	return nil
+}
+
+// Undo reverts the last transaction of the store and commits that
+func (s *AutoSync) Undo(ctx context.Context) (store.Operation, error) {
+	return s.step(ctx, "undo", store.Undoer.Undo)
+}
+
+// Redo applies the last undone transaction of the store again and commits that
+func (s *AutoSync) Redo(ctx context.Context) (store.Operation, error) {
+	return s.step(ctx, "redo", store.Undoer.Redo)
+}
+
+// Operations lists transactions kept by the store to undo
+func (s *AutoSync) Operations(ctx context.Context) ([]store.Operation, error) {
+	u, ok := s.Store.(store.Undoer)
+	if !ok {
+		return nil, store.ErrUndoUnsupported
+	}
+
+	return u.Operations(ctx)
+}
+
+func (s *AutoSync) step(
+	ctx context.Context,
+	action string,
+	fn func(u store.Undoer, ctx context.Context) (store.Operation, error),
+) (store.Operation, error) {
+	u, ok := s.Store.(store.Undoer)
+	if !ok {
+		return store.Operation{}, store.ErrUndoUnsupported
+	}
+
+	s.Pull(ctx)
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	op, err := fn(u, ctx)
+	if err != nil || s.disabled || s.hooked {
+		return op, err
+	}
+
+	if err := s.lockedCommit(ctx, action+": "+commitMessage(op.Changes)); err != nil {
+		log.Warnf("Failed to commit the journal change: %v", err)
+		return op, nil
+	}
+
+	s.schedulePush()
+
+	return op, nil
+}
+
+// saved commits the change right after the store saved it, before other processes can change the journal
+func (s *AutoSync) saved(ctx context.Context, action string, changes []journal.EntityChange) {
+	if s.disabled {
+		return
+	}
+
+	message := commitMessage(changes)
+
+	if action != "" {
+		message = action + ": " + message
+	}
+
+	// the change is saved anyway, so the next commit picks it up if this one fails
+	if err := s.commit(ctx, message); err != nil {
+		log.Warnf("Failed to commit the journal change: %v", err)
+		return
+	}
+
+	s.schedulePush()
+}
+
+// commitMessage puts the main change into the subject and lists all of them in the body
+func commitMessage(changes []journal.EntityChange) string {
+	if len(changes) == 0 {
+		return pendingChangesMessage
+	}
+
+	// updates often follow from additions or removals (e.g. activities counted for friends)
+	changes = slices.Clone(changes)
+	slices.SortStableFunc(changes, func(a, b journal.EntityChange) int {
+		return cmp.Compare(isUpdate(a), isUpdate(b))
+	})
+
+	if len(changes) == 1 {
+		return changes[0].String()
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s and %d more\n\n", changes[0], len(changes)-1)
+
+	for _, c := range changes {
+		sb.WriteString("- " + c.String() + "\n")
+	}
+
+	return sb.String()
+}
+
+func isUpdate(c journal.EntityChange) int {
+	if c.Kind == journal.ChangeUpdated {
+		return 1
+	}
+
+	return 0
+}
+
+// Locked runs fn holding the journal of the wrapped store
+func (s *AutoSync) Locked(ctx context.Context, fn func() error) error {
+	return store.Locked(ctx, s.Store, fn)
+}
+
+// lockedCommit commits the journal holding it, so changes of other processes are not committed half-written
+func (s *AutoSync) lockedCommit(ctx context.Context, message string) error {
+	return s.Locked(ctx, func() error {
+		return s.commit(ctx, message)
+	})
+}
+
+func (s *AutoSync) commit(ctx context.Context, message string) error {
+	status, err := s.repo.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	if status == "" {
+		return nil
+	}
+
+	_, unborn := s.repo.GetBranchName(ctx)
+
+	if err := s.repo.Commit(ctx, message); err != nil {
+		return err
+	}
+
+	if unborn != nil {
+		// the first commit starts the branch journals are synchronized through
+		return s.repo.Branch(ctx, DefaultBranch)
+	}
+
+	return nil
+}
+
+// Pull picks up remote changes once, before the journal is read for the first time.
+// Failures are reported as warnings, as the journal can still be used.
+func (s *AutoSync) Pull(ctx context.Context) {
+	s.pullOnce.Do(func() {
+		s.txMu.Lock()
+		defer s.txMu.Unlock()
+
+		if err := s.repo.Inited(); err != nil {
+			log.Warnf("Auto-sync is off until the journal is connected to a git repository: %v", err)
+
+			s.disabled = true
+
+			return
+		}
+
+		// merges rewrite journal files, so other processes wait for them like for transactions
+		if err := s.Locked(ctx, func() error { return s.pull(ctx) }); err != nil {
+			log.Warnf("Failed to pull journal changes, run `frens journal sync` to resolve: %v", err)
+		}
+	})
+}
+
+func (s *AutoSync) pull(ctx context.Context) error {
+	branch, err := s.repo.GetBranchName(ctx)
+	if err != nil {
+		// nothing is committed yet
+		return nil //nolint:nilerr
+	}
+
+	// merges need local changes committed
+	if err := s.commit(ctx, pendingChangesMessage); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, remoteTimeout)
+	defer cancel()
+
+	if err := s.repo.Pull(ctx, s.origin, branch); err != nil {
+		// leave the journal as it was, instead of in the middle of resolving conflicts
+		if abortErr := s.repo.AbortMerge(context.WithoutCancel(ctx)); abortErr != nil {
+			return errors.Join(err, abortErr)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// PushInBackground makes commits get pushed once no changes come for the push delay (e.g. while serving the journal)
+func (s *AutoSync) PushInBackground() {
+	s.pushMu.Lock()
+	defer s.pushMu.Unlock()
+
+	s.background = true
+
+	if !s.unpushed.IsZero() {
+		s.resetTimer()
+	}
+}
+
+func (s *AutoSync) schedulePush() {
+	s.pushMu.Lock()
+	defer s.pushMu.Unlock()
+
+	if s.unpushed.IsZero() {
+		s.unpushed = time.Now()
+	}
+
+	if s.background {
+		s.resetTimer()
+	}
+}
+
+func (s *AutoSync) resetTimer() {
+	// steady changes postpone pushes for a while only
+	delay := min(s.pushDelay, time.Until(s.unpushed.Add(maxPushDelays*s.pushDelay)))
+
+	if s.timer != nil {
+		s.timer.Reset(delay)
+		return
+	}
+
+	s.timer = time.AfterFunc(delay, func() {
+		if err := s.Flush(context.Background()); err != nil {
+			log.Warnf("Failed to push journal changes: %v", err)
+		}
+	})
+}
+
+// Flush pushes commits that aren't pushed yet. Remote changes are pulled first if the remote has any.
+func (s *AutoSync) Flush(ctx context.Context) error {
+	s.pushMu.Lock()
+
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+
+	unpushed := s.unpushed
+	s.unpushed = time.Time{}
+
+	s.pushMu.Unlock()
+
+	if unpushed.IsZero() {
+		return nil
+	}
+
+	if err := s.push(ctx); err != nil {
+		s.pushMu.Lock()
+		defer s.pushMu.Unlock()
+
+		// try again with the next push
+		if s.unpushed.IsZero() {
+			s.unpushed = unpushed
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *AutoSync) push(ctx context.Context) error {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	branch, err := s.repo.GetBranchName(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, remoteTimeout)
+	defer cancel()
+
+	// the first push creates the remote branch, so there may be nothing to pull before it
+	if err := s.repo.Push(ctx, s.origin, branch); err == nil {
+		return nil
+	}
+
+	err = s.Locked(ctx, func() error {
+		if err := s.repo.Pull(ctx, s.origin, branch); err != nil {
+			if abortErr := s.repo.AbortMerge(context.WithoutCancel(ctx)); abortErr != nil {
+				return errors.Join(err, abortErr)
+			}
+
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Push(ctx, s.origin, branch)
+}
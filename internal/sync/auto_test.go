@@ -0,0 +1,230 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/stretchr/testify/require"
+)
+
+// journalIgnore keeps the journal lock out of commits, like connected journals do
+const journalIgnore = "frens.lock\n*.tmp\n"
+
+func headMessage(t *testing.T, repoPath string, ref plumbing.ReferenceName) string {
+	t.Helper()
+
+	repo, err := git.PlainOpen(repoPath)
+	require.NoError(t, err)
+
+	r, err := repo.Reference(ref, true)
+	require.NoError(t, err)
+
+	c, err := repo.CommitObject(r.Hash())
+	require.NoError(t, err)
+
+	return c.Message
+}
+
+func newAutoSync(t *testing.T, repo *GoGit, pushDelay time.Duration) *AutoSync {
+	t.Helper()
+
+	s := file.NewTOMLFileStore(repo.RepoPath)
+
+	if !s.Exist(t.Context()) {
+		require.NoError(t, s.Init(t.Context()))
+	}
+
+	return NewAutoSync(s, repo, pushDelay)
+}
+
+func TestAutoSync(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	remote, laptop := newRemote(t, map[string]string{"README.md": "journal\n", ignoreFile: journalIgnore})
+	laptopStore := newAutoSync(t, laptop, time.Hour)
+
+	err := laptopStore.Tx(ctx, func(j *journal.Journal) error {
+		j.AddFriend(friend.Person{ID: "jim", Name: "Jim Halpert"})
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "friend added: Jim Halpert", headMessage(t, laptop.RepoPath, plumbing.HEAD))
+
+	// transactions without changes don't commit
+	require.NoError(t, laptopStore.Tx(ctx, func(*journal.Journal) error { return nil }))
+	require.Equal(t, "friend added: Jim Halpert", headMessage(t, laptop.RepoPath, plumbing.HEAD))
+
+	err = laptopStore.Tx(ctx, func(j *journal.Journal) error {
+		j.AddFriend(friend.Person{ID: "pam", Name: "Pam Beesly"})
+		j.AddFriend(friend.Person{ID: "dwight", Name: "Dwight Schrute"})
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t,
+		"friend added: Pam Beesly and 1 more\n\n- friend added: Pam Beesly\n- friend added: Dwight Schrute\n",
+		headMessage(t, laptop.RepoPath, plumbing.HEAD),
+	)
+
+	// nothing is pushed until flushed
+	require.Equal(t, "init", headMessage(t, remote, plumbing.NewBranchReferenceName("main")))
+	require.NoError(t, laptopStore.Flush(ctx))
+	require.NoError(t, laptopStore.Flush(ctx))
+	require.Contains(t, headMessage(t, remote, plumbing.NewBranchReferenceName("main")), "Pam Beesly")
+
+	phone := cloneRemote(t, remote)
+	phoneStore := newAutoSync(t, phone, 10*time.Millisecond)
+	phoneStore.PushInBackground()
+
+	err = laptopStore.Tx(ctx, func(j *journal.Journal) error {
+		j.AddLocation(friend.Location{ID: "scranton", Name: "Scranton"})
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, laptopStore.Flush(ctx))
+
+	// the phone picks up the location before reading the journal
+	jr, err := phoneStore.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, jr.Friends, 3)
+	require.Len(t, jr.Locations, 1)
+
+	err = phoneStore.Tx(ctx, func(j *journal.Journal) error {
+		j.AddFriend(friend.Person{ID: "michael", Name: "Michael Scott"})
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return headMessage(t, remote, plumbing.NewBranchReferenceName("main")) == "friend added: Michael Scott"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAutoSync_NotConnected(t *testing.T) {
+	t.Parallel()
+
+	s := NewAutoSync(file.NewTOMLFileStore(t.TempDir()), NewGoGit(t.TempDir()), time.Second)
+	require.NoError(t, s.Init(t.Context()))
+
+	// the journal is still saved
+	err := s.Tx(t.Context(), func(j *journal.Journal) error {
+		j.AddFriend(friend.Person{ID: "jim", Name: "Jim Halpert"})
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Flush(t.Context()))
+
+	jr, err := s.Load(t.Context())
+	require.NoError(t, err)
+	require.Len(t, jr.Friends, 1)
+}
+
+func TestCommitMessage(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "journal updated", commitMessage(nil))
+
+	changes := []journal.EntityChange{
+		{Kind: journal.ChangeUpdated, Entity: "friend", ID: "jim", Label: "Jim Halpert"},
+		{Kind: journal.ChangeAdded, Entity: "activity", ID: "1", Label: "Dinner with Jim"},
+	}
+
+	require.Equal(t,
+		"activity added: Dinner with Jim and 1 more\n\n- activity added: Dinner with Jim\n- friend updated: Jim Halpert\n",
+		commitMessage(changes),
+	)
+}
+
+func TestAutoSync_Undo(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	_, laptop := newRemote(t, map[string]string{"README.md": "journal\n", ignoreFile: journalIgnore})
+	laptopStore := newAutoSync(t, laptop, time.Hour)
+
+	err := laptopStore.Tx(ctx, func(j *journal.Journal) error {
+		j.AddFriend(friend.Person{ID: "jim", Name: "Jim Halpert"})
+		return nil
+	})
+	require.NoError(t, err)
+
+	op, err := laptopStore.Undo(ctx)
+	require.NoError(t, err)
+	require.Len(t, op.Changes, 1)
+	require.Equal(t, "undo: friend added: Jim Halpert", headMessage(t, laptop.RepoPath, plumbing.HEAD))
+
+	_, err = laptopStore.Redo(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "redo: friend added: Jim Halpert", headMessage(t, laptop.RepoPath, plumbing.HEAD))
+
+	// operations kept to undo are local to the device
+	status, err := laptop.GetStatus(ctx)
+	require.NoError(t, err)
+	require.Empty(t, status)
+}
+
+// lockCheckingRepo records the journal lock commits are made under
+type lockCheckingRepo struct {
+	*GoGit
+
+	locks []string
+}
+
+func (r *lockCheckingRepo) Commit(ctx context.Context, message string) error {
+	lock, _ := os.ReadFile(filepath.Join(r.RepoPath, file.FileNameLock))
+	r.locks = append(r.locks, string(lock))
+
+	return r.GoGit.Commit(ctx, message)
+}
+
+func TestAutoSync_CommitsUnderTransactionLock(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	_, laptop := newRemote(t, map[string]string{"README.md": "journal\n", ignoreFile: journalIgnore})
+
+	s := file.NewTOMLFileStore(laptop.RepoPath)
+	require.NoError(t, s.Init(ctx))
+
+	repo := &lockCheckingRepo{GoGit: laptop}
+	laptopStore := NewAutoSync(s, repo, time.Hour)
+
+	var txLock []byte
+
+	err := laptopStore.Tx(ctx, func(j *journal.Journal) error {
+		var err error
+
+		txLock, err = os.ReadFile(filepath.Join(laptop.RepoPath, file.FileNameLock))
+		j.AddFriend(friend.Person{ID: "jim", Name: "Jim Halpert"})
+
+		return err
+	})
+	require.NoError(t, err)
+
+	// other processes can't save their changes between the transaction and its commit
+	require.NotEmpty(t, repo.locks)
+	require.Equal(t, string(txLock), repo.locks[len(repo.locks)-1])
+}
@@ -15,24 +15,50 @@
 package sync
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Git struct
 type Git struct {
 	RepoPath string
+
+	output io.Writer
 }
 
 // NewGit creates a new Git instance
-func NewGit(repoPath string) *Git {
+func NewGit(repoPath string, opts ...Option) *Git {
+	o := newOptions(opts)
+
 	return &Git{
 		RepoPath: repoPath,
+		output:   o.output,
+	}
+}
+
+// attach connects the command to the terminal, unless its output is redirected
+func (g Git) attach(cmd *exec.Cmd) {
+	if g.output != nil {
+		cmd.Stdout = g.output
+		cmd.Stderr = g.output
+		// nobody is there to answer
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+		return
 	}
+
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
 }
 
 func (g Git) Installed() error {
@@ -61,9 +87,7 @@ func (g Git) Init(ctx context.Context) error {
 	cmd := exec.CommandContext(ctx, "git", "init")
 
 	cmd.Dir = g.RepoPath
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	g.attach(cmd)
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to initialize git repository: %w", wrapCmdErr(cmd, err))
@@ -75,9 +99,7 @@ func (g Git) Init(ctx context.Context) error {
 func (g Git) Clone(ctx context.Context, url string) error {
 	cmd := exec.CommandContext(ctx, "git", "clone", url, g.RepoPath)
 
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	g.attach(cmd)
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("git clone failed: %w", wrapCmdErr(cmd, err))
@@ -110,9 +132,7 @@ func (g Git) Branch(ctx context.Context, branch string) error {
 	cmd := exec.CommandContext(ctx, "git", "branch", "-M", branch)
 
 	cmd.Dir = g.RepoPath
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	g.attach(cmd)
 
 	// Run and wait
 	if err := cmd.Run(); err != nil {
@@ -137,9 +157,7 @@ func (g Git) GetStatus(ctx context.Context) (string, error) {
 func (g Git) Commit(ctx context.Context, message string) error {
 	cmd := exec.CommandContext(ctx, "git", "add", ".")
 	cmd.Dir = g.RepoPath
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	g.attach(cmd)
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("git add failed: %w", wrapCmdErr(cmd, err))
@@ -147,9 +165,7 @@ func (g Git) Commit(ctx context.Context, message string) error {
 
 	cmd = exec.CommandContext(ctx, "git", "commit", "-m", message)
 	cmd.Dir = g.RepoPath
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	g.attach(cmd)
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("git commit failed: %w", wrapCmdErr(cmd, err))
@@ -162,9 +178,7 @@ func (g Git) AddRemote(ctx context.Context, origin, url string) error {
 	cmd := exec.CommandContext(ctx, "git", "remote", "add", origin, url)
 
 	cmd.Dir = g.RepoPath
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	g.attach(cmd)
 
 	// Run and wait
 	if err := cmd.Run(); err != nil {
@@ -177,9 +191,7 @@ func (g Git) AddRemote(ctx context.Context, origin, url string) error {
 func (g Git) Push(ctx context.Context, origin, branch string) error {
 	cmd := exec.CommandContext(ctx, "git", "push", origin, branch)
 	cmd.Dir = g.RepoPath
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	g.attach(cmd)
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("git push failed: %w", wrapCmdErr(cmd, err))
@@ -188,6 +200,31 @@ func (g Git) Push(ctx context.Context, origin, branch string) error {
 	return nil
 }
 
+func (g Git) Fetch(ctx context.Context, origin, branch string) error {
+	cmd := exec.CommandContext(ctx, "git", "fetch", origin, branch)
+	cmd.Dir = g.RepoPath
+	g.attach(cmd)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git fetch failed: %w", wrapCmdErr(cmd, err))
+	}
+
+	return nil
+}
+
+// Merge merges the fetched remote branch into the current one
+func (g Git) Merge(ctx context.Context, origin, branch string) error {
+	cmd := exec.CommandContext(ctx, "git", "merge", "--no-edit", "--allow-unrelated-histories", origin+"/"+branch)
+	cmd.Dir = g.RepoPath
+	g.attach(cmd)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git merge failed: %w", wrapCmdErr(cmd, err))
+	}
+
+	return nil
+}
+
 func (g Git) Pull(ctx context.Context, origin, branch string) error {
 	cmd := exec.CommandContext(
 		ctx,
@@ -201,9 +238,7 @@ func (g Git) Pull(ctx context.Context, origin, branch string) error {
 	)
 
 	cmd.Dir = g.RepoPath
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	g.attach(cmd)
 
 	// Run and wait
 	if err := cmd.Run(); err != nil {
@@ -213,6 +248,112 @@ func (g Git) Pull(ctx context.Context, origin, branch string) error {
 	return nil
 }
 
+// AbortMerge gives up the pull or merge stopped by conflicts, so the repository gets back to the state before it
+func (g Git) AbortMerge(ctx context.Context) error {
+	gitDir := filepath.Join(g.RepoPath, ".git")
+
+	var args []string
+
+	switch {
+	case fileExists(filepath.Join(gitDir, "rebase-merge")), fileExists(filepath.Join(gitDir, "rebase-apply")):
+		args = []string{"rebase", "--abort"}
+	case fileExists(filepath.Join(gitDir, "MERGE_HEAD")):
+		args = []string{"merge", "--abort"}
+	default:
+		return nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = g.RepoPath
+	g.attach(cmd)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s --abort failed: %w", args[0], wrapCmdErr(cmd, err))
+	}
+
+	return nil
+}
+
+// FileHistory lists versions of the file committed to the current branch, newest first.
+// Merged branches are not walked and merges are compared with their first parents.
+func (g Git) FileHistory(ctx context.Context, path string) ([]FileVersion, error) {
+	cmd := exec.CommandContext(ctx, "git", "log", "--first-parent", "--format=%H%x00%an%x00%aI%x00%s", "--", path)
+	cmd.Dir = g.RepoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", wrapCmdErr(cmd, err))
+	}
+
+	var (
+		versions []FileVersion
+		objects  bytes.Buffer
+	)
+
+	for line := range strings.Lines(strings.TrimSpace(string(out))) {
+		fields := strings.SplitN(strings.TrimSuffix(line, "\n"), "\x00", 4)
+		if len(fields) != 4 {
+			continue
+		}
+
+		date, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse commit date %s: %w", fields[2], err)
+		}
+
+		versions = append(versions, FileVersion{Commit: fields[0], Author: fields[1], Date: date, Message: fields[3]})
+		objects.WriteString(fields[0] + ":" + filepath.ToSlash(path) + "\n")
+	}
+
+	if len(versions) == 0 {
+		return nil, nil
+	}
+
+	// all versions are read by one process, as journals have plenty of them
+	cmd = exec.CommandContext(ctx, "git", "cat-file", "--batch")
+	cmd.Dir = g.RepoPath
+	cmd.Stdin = &objects
+
+	out, err = cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file failed: %w", wrapCmdErr(cmd, err))
+	}
+
+	r := bufio.NewReader(bytes.NewReader(out))
+
+	for i := range versions {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s of commit %s: %w", path, versions[i].Commit, err)
+		}
+
+		// the file is missing in commits that removed it
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			continue
+		}
+
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s of commit %s: %w", path, versions[i].Commit, err)
+		}
+
+		content := make([]byte, size+1) // the content is followed by a newline
+		if _, err := io.ReadFull(r, content); err != nil {
+			return nil, fmt.Errorf("failed to read %s of commit %s: %w", path, versions[i].Commit, err)
+		}
+
+		versions[i].Content = content[:size]
+	}
+
+	return versions, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
 func wrapCmdErr(cmd *exec.Cmd, err error) error {
 	if exitError, ok := err.(*exec.ExitError); ok {
 		return fmt.Errorf(
@@ -0,0 +1,776 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/gitattributes"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+var (
+	ErrUncommittedChanges   = errors.New("commit local changes before merging")
+	ErrMergeConflict        = errors.New("files were changed on both sides")
+	ErrRemoteBranchNotFound = errors.New("remote branch not found")
+)
+
+// GoGit works with the repository via the embedded git implementation, so it doesn't need the git binary
+type GoGit struct {
+	RepoPath string
+
+	drivers map[string]MergeDriverFunc
+	output  io.Writer
+}
+
+// NewGoGit creates a new GoGit instance
+func NewGoGit(repoPath string, opts ...Option) *GoGit {
+	o := newOptions(opts)
+
+	output := o.output
+	if output == nil {
+		output = os.Stdout
+	}
+
+	return &GoGit{
+		RepoPath: repoPath,
+		drivers:  o.drivers,
+		output:   output,
+	}
+}
+
+func (g GoGit) Installed() error {
+	return nil
+}
+
+func (g GoGit) Inited() error {
+	if _, err := g.open(); err != nil {
+		return fmt.Errorf(
+			"no git repository found under %s. Please initialize or connect to a remote repository first",
+			g.RepoPath,
+		)
+	}
+
+	return nil
+}
+
+func (g GoGit) open() (*git.Repository, error) {
+	repo, err := git.PlainOpen(g.RepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository %s: %w", g.RepoPath, err)
+	}
+
+	return repo, nil
+}
+
+func (g GoGit) Init(_ context.Context) error {
+	if _, err := git.PlainInit(g.RepoPath, false); err != nil {
+		return fmt.Errorf("failed to initialize git repository: %w", err)
+	}
+
+	return nil
+}
+
+func (g GoGit) Clone(ctx context.Context, url string) error {
+	err := g.clone(ctx, url, "")
+
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		// the remote HEAD may point to a branch nothing was pushed to (e.g. master of a new bare repository)
+		err = g.clone(ctx, url, plumbing.NewBranchReferenceName(DefaultBranch))
+	}
+
+	if errors.Is(err, plumbing.ErrReferenceNotFound) || errors.Is(err, git.NoMatchingRefSpecError{}) ||
+		errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		// git clones empty repositories too, the journal gets pushed there on the first sync
+		if err := g.Init(ctx); err != nil {
+			return err
+		}
+
+		return g.AddRemote(ctx, git.DefaultRemoteName, url)
+	}
+
+	if err != nil {
+		return fmt.Errorf("git clone failed: %w", err)
+	}
+
+	return nil
+}
+
+func (g GoGit) clone(ctx context.Context, url string, branch plumbing.ReferenceName) error {
+	_, err := git.PlainCloneContext(ctx, g.RepoPath, false, &git.CloneOptions{
+		URL:           url,
+		ReferenceName: branch,
+		SingleBranch:  branch != "",
+		Progress:      g.output,
+	})
+	if err != nil {
+		// start over from scratch, as the failed clone may leave a partial repository behind
+		if rmErr := os.RemoveAll(filepath.Join(g.RepoPath, git.GitDirName)); rmErr != nil {
+			return fmt.Errorf("failed to clean up after failed clone: %w", rmErr)
+		}
+	}
+
+	return err
+}
+
+func (g GoGit) GetBranchName(_ context.Context) (string, error) {
+	repo, err := g.open()
+	if err != nil {
+		return "", err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get branch name: %w", err)
+	}
+
+	return head.Name().Short(), nil
+}
+
+// Branch renames the current branch
+func (g GoGit) Branch(_ context.Context, branch string) error {
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+
+	name := plumbing.NewBranchReferenceName(branch)
+
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return fmt.Errorf("git branch failed: %w", err)
+	}
+
+	if head.Type() == plumbing.SymbolicReference && head.Target() == name {
+		return nil
+	}
+
+	current, err := repo.Head()
+
+	switch {
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+		// nothing is committed yet, so there is no branch to move
+	case err != nil:
+		return fmt.Errorf("git branch failed: %w", err)
+	default:
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(name, current.Hash())); err != nil {
+			return fmt.Errorf("git branch failed: %w", err)
+		}
+
+		if head.Type() == plumbing.SymbolicReference {
+			if err := repo.Storer.RemoveReference(head.Target()); err != nil {
+				return fmt.Errorf("git branch failed: %w", err)
+			}
+		}
+	}
+
+	if err := repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, name)); err != nil {
+		return fmt.Errorf("git branch failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetStatus lists changed files in the format of git status --porcelain
+func (g GoGit) GetStatus(_ context.Context) (string, error) {
+	repo, err := g.open()
+	if err != nil {
+		return "", err
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("git status failed: %w", err)
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return "", fmt.Errorf("git status failed: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(status.String(), "\n"), "\n")
+	slices.Sort(lines)
+
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
+
+func (g GoGit) Commit(_ context.Context, message string) error {
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("git add failed: %w", err)
+	}
+
+	if err := wt.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return fmt.Errorf("git add failed: %w", err)
+	}
+
+	if _, err := wt.Commit(message, &git.CommitOptions{Author: signature(repo)}); err != nil {
+		return fmt.Errorf("git commit failed: %w", err)
+	}
+
+	return nil
+}
+
+// signature is the configured git identity. Minimal containers rarely have one, so it falls back to the host name.
+func signature(repo *git.Repository) *object.Signature {
+	cfg, err := repo.ConfigScoped(config.SystemScope)
+	if err == nil && cfg.User.Name != "" && cfg.User.Email != "" {
+		return &object.Signature{Name: cfg.User.Name, Email: cfg.User.Email, When: time.Now()}
+	}
+
+	hostname, _ := os.Hostname()
+
+	return &object.Signature{Name: "frens", Email: "frens@" + hostname, When: time.Now()}
+}
+
+func (g GoGit) AddRemote(_ context.Context, origin, url string) error {
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: origin, URLs: []string{url}}); err != nil {
+		return fmt.Errorf("git remote add failed: %w", err)
+	}
+
+	return nil
+}
+
+func (g GoGit) Fetch(ctx context.Context, origin, branch string) error {
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+
+	refSpec := config.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", branch, origin, branch))
+
+	err = repo.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: origin,
+		RefSpecs:   []config.RefSpec{refSpec},
+		Progress:   g.output,
+	})
+
+	switch {
+	case err == nil, errors.Is(err, git.NoErrAlreadyUpToDate):
+		return nil
+	case errors.Is(err, git.NoMatchingRefSpecError{}), errors.Is(err, transport.ErrEmptyRemoteRepository):
+		return fmt.Errorf("git fetch failed: %w: %s/%s", ErrRemoteBranchNotFound, origin, branch)
+	default:
+		return fmt.Errorf("git fetch failed: %w", err)
+	}
+}
+
+// Merge merges the fetched remote branch into the current one.
+// Files changed on both sides are merged by drivers assigned to them in .gitattributes.
+// Files without drivers or with conflicts reported by them stop the merge with ErrMergeConflict.
+func (g GoGit) Merge(ctx context.Context, origin, branch string) error {
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+
+	remoteRef, err := repo.Reference(plumbing.NewRemoteReferenceName(origin, branch), true)
+	if err != nil {
+		return fmt.Errorf("git merge failed: %w: %s/%s", ErrRemoteBranchNotFound, origin, branch)
+	}
+
+	theirs, err := repo.CommitObject(remoteRef.Hash())
+	if err != nil {
+		return fmt.Errorf("git merge failed: %w", err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("git merge failed: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil && !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return fmt.Errorf("git merge failed: %w", err)
+	}
+
+	if head != nil && head.Hash() == theirs.Hash {
+		return nil
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return fmt.Errorf("git merge failed: %w", err)
+	}
+
+	if !status.IsClean() {
+		return fmt.Errorf("git merge failed: %w", ErrUncommittedChanges)
+	}
+
+	if head == nil {
+		// nothing is committed yet, start from their history
+		return fastForward(repo, wt, theirs.Hash)
+	}
+
+	ours, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return fmt.Errorf("git merge failed: %w", err)
+	}
+
+	bases, err := ours.MergeBase(theirs)
+	if err != nil {
+		return fmt.Errorf("git merge failed: %w", err)
+	}
+
+	var base *object.Commit
+
+	if len(bases) > 0 {
+		base = bases[0]
+	}
+
+	switch {
+	case base != nil && base.Hash == theirs.Hash:
+		return nil
+	case base != nil && base.Hash == ours.Hash:
+		return fastForward(repo, wt, theirs.Hash)
+	}
+
+	if err := g.mergeTrees(ctx, wt, base, ours, theirs); err != nil {
+		return fmt.Errorf("git merge failed: %w", err)
+	}
+
+	message := fmt.Sprintf("Merge remote-tracking branch '%s/%s'", origin, branch)
+
+	_, err = wt.Commit(message, &git.CommitOptions{
+		Author:            signature(repo),
+		Parents:           []plumbing.Hash{ours.Hash, theirs.Hash},
+		AllowEmptyCommits: true,
+	})
+	if err != nil {
+		return fmt.Errorf("git merge failed: %w", err)
+	}
+
+	return nil
+}
+
+func fastForward(repo *git.Repository, wt *git.Worktree, to plumbing.Hash) error {
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return fmt.Errorf("failed to fast-forward: %w", err)
+	}
+
+	name := plumbing.HEAD
+
+	if head.Type() == plumbing.SymbolicReference {
+		name = head.Target()
+	}
+
+	// the branch may not exist yet, so it's moved before resetting the worktree
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(name, to)); err != nil {
+		return fmt.Errorf("failed to fast-forward: %w", err)
+	}
+
+	if err := wt.Reset(&git.ResetOptions{Commit: to, Mode: git.HardReset}); err != nil {
+		return fmt.Errorf("failed to fast-forward: %w", err)
+	}
+
+	return nil
+}
+
+// mergeTrees applies their changes to the worktree and stages them. The base is nil for unrelated histories.
+func (g GoGit) mergeTrees(ctx context.Context, wt *git.Worktree, base, ours, theirs *object.Commit) error {
+	patterns, err := gitattributes.ReadPatterns(wt.Filesystem, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", attributesFile, err)
+	}
+
+	attrs := gitattributes.NewMatcher(patterns)
+
+	baseFiles, err := commitFiles(base)
+	if err != nil {
+		return err
+	}
+
+	ourFiles, err := commitFiles(ours)
+	if err != nil {
+		return err
+	}
+
+	theirFiles, err := commitFiles(theirs)
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(ourFiles)+len(theirFiles))
+
+	for _, files := range []map[string]*object.File{baseFiles, ourFiles, theirFiles} {
+		for path := range files {
+			paths = append(paths, path)
+		}
+	}
+
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	var conflicted []string
+
+	for _, path := range paths {
+		b, o, t := baseFiles[path], ourFiles[path], theirFiles[path]
+
+		switch {
+		case sameFile(o, t), sameFile(b, t):
+			continue
+		case sameFile(b, o):
+			err = g.checkout(wt, path, t)
+		default:
+			err = g.mergeFile(ctx, wt, attrs, path, b, o, t)
+		}
+
+		if errors.Is(err, ErrMergeConflict) {
+			conflicted = append(conflicted, path)
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(conflicted) > 0 {
+		// reset the staged changes, so the repository stays as it was before the merge
+		if err := wt.Reset(&git.ResetOptions{Commit: ours.Hash, Mode: git.HardReset}); err != nil {
+			return fmt.Errorf("failed to abort the merge: %w", err)
+		}
+
+		return fmt.Errorf("%w: %s", ErrMergeConflict, strings.Join(conflicted, ", "))
+	}
+
+	return nil
+}
+
+func commitFiles(c *object.Commit) (map[string]*object.File, error) {
+	files := make(map[string]*object.File)
+
+	if c == nil {
+		return files, nil
+	}
+
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the tree of %s: %w", c.Hash, err)
+	}
+
+	err = tree.Files().ForEach(func(f *object.File) error {
+		files[f.Name] = f
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the tree of %s: %w", c.Hash, err)
+	}
+
+	return files, nil
+}
+
+func sameFile(a, b *object.File) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Hash == b.Hash && a.Mode == b.Mode
+}
+
+// checkout writes their version of the file to the worktree and stages it. A nil file is removed.
+func (g GoGit) checkout(wt *git.Worktree, path string, f *object.File) error {
+	if f == nil {
+		if _, err := wt.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+
+		return nil
+	}
+
+	content, err := f.Contents()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return g.stage(wt, path, []byte(content), f.Mode)
+}
+
+func (g GoGit) stage(wt *git.Worktree, path string, content []byte, mode filemode.FileMode) error {
+	fullPath := filepath.Join(g.RepoPath, filepath.FromSlash(path))
+
+	perm := os.FileMode(0o644)
+
+	if mode == filemode.Executable {
+		perm = 0o755
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create the directory of %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(fullPath, content, perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if _, err := wt.Add(path); err != nil {
+		return fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// mergeFile merges the file changed on both sides with its merge driver
+func (g GoGit) mergeFile(
+	ctx context.Context,
+	wt *git.Worktree,
+	attrs gitattributes.Matcher,
+	path string,
+	base, ours, theirs *object.File,
+) error {
+	if ours == nil || theirs == nil {
+		// removed on one side and changed on the other
+		return ErrMergeConflict
+	}
+
+	results, _ := attrs.Match(strings.Split(path, "/"), []string{"merge"})
+
+	attr, ok := results["merge"]
+	if !ok || !attr.IsValueSet() {
+		return ErrMergeConflict
+	}
+
+	driver, ok := g.drivers[attr.Value()]
+	if !ok {
+		return ErrMergeConflict
+	}
+
+	dir, err := os.MkdirTemp("", "frens-merge-*")
+	if err != nil {
+		return fmt.Errorf("failed to merge %s: %w", path, err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	versions := []string{filepath.Join(dir, "base"), filepath.Join(dir, "ours"), filepath.Join(dir, "theirs")}
+
+	for i, f := range []*object.File{base, ours, theirs} {
+		content := ""
+
+		// files added on both sides are merged with an empty base
+		if f != nil {
+			if content, err = f.Contents(); err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+		}
+
+		if err := os.WriteFile(versions[i], []byte(content), 0o600); err != nil {
+			return fmt.Errorf("failed to merge %s: %w", path, err)
+		}
+	}
+
+	conflicts, err := driver(ctx, path, versions[0], versions[1], versions[2])
+	if err != nil {
+		return fmt.Errorf("failed to merge %s: %w", path, err)
+	}
+
+	// like git, conflicts reported by the driver stop the merge
+	if len(conflicts) > 0 {
+		fmt.Fprintf(g.output, "%d conflict(s) in %s:\n", len(conflicts), path)
+
+		for _, c := range conflicts {
+			fmt.Fprintln(g.output, "  • "+c)
+		}
+
+		return ErrMergeConflict
+	}
+
+	merged, err := os.ReadFile(versions[1])
+	if err != nil {
+		return fmt.Errorf("failed to read the merged %s: %w", path, err)
+	}
+
+	return g.stage(wt, path, merged, ours.Mode)
+}
+
+// AbortMerge has nothing to do, as merges either complete or leave the repository untouched
+func (g GoGit) AbortMerge(_ context.Context) error {
+	return nil
+}
+
+// Pull fetches the remote branch and merges it into the current one.
+// Unlike the git binary, it merges instead of rebasing, as the embedded implementation can't rebase.
+func (g GoGit) Pull(ctx context.Context, origin, branch string) error {
+	if err := g.Fetch(ctx, origin, branch); err != nil {
+		if errors.Is(err, ErrRemoteBranchNotFound) {
+			// nothing has been pushed there yet
+			return nil
+		}
+
+		return err
+	}
+
+	return g.Merge(ctx, origin, branch)
+}
+
+func (g GoGit) Push(ctx context.Context, origin, branch string) error {
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+
+	refSpec := config.RefSpec(fmt.Sprintf("refs/heads/%s:refs/heads/%s", branch, branch))
+
+	err = repo.PushContext(ctx, &git.PushOptions{
+		RemoteName: origin,
+		RefSpecs:   []config.RefSpec{refSpec},
+		Progress:   g.output,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("git push failed: %w", err)
+	}
+
+	return nil
+}
+
+// RegisterMergeDriver keeps the driver command in the repository config for when git gets installed,
+// while the embedded implementation merges with drivers passed via WithMergeDriver.
+func (g GoGit) RegisterMergeDriver(_ context.Context, command string, files []string) error {
+	repo, err := g.open()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		return fmt.Errorf("failed to configure the merge driver: %w", err)
+	}
+
+	driver := cfg.Raw.Section("merge").Subsection(MergeDriver)
+	driver.SetOption("name", mergeDriverName)
+	driver.SetOption("driver", command)
+
+	if err := repo.SetConfig(cfg); err != nil {
+		return fmt.Errorf("failed to configure the merge driver: %w", err)
+	}
+
+	return writeMergeAttributes(g.RepoPath, files)
+}
+
+// FileHistory lists versions of the file committed to the current branch, newest first.
+// Like git log --first-parent, merged branches are not walked and merges are compared with their first parents.
+func (g GoGit) FileHistory(ctx context.Context, path string) ([]FileVersion, error) {
+	repo, err := g.open()
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the current commit: %w", err)
+	}
+
+	c, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit %s: %w", head.Hash(), err)
+	}
+
+	path = filepath.ToSlash(path)
+
+	f, err := commitFile(c, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []FileVersion
+
+	for c != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var (
+			parent     *object.Commit
+			parentFile *object.File
+		)
+
+		if c.NumParents() > 0 {
+			if parent, err = c.Parent(0); err != nil {
+				return nil, fmt.Errorf("failed to read the parent of commit %s: %w", c.Hash, err)
+			}
+
+			if parentFile, err = commitFile(parent, path); err != nil {
+				return nil, err
+			}
+		}
+
+		if fileChanged(f, parentFile) {
+			subject, _, _ := strings.Cut(c.Message, "\n")
+			v := FileVersion{Commit: c.Hash.String(), Author: c.Author.Name, Date: c.Author.When, Message: subject}
+
+			if f != nil {
+				content, err := f.Contents()
+				if err != nil {
+					return nil, fmt.Errorf("failed to read %s of commit %s: %w", path, c.Hash, err)
+				}
+
+				v.Content = []byte(content)
+			}
+
+			versions = append(versions, v)
+		}
+
+		c, f = parent, parentFile
+	}
+
+	return versions, nil
+}
+
+// commitFile finds the file in the commit tree, nil if the commit has no such file
+func commitFile(c *object.Commit, path string) (*object.File, error) {
+	f, err := c.File(path)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s of commit %s: %w", path, c.Hash, err)
+	}
+
+	return f, nil
+}
+
+// fileChanged tells if the file differs from its version in the parent commit
+func fileChanged(f, parent *object.File) bool {
+	if f == nil || parent == nil {
+		return f != parent
+	}
+
+	return f.Hash != parent.Hash
+}
@@ -0,0 +1,364 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+// newRemote creates a bare repository with the first commit pushed from a device repository
+func newRemote(t *testing.T, files map[string]string, opts ...Option) (string, *GoGit) {
+	t.Helper()
+
+	ctx := t.Context()
+	remote := filepath.Join(t.TempDir(), "journal.git")
+
+	_, err := git.PlainInit(remote, true)
+	require.NoError(t, err)
+
+	device := NewGoGit(t.TempDir(), opts...)
+	require.Error(t, device.Inited())
+	require.NoError(t, device.Init(ctx))
+	require.NoError(t, device.Inited())
+
+	for name, content := range files {
+		writeFile(t, device.RepoPath, name, content)
+	}
+
+	require.NoError(t, device.Commit(ctx, "init"))
+	require.NoError(t, device.Branch(ctx, "main"))
+	require.NoError(t, device.AddRemote(ctx, "origin", remote))
+	require.NoError(t, device.Push(ctx, "origin", "main"))
+
+	return remote, device
+}
+
+func cloneRemote(t *testing.T, remote string, opts ...Option) *GoGit {
+	t.Helper()
+
+	device := NewGoGit(filepath.Join(t.TempDir(), "journal"), opts...)
+	require.NoError(t, device.Clone(t.Context(), remote))
+
+	return device
+}
+
+func TestGoGit_Sync(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	remote, laptop := newRemote(t, map[string]string{"friends.toml": "jim\n"})
+
+	phone := cloneRemote(t, remote)
+	require.Equal(t, "jim\n", readFile(t, phone.RepoPath, "friends.toml"))
+
+	branch, err := phone.GetBranchName(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "main", branch)
+
+	writeFile(t, laptop.RepoPath, "friends.toml", "jim\npam\n")
+
+	status, err := laptop.GetStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "M friends.toml", status)
+
+	require.NoError(t, laptop.Commit(ctx, "add pam"))
+	require.NoError(t, laptop.Push(ctx, "origin", "main"))
+
+	writeFile(t, phone.RepoPath, "activities.toml", "lunch\n")
+	require.NoError(t, phone.Commit(ctx, "add lunch"))
+
+	require.ErrorContains(t, phone.Push(ctx, "origin", "main"), "non-fast-forward")
+
+	// files changed on different sides merge without drivers
+	require.NoError(t, phone.Pull(ctx, "origin", "main"))
+	require.Equal(t, "jim\npam\n", readFile(t, phone.RepoPath, "friends.toml"))
+	require.Equal(t, "lunch\n", readFile(t, phone.RepoPath, "activities.toml"))
+
+	status, err = phone.GetStatus(ctx)
+	require.NoError(t, err)
+	require.Empty(t, status)
+
+	require.NoError(t, phone.Push(ctx, "origin", "main"))
+
+	// the laptop has no changes of its own, so it fast-forwards
+	require.NoError(t, laptop.Pull(ctx, "origin", "main"))
+	require.Equal(t, "lunch\n", readFile(t, laptop.RepoPath, "activities.toml"))
+
+	repo, err := git.PlainOpen(laptop.RepoPath)
+	require.NoError(t, err)
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+
+	merge, err := repo.CommitObject(head.Hash())
+	require.NoError(t, err)
+	require.Len(t, merge.ParentHashes, 2)
+	require.Equal(t, "Merge remote-tracking branch 'origin/main'", merge.Message)
+
+	// removals are committed too
+	require.NoError(t, os.Remove(filepath.Join(laptop.RepoPath, "activities.toml")))
+	require.NoError(t, laptop.Commit(ctx, "remove lunch"))
+	require.NoError(t, laptop.Push(ctx, "origin", "main"))
+	require.NoError(t, phone.Pull(ctx, "origin", "main"))
+	require.NoFileExists(t, filepath.Join(phone.RepoPath, "activities.toml"))
+}
+
+// unionDriver merges files as sets of lines. Lines removed on one side are reported as conflicts.
+func unionDriver(_ context.Context, _, basePath, oursPath, theirsPath string) ([]string, error) {
+	var versions [3][]string
+
+	for i, path := range []string{basePath, oursPath, theirsPath} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		versions[i] = strings.Fields(string(content))
+	}
+
+	var conflicts []string
+
+	for _, line := range versions[0] {
+		if !slices.Contains(versions[1], line) || !slices.Contains(versions[2], line) {
+			conflicts = append(conflicts, line+" removed on one side")
+		}
+	}
+
+	lines := slices.Concat(versions[1], versions[2])
+	slices.Sort(lines)
+
+	merged := strings.Join(slices.Compact(lines), "\n")
+
+	return conflicts, os.WriteFile(oursPath, []byte(merged), 0o600)
+}
+
+func TestGoGit_MergeDriver(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	opt := WithMergeDriver(MergeDriver, unionDriver)
+
+	remote, laptop := newRemote(t, map[string]string{"friends.toml": "jim\n", "README.md": "journal\n"}, opt)
+	command := "frens -j . journal merge-driver %O %A %B %P"
+	require.NoError(t, laptop.RegisterMergeDriver(ctx, command, []string{"friends.toml"}))
+	require.Equal(t, "friends.toml merge=frens\n", readFile(t, laptop.RepoPath, attributesFile))
+
+	repo, err := git.PlainOpen(laptop.RepoPath)
+	require.NoError(t, err)
+
+	cfg, err := repo.Config()
+	require.NoError(t, err)
+	require.Equal(t, command, cfg.Raw.Section("merge").Subsection(MergeDriver).Option("driver"))
+
+	require.NoError(t, laptop.Commit(ctx, "register the merge driver"))
+	require.NoError(t, laptop.Push(ctx, "origin", "main"))
+
+	phone := cloneRemote(t, remote, opt)
+
+	writeFile(t, laptop.RepoPath, "friends.toml", "jim\npam\n")
+	require.NoError(t, laptop.Commit(ctx, "add pam"))
+	require.NoError(t, laptop.Push(ctx, "origin", "main"))
+
+	writeFile(t, phone.RepoPath, "friends.toml", "dwight\njim\n")
+	require.NoError(t, phone.Commit(ctx, "add dwight"))
+
+	require.NoError(t, phone.Pull(ctx, "origin", "main"))
+	require.Equal(t, "dwight\njim\npam", readFile(t, phone.RepoPath, "friends.toml"))
+
+	// conflicts reported by drivers stop the merge like git does
+	require.NoError(t, phone.Push(ctx, "origin", "main"))
+	require.NoError(t, laptop.Pull(ctx, "origin", "main"))
+
+	writeFile(t, laptop.RepoPath, "friends.toml", "dwight\njim\npam\nryan\n")
+	require.NoError(t, laptop.Commit(ctx, "add ryan"))
+	require.NoError(t, laptop.Push(ctx, "origin", "main"))
+
+	writeFile(t, phone.RepoPath, "friends.toml", "jim\npam\n")
+	require.NoError(t, phone.Commit(ctx, "remove dwight"))
+
+	require.ErrorIs(t, phone.Pull(ctx, "origin", "main"), ErrMergeConflict)
+	require.Equal(t, "jim\npam\n", readFile(t, phone.RepoPath, "friends.toml"))
+
+	status, err := phone.GetStatus(ctx)
+	require.NoError(t, err)
+	require.Empty(t, status)
+
+	writeFile(t, phone.RepoPath, "friends.toml", "dwight\njim\npam\n")
+	require.NoError(t, phone.Commit(ctx, "keep dwight"))
+	require.NoError(t, phone.Pull(ctx, "origin", "main"))
+	require.Equal(t, "dwight\njim\npam\nryan", readFile(t, phone.RepoPath, "friends.toml"))
+
+	// files without drivers stop the merge and the repository stays as it was
+	require.NoError(t, phone.Push(ctx, "origin", "main"))
+	require.NoError(t, laptop.Pull(ctx, "origin", "main"))
+
+	writeFile(t, laptop.RepoPath, "README.md", "Jim's journal\n")
+	require.NoError(t, laptop.Commit(ctx, "rename"))
+	require.NoError(t, laptop.Push(ctx, "origin", "main"))
+
+	writeFile(t, phone.RepoPath, "README.md", "Pam's journal\n")
+	writeFile(t, phone.RepoPath, "friends.toml", "jim")
+	require.NoError(t, phone.Commit(ctx, "rename"))
+
+	require.ErrorIs(t, phone.Pull(ctx, "origin", "main"), ErrMergeConflict)
+	require.Equal(t, "Pam's journal\n", readFile(t, phone.RepoPath, "README.md"))
+	require.Equal(t, "jim", readFile(t, phone.RepoPath, "friends.toml"))
+
+	status, err = phone.GetStatus(ctx)
+	require.NoError(t, err)
+	require.Empty(t, status)
+}
+
+func TestIgnoreFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, dir, ignoreFile, "*.log")
+
+	require.NoError(t, IgnoreFiles(dir, []string{"frens.lock", "*.tmp"}))
+	require.NoError(t, IgnoreFiles(dir, []string{"*.tmp", "frens.lock"}))
+	require.Equal(t, "*.log\nfrens.lock\n*.tmp\n", readFile(t, dir, ignoreFile))
+}
+
+func TestGoGit_EmptyRemote(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	remote := filepath.Join(t.TempDir(), "journal.git")
+
+	_, err := git.PlainInit(remote, true)
+	require.NoError(t, err)
+
+	device := cloneRemote(t, remote)
+
+	_, err = device.GetBranchName(ctx)
+	require.Error(t, err, "nothing is committed yet")
+
+	require.NoError(t, device.Pull(ctx, "origin", "main"))
+
+	writeFile(t, device.RepoPath, "friends.toml", "jim\n")
+
+	require.NoError(t, device.Commit(ctx, "init"))
+	require.NoError(t, device.Branch(ctx, "main"))
+	require.NoError(t, device.Push(ctx, "origin", "main"))
+
+	require.Equal(t, "jim\n", readFile(t, cloneRemote(t, remote).RepoPath, "friends.toml"))
+}
+
+func TestFileHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	_, laptop := newRemote(t, map[string]string{"friends.toml": "jim\n", "README.md": "journal\n"})
+
+	writeFile(t, laptop.RepoPath, "README.md", "Jim's journal\n")
+	require.NoError(t, laptop.Commit(ctx, "rename"))
+
+	writeFile(t, laptop.RepoPath, "friends.toml", "jim\npam\n")
+	require.NoError(t, laptop.Commit(ctx, "add pam\n\nPam works at the reception"))
+
+	require.NoError(t, os.Remove(filepath.Join(laptop.RepoPath, "friends.toml")))
+	require.NoError(t, laptop.Commit(ctx, "remove friends"))
+
+	repos := map[string]Repository{"gogit": laptop}
+
+	if _, err := exec.LookPath("git"); err == nil {
+		repos["git"] = NewGit(laptop.RepoPath)
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			versions, err := repo.FileHistory(t.Context(), "friends.toml")
+			require.NoError(t, err)
+			require.Len(t, versions, 3)
+
+			require.Equal(t, "remove friends", versions[0].Message)
+			require.Nil(t, versions[0].Content)
+
+			require.Equal(t, "add pam", versions[1].Message)
+			require.Equal(t, "jim\npam\n", string(versions[1].Content))
+			require.NotEmpty(t, versions[1].Author)
+			require.False(t, versions[1].Date.IsZero())
+
+			require.Equal(t, "init", versions[2].Message)
+			require.Equal(t, "jim\n", string(versions[2].Content))
+		})
+	}
+}
+
+func TestFileHistory_FirstParent(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	remote, laptop := newRemote(t, map[string]string{"friends.toml": "jim\n", "README.md": "journal\n"})
+
+	phone := cloneRemote(t, remote)
+
+	writeFile(t, phone.RepoPath, "friends.toml", "jim\ndwight\n")
+	require.NoError(t, phone.Commit(ctx, "add dwight"))
+	require.NoError(t, phone.Push(ctx, "origin", "main"))
+
+	writeFile(t, laptop.RepoPath, "README.md", "Jim's journal\n")
+	require.NoError(t, laptop.Commit(ctx, "rename"))
+	require.NoError(t, laptop.Pull(ctx, "origin", "main"))
+
+	repos := map[string]Repository{"gogit": laptop}
+
+	if _, err := exec.LookPath("git"); err == nil {
+		repos["git"] = NewGit(laptop.RepoPath)
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// commits of the merged branch show up as the merge that brought them in
+			versions, err := repo.FileHistory(t.Context(), "friends.toml")
+			require.NoError(t, err)
+			require.Len(t, versions, 2)
+
+			require.Equal(t, "Merge remote-tracking branch 'origin/main'", versions[0].Message)
+			require.Equal(t, "jim\ndwight\n", string(versions[0].Content))
+
+			require.Equal(t, "init", versions[1].Message)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+)
+
+func init() {
+	// go-git runs git-upload-pack and git-receive-pack to reach local repositories,
+	// serve them in-process instead, so local remotes work without git too
+	client.InstallProtocol("file", localTransport{Transport: server.DefaultServer, loader: server.DefaultLoader})
+}
+
+// localTransport serves local repositories in-process.
+// The go-git server fails to fetch when the client has commits the repository doesn't have (e.g. not pushed yet),
+// so they are dropped from fetch requests, the way git servers ignore them.
+type localTransport struct {
+	transport.Transport
+
+	loader server.Loader
+}
+
+func (t localTransport) NewUploadPackSession(
+	ep *transport.Endpoint,
+	auth transport.AuthMethod,
+) (transport.UploadPackSession, error) {
+	session, err := t.Transport.NewUploadPackSession(ep, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := t.loader.Load(ep)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository %s: %w", ep.Path, err)
+	}
+
+	return knownHavesSession{UploadPackSession: session, storer: st}, nil
+}
+
+type knownHavesSession struct {
+	transport.UploadPackSession
+
+	storer storer.EncodedObjectStorer
+}
+
+func (s knownHavesSession) UploadPack(
+	ctx context.Context,
+	req *packp.UploadPackRequest,
+) (*packp.UploadPackResponse, error) {
+	haves := req.Haves[:0:0]
+
+	for _, h := range req.Haves {
+		if s.storer.HasEncodedObject(h) == nil {
+			haves = append(haves, h)
+		}
+	}
+
+	req.Haves = haves
+
+	return s.UploadPackSession.UploadPack(ctx, req)
+}
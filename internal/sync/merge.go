@@ -0,0 +1,120 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// MergeDriver is the name journal files refer to in .gitattributes
+	MergeDriver     = "frens"
+	mergeDriverName = "frens journal merge driver"
+	attributesFile  = ".gitattributes"
+	ignoreFile      = ".gitignore"
+)
+
+// RegisterMergeDriver makes git merge the files with the command.
+// The command is kept in the repository config, as git doesn't take merge drivers from the repository content,
+// while .gitattributes is committed, so it travels to other devices with the journal.
+func (g Git) RegisterMergeDriver(ctx context.Context, command string, files []string) error {
+	config := [][2]string{
+		{"merge." + MergeDriver + ".name", mergeDriverName},
+		{"merge." + MergeDriver + ".driver", command},
+	}
+
+	for _, kv := range config {
+		cmd := exec.CommandContext(ctx, "git", "config", kv[0], kv[1])
+		cmd.Dir = g.RepoPath
+
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to configure the merge driver: %w: %s", wrapCmdErr(cmd, err), out)
+		}
+	}
+
+	return writeMergeAttributes(g.RepoPath, files)
+}
+
+// writeMergeAttributes assigns the merge driver to the files in .gitattributes of the repository
+func writeMergeAttributes(repoPath string, files []string) error {
+	attrs := make([]string, 0, len(files))
+
+	for _, f := range files {
+		attrs = append(attrs, f+" merge="+MergeDriver)
+	}
+
+	return appendLines(filepath.Join(repoPath, attributesFile), attrs)
+}
+
+// IgnoreFiles adds the patterns to .gitignore of the repository, so files like locks are never committed
+func IgnoreFiles(repoPath string, patterns []string) error {
+	return appendLines(filepath.Join(repoPath, ignoreFile), patterns)
+}
+
+// appendLines adds lines to the file, unless they are there already
+func appendLines(path string, lines []string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	updated := withLines(string(data), lines)
+
+	if updated == string(data) {
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// withLines adds lines missing in the content to its end. Lines are compared ignoring extra spaces.
+func withLines(content string, lines []string) string {
+	has := make(map[string]bool)
+
+	for l := range strings.Lines(content) {
+		has[strings.Join(strings.Fields(l), " ")] = true
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(content)
+
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		sb.WriteString("\n")
+	}
+
+	for _, l := range lines {
+		if !has[l] {
+			sb.WriteString(l + "\n")
+			has[l] = true
+		}
+	}
+
+	if sb.Len() == len(content) {
+		return content
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"time"
+)
+
+// DefaultBranch is the branch journals are synchronized through unless another one is checked out
+const DefaultBranch = "main"
+
+// Repository is a git repository the journal is synchronized through
+type Repository interface {
+	Installed() error
+	Inited() error
+	Init(ctx context.Context) error
+	Clone(ctx context.Context, url string) error
+	GetBranchName(ctx context.Context) (string, error)
+	Branch(ctx context.Context, branch string) error
+	GetStatus(ctx context.Context) (string, error)
+	Commit(ctx context.Context, message string) error
+	AddRemote(ctx context.Context, origin, url string) error
+	Fetch(ctx context.Context, origin, branch string) error
+	Merge(ctx context.Context, origin, branch string) error
+	Pull(ctx context.Context, origin, branch string) error
+	AbortMerge(ctx context.Context) error
+	Push(ctx context.Context, origin, branch string) error
+	RegisterMergeDriver(ctx context.Context, command string, files []string) error
+	FileHistory(ctx context.Context, path string) ([]FileVersion, error)
+}
+
+// FileVersion is the content of the file committed to the repository
+type FileVersion struct {
+	Commit  string
+	Author  string
+	Date    time.Time
+	Message string
+	// Content is nil when the commit removed the file
+	Content []byte
+}
+
+var (
+	_ Repository = (*Git)(nil)
+	_ Repository = (*GoGit)(nil)
+)
+
+// MergeDriverFunc merges a file changed on both sides the way git merge drivers do:
+// the base, ours and theirs versions are passed as paths and the result is written to the ours one.
+// It returns the conflicts it resolved by keeping our changes.
+type MergeDriverFunc func(ctx context.Context, path, basePath, oursPath, theirsPath string) ([]string, error)
+
+type options struct {
+	drivers map[string]MergeDriverFunc
+	output  io.Writer
+}
+
+func newOptions(opts []Option) options {
+	o := options{drivers: make(map[string]MergeDriverFunc)}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+type Option func(*options)
+
+// WithMergeDriver merges files assigned to the driver in .gitattributes with the function
+// when the git binary is missing, as driver commands from git config need a shell to run
+func WithMergeDriver(name string, driver MergeDriverFunc) Option {
+	return func(o *options) {
+		o.drivers[name] = driver
+	}
+}
+
+// WithOutput sends git output to the writer instead of the terminal. Git doesn't prompt for credentials then.
+func WithOutput(w io.Writer) Option {
+	return func(o *options) {
+		o.output = w
+	}
+}
+
+// New opens the repository with the git binary when it's installed,
+// or with the built-in git implementation otherwise (e.g. in minimal containers).
+func New(repoPath string, opts ...Option) Repository {
+	if _, err := exec.LookPath("git"); err == nil {
+		return NewGit(repoPath, opts...)
+	}
+
+	return NewGoGit(repoPath, opts...)
+}
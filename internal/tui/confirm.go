@@ -21,18 +21,24 @@ import (
 	"strings"
 )
 
+// stdin is shared by prompts, so answers piped in advance are not lost in the buffer of a previous prompt
+var stdin = bufio.NewReader(os.Stdin)
+
 func ConfirmAction(message string) bool {
-	reader := bufio.NewReader(os.Stdin)
+	response := Prompt(message + " [y/N]")
+
+	return response == "y" || response == "yes"
+}
 
-	fmt.Printf("%s [y/N]: ", message)
+// Prompt asks for a line of input and returns the lower-cased answer
+func Prompt(message string) string {
+	fmt.Printf("%s: ", message)
 
-	response, err := reader.ReadString('\n')
-	if err != nil {
+	response, err := stdin.ReadString('\n')
+	if err != nil && response == "" {
 		fmt.Fprintln(os.Stderr, "Error reading input:", err)
-		return false
+		return ""
 	}
 
-	response = strings.TrimSpace(strings.ToLower(response))
-
-	return response == "y" || response == "yes"
+	return strings.TrimSpace(strings.ToLower(response))
 }
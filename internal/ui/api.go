@@ -18,9 +18,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 	"sort"
+	"strings"
+	gosync "sync"
 	"time"
 
+	"github.com/roma-glushko/frens/internal/carddav"
 	"github.com/roma-glushko/frens/internal/friend"
 	"github.com/roma-glushko/frens/internal/journal"
 	"github.com/roma-glushko/frens/internal/store"
@@ -86,40 +90,63 @@ type FeedItem struct {
 // API holds the dependencies for API handlers.
 type API struct {
 	store store.Store
+	feed  *changeFeed
+	spec  func() *OpenAPI
 }
 
 // NewAPI creates a new API instance.
 func NewAPI(s store.Store) *API {
-	return &API{store: s}
+	a := &API{
+		store: s,
+		feed:  newChangeFeed(s),
+	}
+
+	a.spec = gosync.OnceValue(func() *OpenAPI {
+		return buildOpenAPI(a.routes())
+	})
+
+	return a
 }
 
 // RegisterRoutes registers all API routes on the given mux.
 func (a *API) RegisterRoutes(mux *http.ServeMux) {
-	mux.HandleFunc("GET /api/friends", a.handleListFriends)
-	mux.HandleFunc("GET /api/friends/{id}", a.handleGetFriend)
-	mux.HandleFunc("GET /api/friends/{id}/activities", a.handleGetFriendActivities)
-	mux.HandleFunc("GET /api/friends/{id}/notes", a.handleGetFriendNotes)
-	mux.HandleFunc("GET /api/locations", a.handleListLocations)
-	mux.HandleFunc("GET /api/locations/{id}", a.handleGetLocation)
-	mux.HandleFunc("GET /api/locations/{id}/activities", a.handleGetLocationActivities)
-	mux.HandleFunc("GET /api/locations/{id}/notes", a.handleGetLocationNotes)
-	mux.HandleFunc("GET /api/notes", a.handleListNotes)
-	mux.HandleFunc("GET /api/activities", a.handleListActivities)
-	mux.HandleFunc("GET /api/stats", a.handleGetStats)
-	mux.HandleFunc("GET /api/stats/comprehensive", a.handleGetComprehensiveStats)
-	mux.HandleFunc("GET /api/sync/status", a.handleGetSyncStatus)
-	mux.HandleFunc("GET /api/feed", a.handleGetFeed)
+	for _, rt := range a.routes() {
+		h := rt.handler
+
+		if rt.write {
+			h = a.publishing(h)
+		}
+
+		mux.HandleFunc(rt.method+" "+rt.path, h)
+	}
+
+	// address books sync friends over CardDAV, a protocol of its own, so it's not described by the OpenAPI document
+	dav := carddav.NewHandler(a.store)
+	publishingDAV := a.publishing(dav.ServeHTTP)
+
+	mux.HandleFunc(carddav.Prefix, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut || r.Method == http.MethodDelete {
+			publishingDAV(w, r)
+			return
+		}
+
+		dav.ServeHTTP(w, r)
+	})
+	mux.Handle(carddav.WellKnownPath, http.RedirectHandler(carddav.Prefix, http.StatusMovedPermanently))
 }
 
-// handleListFriends returns all friends.
+// handleListFriends returns a page of friends.
 func (a *API) handleListFriends(w http.ResponseWriter, r *http.Request) {
+	q, err := friendQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
 	var friends []friend.Person
 
-	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
-		friends = j.ListFriends(friend.ListFriendQuery{
-			SortBy:    friend.SortAlpha,
-			SortOrder: friend.SortOrderDirect,
-		})
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		friends = j.ListFriends(q)
 
 		return nil
 	})
@@ -128,11 +155,7 @@ func (a *API) handleListFriends(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-
-	if err := json.NewEncoder(w).Encode(friends); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
+	writePage(w, r, friends, personID)
 }
 
 // handleGetFriend returns a single friend by ID.
@@ -176,7 +199,7 @@ func (a *API) handleGetFriend(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// handleGetFriendActivities returns activities for a specific friend.
+// handleGetFriendActivities returns a page of activities for a specific friend.
 func (a *API) handleGetFriendActivities(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
@@ -184,37 +207,12 @@ func (a *API) handleGetFriendActivities(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var activities []friend.Event
-
-	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
-		for _, event := range j.Activities {
-			for _, friendID := range event.FriendIDs {
-				if friendID == id {
-					activities = append(activities, *event)
-					break
-				}
-			}
-		}
-
-		return nil
+	a.listEvents(w, r, friend.EventTypeActivity, func(e friend.Event) bool {
+		return slices.Contains(e.FriendIDs, id)
 	})
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	if activities == nil {
-		activities = []friend.Event{}
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-
-	if err := json.NewEncoder(w).Encode(activities); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
 }
 
-// handleGetFriendNotes returns notes for a specific friend.
+// handleGetFriendNotes returns a page of notes for a specific friend.
 func (a *API) handleGetFriendNotes(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
@@ -222,45 +220,23 @@ func (a *API) handleGetFriendNotes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var notes []friend.Event
-
-	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
-		for _, event := range j.Notes {
-			for _, friendID := range event.FriendIDs {
-				if friendID == id {
-					notes = append(notes, *event)
-					break
-				}
-			}
-		}
-
-		return nil
+	a.listEvents(w, r, friend.EventTypeNote, func(e friend.Event) bool {
+		return slices.Contains(e.FriendIDs, id)
 	})
+}
+
+// handleListLocations returns a page of locations.
+func (a *API) handleListLocations(w http.ResponseWriter, r *http.Request) {
+	q, err := locationQuery(r.URL.Query())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	if notes == nil {
-		notes = []friend.Event{}
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-
-	if err := json.NewEncoder(w).Encode(notes); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
-}
-
-// handleListLocations returns all locations.
-func (a *API) handleListLocations(w http.ResponseWriter, r *http.Request) {
 	var locations []friend.Location
 
-	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
-		locations = j.ListLocations(friend.ListLocationQuery{
-			SortBy:    friend.SortAlpha,
-			SortOrder: friend.SortOrderDirect,
-		})
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		locations = j.ListLocations(q)
 
 		return nil
 	})
@@ -269,15 +245,7 @@ func (a *API) handleListLocations(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if locations == nil {
-		locations = []friend.Location{}
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-
-	if err := json.NewEncoder(w).Encode(locations); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
+	writePage(w, r, locations, locationID)
 }
 
 // handleGetLocation returns a single location by ID.
@@ -312,7 +280,7 @@ func (a *API) handleGetLocation(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// handleGetLocationActivities returns activities for a specific location.
+// handleGetLocationActivities returns a page of activities for a specific location.
 func (a *API) handleGetLocationActivities(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
@@ -320,37 +288,12 @@ func (a *API) handleGetLocationActivities(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	var activities []friend.Event
-
-	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
-		for _, event := range j.Activities {
-			for _, locationID := range event.LocationIDs {
-				if locationID == id {
-					activities = append(activities, *event)
-					break
-				}
-			}
-		}
-
-		return nil
+	a.listEvents(w, r, friend.EventTypeActivity, func(e friend.Event) bool {
+		return slices.Contains(e.LocationIDs, id)
 	})
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	if activities == nil {
-		activities = []friend.Event{}
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-
-	if err := json.NewEncoder(w).Encode(activities); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
 }
 
-// handleGetLocationNotes returns notes for a specific location.
+// handleGetLocationNotes returns a page of notes for a specific location.
 func (a *API) handleGetLocationNotes(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
@@ -358,88 +301,52 @@ func (a *API) handleGetLocationNotes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var notes []friend.Event
-
-	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
-		for _, event := range j.Notes {
-			for _, locationID := range event.LocationIDs {
-				if locationID == id {
-					notes = append(notes, *event)
-					break
-				}
-			}
-		}
-
-		return nil
+	a.listEvents(w, r, friend.EventTypeNote, func(e friend.Event) bool {
+		return slices.Contains(e.LocationIDs, id)
 	})
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	if notes == nil {
-		notes = []friend.Event{}
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-
-	if err := json.NewEncoder(w).Encode(notes); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
 }
 
-// handleListNotes returns all notes.
+// handleListNotes returns a page of notes.
 func (a *API) handleListNotes(w http.ResponseWriter, r *http.Request) {
-	var notes []friend.Event
+	a.listEvents(w, r, friend.EventTypeNote, nil)
+}
 
-	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
-		for _, note := range j.Notes {
-			notes = append(notes, *note)
-		}
+// handleListActivities returns a page of activities.
+func (a *API) handleListActivities(w http.ResponseWriter, r *http.Request) {
+	a.listEvents(w, r, friend.EventTypeActivity, nil)
+}
 
-		return nil
-	})
+// listEvents writes a page of filtered activities or notes, optionally narrowed down to related ones.
+func (a *API) listEvents(w http.ResponseWriter, r *http.Request, t friend.EventType, related func(friend.Event) bool) {
+	q, err := eventQuery(r.URL.Query(), t)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	if notes == nil {
-		notes = []friend.Event{}
-	}
+	var events []friend.Event
 
-	w.Header().Set("Content-Type", "application/json")
-
-	if err := json.NewEncoder(w).Encode(notes); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
-}
-
-// handleListActivities returns all activities.
-func (a *API) handleListActivities(w http.ResponseWriter, r *http.Request) {
-	var activities []friend.Event
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		events, err = j.ListEvents(q)
+		if err != nil {
+			// unknown friends or locations in filters
+			return badRequest(err)
+		}
 
-	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
-		for _, activity := range j.Activities {
-			activities = append(activities, *activity)
+		if related != nil {
+			events = slices.DeleteFunc(events, func(e friend.Event) bool {
+				return !related(e)
+			})
 		}
 
 		return nil
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	if activities == nil {
-		activities = []friend.Event{}
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-
-	if err := json.NewEncoder(w).Encode(activities); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
+	writePage(w, r, events, eventID)
 }
 
 // handleGetStats returns journal statistics.
@@ -649,96 +556,129 @@ func (a *API) handleGetComprehensiveStats(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// handleGetFeed returns a unified feed of recent activity.
-func (a *API) handleGetFeed(w http.ResponseWriter, r *http.Request) {
-	var feed []FeedItem
+// handleGetFeed returns a page of the unified feed of recent activity.
+func (a *API) handleGetFeed(w http.ResponseWriter, r *http.Request) { //nolint:cyclop
+	actQuery, err := eventQuery(r.URL.Query(), friend.EventTypeActivity)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
-	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
-		// Add activities
-		for _, event := range j.Activities {
-			feed = append(feed, FeedItem{
-				ID:          event.ID,
-				Type:        "activity",
-				Date:        event.Date.Format(time.RFC3339),
-				Description: event.Desc,
-				FriendIDs:   event.FriendIDs,
-				LocationIDs: event.LocationIDs,
-				Tags:        event.Tags,
-			})
+	if actQuery.SortBy != friend.SortRecency {
+		writeError(w, badRequest(fmt.Errorf("the feed can only be sorted by %s", friend.SortRecency)))
+		return
+	}
+
+	noteQuery := actQuery
+	noteQuery.Type = friend.EventTypeNote
+
+	type datedItem struct {
+		FeedItem
+
+		date time.Time
+	}
+
+	var items []datedItem
+
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		for _, q := range []friend.ListEventQuery{actQuery, noteQuery} {
+			events, err := j.ListEvents(q)
+			if err != nil {
+				return badRequest(err)
+			}
+
+			for _, event := range events {
+				items = append(items, datedItem{
+					FeedItem: FeedItem{
+						ID:          event.ID,
+						Type:        string(event.Type),
+						Date:        event.Date.Format(time.RFC3339),
+						Description: event.Desc,
+						FriendIDs:   event.FriendIDs,
+						LocationIDs: event.LocationIDs,
+						Tags:        event.Tags,
+					},
+					date: event.Date,
+				})
+			}
 		}
 
-		// Add notes
-		for _, event := range j.Notes {
-			feed = append(feed, FeedItem{
-				ID:          event.ID,
-				Type:        "note",
-				Date:        event.Date.Format(time.RFC3339),
-				Description: event.Desc,
-				FriendIDs:   event.FriendIDs,
-				LocationIDs: event.LocationIDs,
-				Tags:        event.Tags,
-			})
+		// additions have no tags, friends or locations to filter by
+		if len(actQuery.Tags) > 0 || len(actQuery.Friends) > 0 || len(actQuery.Locations) > 0 {
+			return nil
+		}
+
+		keyword := strings.ToLower(actQuery.Keyword)
+
+		inRange := func(name string, ts time.Time) bool {
+			return !ts.IsZero() &&
+				strings.Contains(strings.ToLower(name), keyword) &&
+				(actQuery.Since.IsZero() || !ts.Before(actQuery.Since)) &&
+				(actQuery.Until.IsZero() || !ts.After(actQuery.Until))
 		}
 
-		// Add friend additions
 		for _, f := range j.Friends {
-			if !f.CreatedAt.IsZero() {
-				feed = append(feed, FeedItem{
-					ID:          "friend-" + f.ID,
-					Type:        "friend_added",
-					Date:        f.CreatedAt.Format(time.RFC3339),
-					Description: "Added " + f.Name + " as a friend",
-					EntityID:    f.ID,
-					EntityName:  f.Name,
+			if inRange(f.Name, f.CreatedAt) {
+				items = append(items, datedItem{
+					FeedItem: FeedItem{
+						ID:          "friend-" + f.ID,
+						Type:        "friend_added",
+						Date:        f.CreatedAt.Format(time.RFC3339),
+						Description: "Added " + f.Name + " as a friend",
+						EntityID:    f.ID,
+						EntityName:  f.Name,
+					},
+					date: f.CreatedAt,
 				})
 			}
 		}
 
-		// Add location additions
 		for _, l := range j.Locations {
-			if !l.CreatedAt.IsZero() {
-				feed = append(feed, FeedItem{
-					ID:          "location-" + l.ID,
-					Type:        "location_added",
-					Date:        l.CreatedAt.Format(time.RFC3339),
-					Description: "Added " + l.Name + " as a location",
-					EntityID:    l.ID,
-					EntityName:  l.Name,
+			if inRange(l.Name, l.CreatedAt) {
+				items = append(items, datedItem{
+					FeedItem: FeedItem{
+						ID:          "location-" + l.ID,
+						Type:        "location_added",
+						Date:        l.CreatedAt.Format(time.RFC3339),
+						Description: "Added " + l.Name + " as a location",
+						EntityID:    l.ID,
+						EntityName:  l.Name,
+					},
+					date: l.CreatedAt,
 				})
 			}
 		}
 
-		// Sort by date descending
-		sort.Slice(feed, func(i, j int) bool {
-			ti, _ := time.Parse(time.RFC3339, feed[i].Date)
-			tj, _ := time.Parse(time.RFC3339, feed[j].Date)
-
-			return ti.After(tj)
-		})
-
 		return nil
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	if feed == nil {
-		feed = []FeedItem{}
-	}
+	// newest first unless asked otherwise
+	slices.SortStableFunc(items, func(x, y datedItem) int {
+		if actQuery.SortOrder == friend.SortOrderDirect {
+			return x.date.Compare(y.date)
+		}
 
-	w.Header().Set("Content-Type", "application/json")
+		return y.date.Compare(x.date)
+	})
 
-	if err := json.NewEncoder(w).Encode(feed); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	feed := make([]FeedItem, 0, len(items))
+
+	for _, it := range items {
+		feed = append(feed, it.FeedItem)
 	}
+
+	writePage(w, r, feed, feedItemID)
 }
 
 // handleGetSyncStatus returns the current sync status of the journal.
 func (a *API) handleGetSyncStatus(w http.ResponseWriter, r *http.Request) { //nolint:cyclop
 	status := SyncStatus{}
 
-	git := sync.NewGit(a.store.Path())
+	git := sync.New(a.store.Path())
 
 	// Check if git is installed
 	if err := git.Installed(); err != nil {
@@ -0,0 +1,880 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/lang"
+)
+
+const maxBodySize = 1 << 20
+
+// statusError carries the HTTP status the failed request should be answered with
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+func badRequest(err error) error {
+	return &statusError{status: http.StatusBadRequest, err: err}
+}
+
+func notFound(err error) error {
+	return &statusError{status: http.StatusNotFound, err: err}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	var sErr *statusError
+
+	if errors.As(err, &sErr) {
+		status = sErr.status
+	}
+
+	http.Error(w, err.Error(), status)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// input is a request body that holds either a raw frentxt text or a JSON document
+type input struct {
+	text bool
+	data []byte
+}
+
+// readInput reads the body before the journal transaction starts, so slow clients don't hold the journal lock
+func readInput(w http.ResponseWriter, r *http.Request) (input, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		return input{}, badRequest(fmt.Errorf("failed to read request body: %w", err))
+	}
+
+	in := input{text: mediaType == "text/plain", data: data}
+
+	if in.text {
+		in.data = []byte(strings.TrimSpace(string(data)))
+	}
+
+	if len(in.data) == 0 {
+		return input{}, badRequest(lang.ErrNoInfo)
+	}
+
+	return in, nil
+}
+
+// decodeInput parses frentxt bodies with the given parser. JSON documents are applied on top of the base entity,
+// so PATCH requests may contain only the fields to change.
+func decodeInput[T any](in input, base T, parse func(string) (T, error)) (T, error) {
+	if in.text {
+		parsed, err := parse(string(in.data))
+		if err != nil {
+			return parsed, badRequest(err)
+		}
+
+		return parsed, nil
+	}
+
+	var v T
+
+	// round-trip the base entity, so decoding doesn't write into slices it shares with the journal
+	data, err := json.Marshal(base)
+	if err != nil {
+		return v, fmt.Errorf("failed to encode entity: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return v, fmt.Errorf("failed to copy entity: %w", err)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(in.data)))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&v); err != nil {
+		return v, badRequest(fmt.Errorf("invalid JSON body: %w", err))
+	}
+
+	return v, nil
+}
+
+func removeMode(r *http.Request) (journal.RemoveMode, error) {
+	switch mode := journal.RemoveMode(r.URL.Query().Get("mode")); mode {
+	case "", journal.RemoveModeDetach:
+		return journal.RemoveModeDetach, nil
+	case journal.RemoveModeCascade:
+		return mode, nil
+	default:
+		return "", badRequest(fmt.Errorf("unknown remove mode '%s', use 'detach' or 'cascade'", mode))
+	}
+}
+
+// findFriend looks the friend up by the exact ID, as names and nicknames may point to other friends
+func findFriend(j *journal.Journal, id string) (friend.Person, error) {
+	i := slices.IndexFunc(j.Friends, func(f *friend.Person) bool { return f.ID == id })
+	if i < 0 {
+		return friend.Person{}, fmt.Errorf("friend '%s' not found", id)
+	}
+
+	return *j.Friends[i], nil
+}
+
+// findLocation looks the location up by the exact ID, as names and aliases may point to other locations
+func findLocation(j *journal.Journal, id string) (friend.Location, error) {
+	i := slices.IndexFunc(j.Locations, func(l *friend.Location) bool { return l.ID == id })
+	if i < 0 {
+		return friend.Location{}, fmt.Errorf("location '%s' not found", id)
+	}
+
+	return *j.Locations[i], nil
+}
+
+// handleCreateFriend adds a new friend.
+func (a *API) handleCreateFriend(w http.ResponseWriter, r *http.Request) {
+	in, err := readInput(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var created friend.Person
+
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		f, err := decodeInput(in, friend.Person{}, lang.ExtractPerson)
+		if err != nil {
+			return err
+		}
+
+		if err := f.Validate(); err != nil {
+			return badRequest(err)
+		}
+
+		j.AddFriend(f)
+
+		created = *j.Friends[len(j.Friends)-1]
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// handleUpdateFriend updates the friend information.
+func (a *API) handleUpdateFriend(w http.ResponseWriter, r *http.Request) {
+	in, err := readInput(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var updated friend.Person
+
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		o, err := findFriend(j, r.PathValue("id"))
+		if err != nil {
+			return notFound(err)
+		}
+
+		n, err := decodeInput(in, o, lang.ExtractPerson)
+		if err != nil {
+			return err
+		}
+
+		if n.ID == "" {
+			n.ID = o.ID
+		}
+
+		if err := n.Validate(); err != nil {
+			return badRequest(err)
+		}
+
+		j.UpdateFriend(o, n)
+
+		updated, err = findFriend(j, n.ID)
+
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// handleDeleteFriend removes the friend. Events that mention the friend are detached
+// or deleted too depending on the "mode" query parameter.
+func (a *API) handleDeleteFriend(w http.ResponseWriter, r *http.Request) {
+	mode, err := removeMode(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		f, err := findFriend(j, r.PathValue("id"))
+		if err != nil {
+			return notFound(err)
+		}
+
+		j.RemoveFriends([]friend.Person{f}, mode)
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleCreateLocation adds a new location.
+func (a *API) handleCreateLocation(w http.ResponseWriter, r *http.Request) {
+	in, err := readInput(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var created friend.Location
+
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		l, err := decodeInput(in, friend.Location{}, lang.ExtractLocation)
+		if err != nil {
+			return err
+		}
+
+		if err := l.Validate(); err != nil {
+			return badRequest(err)
+		}
+
+		j.AddLocation(l)
+
+		created = *j.Locations[len(j.Locations)-1]
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// handleUpdateLocation updates the location information.
+func (a *API) handleUpdateLocation(w http.ResponseWriter, r *http.Request) {
+	in, err := readInput(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var updated friend.Location
+
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		o, err := findLocation(j, r.PathValue("id"))
+		if err != nil {
+			return notFound(err)
+		}
+
+		n, err := decodeInput(in, o, lang.ExtractLocation)
+		if err != nil {
+			return err
+		}
+
+		if n.ID == "" {
+			n.ID = o.ID
+		}
+
+		if err := n.Validate(); err != nil {
+			return badRequest(err)
+		}
+
+		j.UpdateLocation(o, n)
+
+		updated, err = findLocation(j, n.ID)
+
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// handleDeleteLocation removes the location. Events that mention the location are detached
+// or deleted too depending on the "mode" query parameter.
+func (a *API) handleDeleteLocation(w http.ResponseWriter, r *http.Request) {
+	mode, err := removeMode(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		l, err := findLocation(j, r.PathValue("id"))
+		if err != nil {
+			return notFound(err)
+		}
+
+		j.RemoveLocations([]friend.Location{l}, mode)
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EventResult is an added or updated event along with location markers that matched no known location.
+type EventResult struct {
+	friend.Event
+
+	UnknownLocations []string `json:"unknownLocations,omitempty"`
+}
+
+func eventParser(t friend.EventType) func(string) (friend.Event, error) {
+	return func(s string) (friend.Event, error) {
+		return lang.ExtractEvent(t, s)
+	}
+}
+
+// handleCreateEvent returns a handler that records a new activity or note.
+func (a *API) handleCreateEvent(t friend.EventType) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		in, err := readInput(w, r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		var result EventResult
+
+		err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+			e, err := decodeInput(in, friend.Event{}, eventParser(t))
+			if err != nil {
+				return err
+			}
+
+			e.Type = t
+			e.FriendIDs = nil
+
+			if e.Date.IsZero() {
+				e.Date = time.Now().UTC()
+			}
+
+			if err := e.Validate(); err != nil {
+				return badRequest(err)
+			}
+
+			result.UnknownLocations = j.UnknownLocations(e.LocationIDs)
+
+			result.Event, err = j.AddEvent(e)
+			if err != nil {
+				return fmt.Errorf("failed to add a new event: %w", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, result)
+	}
+}
+
+// handleUpdateEvent returns a handler that updates an activity or a note.
+func (a *API) handleUpdateEvent(t friend.EventType) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		in, err := readInput(w, r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		var result EventResult
+
+		err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+			o, err := j.GetEvent(t, r.PathValue("id"))
+			if err != nil {
+				return notFound(err)
+			}
+
+			n, err := decodeInput(in, o, eventParser(t))
+			if err != nil {
+				return err
+			}
+
+			// friends are guessed from the description again
+			n.Type = t
+			n.FriendIDs = nil
+
+			if err := n.Validate(); err != nil {
+				return badRequest(err)
+			}
+
+			result.UnknownLocations = j.UnknownLocations(n.LocationIDs)
+
+			result.Event, err = j.UpdateEvent(o, n)
+			if err != nil {
+				return fmt.Errorf("failed to update event: %w", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// handleDeleteEvent returns a handler that removes an activity or a note.
+func (a *API) handleDeleteEvent(t friend.EventType) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
+			e, err := j.GetEvent(t, r.PathValue("id"))
+			if err != nil {
+				return notFound(err)
+			}
+
+			j.RemoveEvents(t, []friend.Event{e})
+
+			return nil
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// handleCreateContacts adds one or more contacts to the friend.
+// Text bodies may list several contacts, JSON bodies describe a single one.
+func (a *API) handleCreateContacts(w http.ResponseWriter, r *http.Request) {
+	in, err := readInput(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var created []friend.Contact
+
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		f, err := findFriend(j, r.PathValue("id"))
+		if err != nil {
+			return notFound(err)
+		}
+
+		var contacts []friend.Contact
+
+		if in.text {
+			contacts, err = lang.ExtractContacts(string(in.data))
+			if err != nil {
+				return badRequest(err)
+			}
+		} else {
+			c, err := decodeInput(in, friend.Contact{}, nil)
+			if err != nil {
+				return err
+			}
+
+			contacts = []friend.Contact{c}
+		}
+
+		for _, c := range contacts {
+			if err := c.Validate(); err != nil {
+				return badRequest(fmt.Errorf("invalid contact %s: %w", c.Value, err))
+			}
+
+			added, err := j.AddFriendContact(f.ID, c)
+			if err != nil {
+				return err
+			}
+
+			added.Person = f.ID
+			created = append(created, added)
+		}
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// friendContact resolves the contact making sure it belongs to the friend from the path
+func friendContact(j *journal.Journal, r *http.Request) (friend.Contact, error) {
+	f, err := findFriend(j, r.PathValue("id"))
+	if err != nil {
+		return friend.Contact{}, notFound(err)
+	}
+
+	c, err := j.GetFriendContact(r.PathValue("contactID"))
+	if err != nil || c.Person != f.ID {
+		return friend.Contact{}, notFound(fmt.Errorf("contact %s not found for %s", r.PathValue("contactID"), f.Name))
+	}
+
+	return c, nil
+}
+
+// handleUpdateContact updates the friend's contact.
+func (a *API) handleUpdateContact(w http.ResponseWriter, r *http.Request) {
+	in, err := readInput(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var updated friend.Contact
+
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		o, err := friendContact(j, r)
+		if err != nil {
+			return err
+		}
+
+		n, err := decodeInput(in, o, lang.ExtractContact)
+		if err != nil {
+			return err
+		}
+
+		if err := n.Validate(); err != nil {
+			return badRequest(err)
+		}
+
+		updated, err = j.UpdateFriendContact(o, n)
+		if err != nil {
+			return err
+		}
+
+		updated.Person = o.Person
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// handleDeleteContact removes the friend's contact.
+func (a *API) handleDeleteContact(w http.ResponseWriter, r *http.Request) {
+	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		c, err := friendContact(j, r)
+		if err != nil {
+			return err
+		}
+
+		return j.RemoveFriendContacts([]friend.Contact{c})
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleCreateDate adds an important date to the friend.
+func (a *API) handleCreateDate(w http.ResponseWriter, r *http.Request) {
+	in, err := readInput(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var created friend.Date
+
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		f, err := findFriend(j, r.PathValue("id"))
+		if err != nil {
+			return notFound(err)
+		}
+
+		d, err := decodeInput(in, friend.Date{}, lang.ExtractDateInfo)
+		if err != nil {
+			return err
+		}
+
+		if d.Calendar == "" {
+			d.Calendar = friend.CalendarGregorian
+		}
+
+		if err := d.Validate(); err != nil {
+			return badRequest(err)
+		}
+
+		created, err = j.AddFriendDate(f.ID, d)
+		if err != nil {
+			return err
+		}
+
+		created.Person = f.ID
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// friendDate resolves the date making sure it belongs to the friend from the path
+func friendDate(j *journal.Journal, r *http.Request) (friend.Date, error) {
+	f, err := findFriend(j, r.PathValue("id"))
+	if err != nil {
+		return friend.Date{}, notFound(err)
+	}
+
+	d, err := j.GetFriendDate(r.PathValue("dateID"))
+	if err != nil || d.Person != f.ID {
+		return friend.Date{}, notFound(fmt.Errorf("date %s not found for %s", r.PathValue("dateID"), f.Name))
+	}
+
+	return d, nil
+}
+
+// handleUpdateDate updates the friend's date.
+func (a *API) handleUpdateDate(w http.ResponseWriter, r *http.Request) {
+	in, err := readInput(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var updated friend.Date
+
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		o, err := friendDate(j, r)
+		if err != nil {
+			return err
+		}
+
+		n, err := decodeInput(in, o, lang.ExtractDateInfo)
+		if err != nil {
+			return err
+		}
+
+		if err := n.Validate(); err != nil {
+			return badRequest(err)
+		}
+
+		updated, err = j.UpdateFriendDate(o, n)
+		if err != nil {
+			return err
+		}
+
+		updated.Person = o.Person
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// handleDeleteDate removes the friend's date.
+func (a *API) handleDeleteDate(w http.ResponseWriter, r *http.Request) {
+	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		d, err := friendDate(j, r)
+		if err != nil {
+			return err
+		}
+
+		return j.RemoveFriendDates([]friend.Date{d})
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleCreateWishlistItem adds a gift idea to the friend's wishlist.
+func (a *API) handleCreateWishlistItem(w http.ResponseWriter, r *http.Request) {
+	in, err := readInput(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var created friend.WishlistItem
+
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		f, err := findFriend(j, r.PathValue("id"))
+		if err != nil {
+			return notFound(err)
+		}
+
+		item, err := decodeInput(in, friend.WishlistItem{}, lang.ExtractWishlistItem)
+		if err != nil {
+			return err
+		}
+
+		if item.CreatedAt.IsZero() {
+			item.CreatedAt = time.Now()
+		}
+
+		if err := item.Validate(); err != nil {
+			return badRequest(err)
+		}
+
+		created, err = j.AddFriendWishlistItem(f.ID, item)
+		if err != nil {
+			return err
+		}
+
+		created.Person = f.ID
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// friendWishlistItem resolves the wishlist item making sure it belongs to the friend from the path
+func friendWishlistItem(j *journal.Journal, r *http.Request) (friend.WishlistItem, error) {
+	f, err := findFriend(j, r.PathValue("id"))
+	if err != nil {
+		return friend.WishlistItem{}, notFound(err)
+	}
+
+	item, err := j.GetFriendWishlistItem(r.PathValue("itemID"))
+	if err != nil || item.Person != f.ID {
+		return friend.WishlistItem{}, notFound(
+			fmt.Errorf("wishlist item %s not found for %s", r.PathValue("itemID"), f.Name),
+		)
+	}
+
+	return item, nil
+}
+
+// handleUpdateWishlistItem updates the friend's wishlist item.
+func (a *API) handleUpdateWishlistItem(w http.ResponseWriter, r *http.Request) {
+	in, err := readInput(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var updated friend.WishlistItem
+
+	err = a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		o, err := friendWishlistItem(j, r)
+		if err != nil {
+			return err
+		}
+
+		n, err := decodeInput(in, o, lang.ExtractWishlistItem)
+		if err != nil {
+			return err
+		}
+
+		if err := n.Validate(); err != nil {
+			return badRequest(err)
+		}
+
+		updated, err = j.UpdateFriendWishlistItem(o, n)
+		if err != nil {
+			return err
+		}
+
+		updated.Person = o.Person
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// handleDeleteWishlistItem removes the item from the friend's wishlist.
+func (a *API) handleDeleteWishlistItem(w http.ResponseWriter, r *http.Request) {
+	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		item, err := friendWishlistItem(j, r)
+		if err != nil {
+			return err
+		}
+
+		return j.RemoveFriendWishlistItems([]friend.WishlistItem{item})
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,230 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/store"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	s := file.NewTOMLFileStore(t.TempDir())
+	require.NoError(t, s.Init(t.Context()))
+
+	return newTestServerWith(t, s)
+}
+
+func newTestServerWith(t *testing.T, s store.Store) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	NewAPI(s).RegisterRoutes(mux)
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func doRequest(t *testing.T, srv *httptest.Server, method, path, contentType, body string, out any) int {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, srv.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode < http.StatusBadRequest {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+
+	return resp.StatusCode
+}
+
+func TestAPI_Friends(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	var f friend.Person
+
+	status := doRequest(t, srv, http.MethodPost, "/api/friends", "text/plain",
+		"Jim Halpert (aka Big Tuna) :: a prankster #office", &f)
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, "jim-halpert", f.ID)
+	require.Equal(t, []string{"Big Tuna"}, f.Nicknames)
+
+	status = doRequest(t, srv, http.MethodPatch, "/api/friends/jim-halpert", "application/json",
+		`{"description": "a salesman"}`, &f)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "a salesman", f.Desc)
+	require.Equal(t, []string{"Big Tuna"}, f.Nicknames)
+
+	var contacts []friend.Contact
+
+	status = doRequest(t, srv, http.MethodPost, "/api/friends/jim-halpert/contacts", "text/plain",
+		"jim@dundermifflin.com tg:@bigtuna", &contacts)
+	require.Equal(t, http.StatusCreated, status)
+	require.Len(t, contacts, 2)
+
+	var date friend.Date
+
+	status = doRequest(t, srv, http.MethodPost, "/api/friends/jim-halpert/dates", "text/plain",
+		"10-01 :: birthday", &date)
+	require.Equal(t, http.StatusCreated, status)
+
+	// editing the friend keeps their contacts and dates
+	status = doRequest(t, srv, http.MethodPatch, "/api/friends/jim-halpert", "text/plain",
+		"Jim Halpert :: Pam's husband", &f)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, f.Contacts, 2)
+	require.Len(t, f.Dates, 1)
+
+	status = doRequest(t, srv, http.MethodDelete, "/api/friends/jim-halpert/contacts/"+contacts[0].ID, "", "", nil)
+	require.Equal(t, http.StatusNoContent, status)
+
+	status = doRequest(t, srv, http.MethodGet, "/api/friends/jim-halpert", "", "", &f)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, f.Contacts, 1)
+
+	status = doRequest(t, srv, http.MethodPost, "/api/friends", "application/json", `{"nickname": "Jim"}`, nil)
+	require.Equal(t, http.StatusBadRequest, status)
+
+	// names and nicknames are not IDs, so they don't resolve to Jim
+	status = doRequest(t, srv, http.MethodPatch, "/api/friends/jim", "application/json",
+		`{"description": "a boss"}`, nil)
+	require.Equal(t, http.StatusNotFound, status)
+
+	status = doRequest(t, srv, http.MethodDelete, "/api/friends/big-tuna", "", "", nil)
+	require.Equal(t, http.StatusNotFound, status)
+
+	status = doRequest(t, srv, http.MethodDelete, "/api/friends/jim-halpert?mode=purge", "", "", nil)
+	require.Equal(t, http.StatusBadRequest, status)
+
+	status = doRequest(t, srv, http.MethodDelete, "/api/friends/jim-halpert", "", "", nil)
+	require.Equal(t, http.StatusNoContent, status)
+
+	status = doRequest(t, srv, http.MethodGet, "/api/friends/jim-halpert", "", "", nil)
+	require.Equal(t, http.StatusNotFound, status)
+}
+
+func TestAPI_Events(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	status := doRequest(t, srv, http.MethodPost, "/api/friends", "text/plain", "Michael Scott", nil)
+	require.Equal(t, http.StatusCreated, status)
+
+	status = doRequest(t, srv, http.MethodPost, "/api/locations", "text/plain",
+		"Scranton, USA :: the Electric City", nil)
+	require.Equal(t, http.StatusCreated, status)
+
+	var act EventResult
+
+	status = doRequest(t, srv, http.MethodPost, "/api/activities", "text/plain",
+		"yesterday :: Michael hosted the Dundies @scranton @chilis #party", &act)
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, friend.EventTypeActivity, act.Type)
+	require.Equal(t, []string{"michael-scott"}, act.FriendIDs)
+	require.Equal(t, []string{"scranton"}, act.LocationIDs)
+	require.Equal(t, []string{"chilis"}, act.UnknownLocations)
+
+	status = doRequest(t, srv, http.MethodPatch, "/api/activities/"+act.ID, "application/json",
+		`{"description": "Michael hosted the Dundies again"}`, &act)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "Michael hosted the Dundies again", act.Desc)
+	require.Equal(t, []string{"michael-scott"}, act.FriendIDs)
+
+	var note EventResult
+
+	status = doRequest(t, srv, http.MethodPost, "/api/notes", "application/json",
+		`{"description": "Michael loves improv"}`, &note)
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, friend.EventTypeNote, note.Type)
+
+	status = doRequest(t, srv, http.MethodDelete, "/api/activities/"+note.ID, "", "", nil)
+	require.Equal(t, http.StatusNotFound, status)
+
+	status = doRequest(t, srv, http.MethodDelete, "/api/friends/michael-scott?mode=cascade", "", "", nil)
+	require.Equal(t, http.StatusNoContent, status)
+
+	var activities Page[friend.Event]
+
+	// the activity is still about Scranton, so it's kept
+	status = doRequest(t, srv, http.MethodGet, "/api/activities", "", "", &activities)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, activities.Items, 1)
+	require.Empty(t, activities.Items[0].FriendIDs)
+	require.Equal(t, []string{"scranton"}, activities.Items[0].LocationIDs)
+
+	status = doRequest(t, srv, http.MethodDelete, "/api/locations/scranton?mode=cascade", "", "", nil)
+	require.Equal(t, http.StatusNoContent, status)
+
+	status = doRequest(t, srv, http.MethodGet, "/api/activities", "", "", &activities)
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, activities.Items)
+	require.Zero(t, activities.Total)
+}
+
+func TestAPI_CardDAV(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	status := doRequest(t, srv, http.MethodPost, "/api/friends", "text/plain", "Jim Halpert", nil)
+	require.Equal(t, http.StatusCreated, status)
+
+	status = doRequest(t, srv, "PROPFIND", "/carddav/", "", "", nil)
+	require.Equal(t, http.StatusMultiStatus, status)
+
+	client := *srv.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+"/.well-known/carddav", nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+	require.Equal(t, "/carddav/", resp.Header.Get("Location"))
+
+	status = doRequest(t, srv, http.MethodGet, "/carddav/friends/jim-halpert.vcf", "", "", nil)
+	require.Equal(t, http.StatusOK, status)
+
+	status = doRequest(t, srv, http.MethodDelete, "/carddav/friends/jim-halpert.vcf", "", "", nil)
+	require.Equal(t, http.StatusNoContent, status)
+
+	status = doRequest(t, srv, http.MethodGet, "/api/friends/jim-halpert", "", "", nil)
+	require.Equal(t, http.StatusNotFound, status)
+}
@@ -0,0 +1,301 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/roma-glushko/frens/internal/carddav"
+)
+
+const (
+	// TokenParam is the query parameter the access token is passed in when the UI is opened in a browser
+	TokenParam = "token"
+	// FeedKeyParam is the query parameter feed subscriptions (e.g. the calendar) pass their read-only key in
+	FeedKeyParam = "key"
+	// CSRFHeader must echo the CSRF cookie value on state-changing requests authenticated by the session cookie
+	CSRFHeader = "X-CSRF-Token"
+
+	sessionCookie = "frens_session"
+	csrfCookie    = "frens_csrf"
+)
+
+var (
+	ErrInsecureBind = errors.New("refusing to serve the journal on a non-loopback address without authentication")
+	ErrEmptyToken   = errors.New("access token is empty")
+)
+
+// GenerateToken creates a random access token
+func GenerateToken() (string, error) {
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// ReadTokenFile reads the access token from the file ignoring surrounding whitespace
+func ReadTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file %s: %w", path, err)
+	}
+
+	token := strings.TrimSpace(string(data))
+
+	if token == "" {
+		return "", fmt.Errorf("%w: %s", ErrEmptyToken, path)
+	}
+
+	return token, nil
+}
+
+// auth checks the access token passed as a bearer token, or exchanges it for a session cookie.
+// Sessions are signed with the token, so they stay valid across restarts with the same token.
+type auth struct {
+	token []byte
+}
+
+func newAuth(token string) *auth {
+	return &auth{token: []byte(token)}
+}
+
+func (a *auth) validToken(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), a.token) == 1
+}
+
+func (a *auth) sign(value string) string {
+	mac := hmac.New(sha256.New, a.token)
+	mac.Write([]byte(value))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// FeedKey derives the key that grants read access to feeds calendar apps subscribe to.
+// It's put into subscription URLs, which are stored in plain text, so it doesn't reveal the access token.
+func FeedKey(token string) string {
+	return newAuth(token).sign("feed")
+}
+
+func (a *auth) csrfToken(nonce string) string {
+	return a.sign("csrf:" + nonce)
+}
+
+func (a *auth) startSession(w http.ResponseWriter, r *http.Request) error {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+
+	nonce := base64.RawURLEncoding.EncodeToString(b)
+	secure := r.TLS != nil
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    nonce + "." + a.sign("session:"+nonce),
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	// the UI reads this one to put it into the CSRF header
+	http.SetCookie(w, &http.Cookie{
+		Name:     csrfCookie,
+		Value:    a.csrfToken(nonce),
+		Path:     "/",
+		Secure:   secure,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	return nil
+}
+
+// session returns the session nonce if the request carries a valid session cookie
+func (a *auth) session(r *http.Request) (string, bool) {
+	c, err := r.Cookie(sessionCookie)
+	if err != nil {
+		return "", false
+	}
+
+	nonce, sig, ok := strings.Cut(c.Value, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(a.sign("session:"+nonce))) {
+		return "", false
+	}
+
+	return nonce, true
+}
+
+func (a *auth) middleware(next http.Handler) http.Handler {
+	feedKey := []byte(a.sign("feed"))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.URL.Query().Get(FeedKeyParam); key != "" && safeMethod(r.Method) && isFeed(r.URL.Path) {
+			if !hmac.Equal([]byte(key), feedKey) {
+				http.Error(w, "invalid feed key", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if token := r.URL.Query().Get(TokenParam); token != "" && r.Method == http.MethodGet {
+			if !a.validToken(token) {
+				http.Error(w, "invalid access token", http.StatusUnauthorized)
+				return
+			}
+
+			if err := a.startSession(w, r); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			// drop the token from the address bar and the browser history
+			u := *r.URL
+			q := u.Query()
+			q.Del(TokenParam)
+			u.RawQuery = q.Encode()
+
+			http.Redirect(w, r, u.String(), http.StatusSeeOther)
+
+			return
+		}
+
+		// address books support the basic authentication only: any user name goes with the access token as the password.
+		// Browsers may attach remembered credentials to requests of other sites, but CardDAV writes
+		// use methods other sites can't send without CORS, and sameOrigin rejects them anyway.
+		if _, password, ok := r.BasicAuth(); ok && isDAV(r.URL.Path) {
+			if !a.validToken(password) {
+				unauthorizedDAV(w)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		// bearer tokens can't be attached by other sites, so they don't need CSRF protection
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			if !a.validToken(token) {
+				http.Error(w, "invalid access token", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		nonce, ok := a.session(r)
+		if !ok && isDAV(r.URL.Path) {
+			unauthorizedDAV(w)
+			return
+		}
+
+		if !ok {
+			http.Error(
+				w,
+				"unauthorized: open the URL printed by `frens serve` or pass the token as a bearer token",
+				http.StatusUnauthorized,
+			)
+
+			return
+		}
+
+		if !safeMethod(r.Method) && !hmac.Equal([]byte(r.Header.Get(CSRFHeader)), []byte(a.csrfToken(nonce))) {
+			http.Error(w, "invalid CSRF token", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isFeed tells whether the path serves a read-only feed clients can't authenticate to otherwise
+func isFeed(path string) bool {
+	return path == CalendarPath
+}
+
+// isDAV tells whether the path is served to address books
+func isDAV(path string) bool {
+	return strings.HasPrefix(path, carddav.Prefix) || path == carddav.WellKnownPath
+}
+
+// unauthorizedDAV asks address books for the access token
+func unauthorizedDAV(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Basic realm="frens", charset="UTF-8"`)
+	http.Error(w, "unauthorized: use the access token printed by `frens serve` as the password", http.StatusUnauthorized)
+}
+
+func safeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// sameOrigin rejects state-changing cross-site requests. It guards the UI even when authentication is off,
+// as any web page can send simple requests to a server on the loopback address.
+func sameOrigin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if safeMethod(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if origin := r.Header.Get("Origin"); origin != "" {
+			u, err := url.Parse(origin)
+			if err != nil || u.Host != r.Host {
+				http.Error(w, "cross-origin request denied", http.StatusForbidden)
+				return
+			}
+		} else if r.Header.Get("Sec-Fetch-Site") == "cross-site" {
+			http.Error(w, "cross-origin request denied", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLoopback tells whether the listen address is reachable from this machine only
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuthHandler(t *testing.T, token string) http.Handler {
+	t.Helper()
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return sameOrigin(newAuth(token).middleware(ok))
+}
+
+func serve(h http.Handler, r *http.Request) *http.Response {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+
+	return rec.Result()
+}
+
+func TestAuth_BearerToken(t *testing.T) {
+	t.Parallel()
+
+	h := newAuthHandler(t, "secret")
+
+	resp := serve(h, httptest.NewRequest(http.MethodGet, "/api/friends", nil))
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	r := httptest.NewRequest(http.MethodPost, "/api/friends", nil)
+	r.Header.Set("Authorization", "Bearer wrong")
+	require.Equal(t, http.StatusUnauthorized, serve(h, r).StatusCode)
+
+	r = httptest.NewRequest(http.MethodPost, "/api/friends", nil)
+	r.Header.Set("Authorization", "Bearer secret")
+	require.Equal(t, http.StatusOK, serve(h, r).StatusCode)
+}
+
+func TestAuth_Session(t *testing.T) {
+	t.Parallel()
+
+	h := newAuthHandler(t, "secret")
+
+	resp := serve(h, httptest.NewRequest(http.MethodGet, "/friends?token=wrong", nil))
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = serve(h, httptest.NewRequest(http.MethodGet, "/friends?token=secret&tab=notes", nil))
+	require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+	require.Equal(t, "/friends?tab=notes", resp.Header.Get("Location"))
+
+	cookies := resp.Cookies()
+	require.Len(t, cookies, 2)
+
+	var csrf string
+
+	for _, c := range cookies {
+		if c.Name == csrfCookie {
+			csrf = c.Value
+		}
+	}
+
+	request := func(method string, header map[string]string) int {
+		r := httptest.NewRequest(method, "/api/friends", nil)
+
+		for _, c := range cookies {
+			r.AddCookie(c)
+		}
+
+		for k, v := range header {
+			r.Header.Set(k, v)
+		}
+
+		return serve(h, r).StatusCode
+	}
+
+	require.Equal(t, http.StatusOK, request(http.MethodGet, nil))
+	require.Equal(t, http.StatusForbidden, request(http.MethodPost, nil))
+	require.Equal(t, http.StatusForbidden, request(http.MethodPost, map[string]string{CSRFHeader: "forged"}))
+	require.Equal(t, http.StatusOK, request(http.MethodPost, map[string]string{CSRFHeader: csrf}))
+	require.Equal(t, http.StatusForbidden, request(http.MethodDelete, map[string]string{
+		CSRFHeader: csrf,
+		"Origin":   "https://evil.example.com",
+	}))
+
+	// sessions are signed with the token, so they don't survive the token change
+	r := httptest.NewRequest(http.MethodGet, "/api/friends", nil)
+
+	for _, c := range cookies {
+		r.AddCookie(c)
+	}
+
+	require.Equal(t, http.StatusUnauthorized, serve(newAuthHandler(t, "another"), r).StatusCode)
+}
+
+func TestAuth_FeedKey(t *testing.T) {
+	t.Parallel()
+
+	h := newAuthHandler(t, "secret")
+	key := FeedKey("secret")
+
+	require.NotContains(t, key, "secret")
+	require.NotEqual(t, FeedKey("other"), key)
+
+	resp := serve(h, httptest.NewRequest(http.MethodGet, CalendarPath+"?key="+key, nil))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = serve(h, httptest.NewRequest(http.MethodGet, CalendarPath+"?key=wrong", nil))
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// the key doesn't grant access to anything but feeds
+	resp = serve(h, httptest.NewRequest(http.MethodGet, "/api/friends?key="+key, nil))
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAuth_CardDAV(t *testing.T) {
+	t.Parallel()
+
+	h := newAuthHandler(t, "secret")
+
+	resp := serve(h, httptest.NewRequest("PROPFIND", "/carddav/friends/", nil))
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	require.Contains(t, resp.Header.Get("WWW-Authenticate"), "Basic")
+
+	r := httptest.NewRequest("PROPFIND", "/carddav/friends/", nil)
+	r.SetBasicAuth("jim", "wrong")
+	require.Equal(t, http.StatusUnauthorized, serve(h, r).StatusCode)
+
+	r = httptest.NewRequest(http.MethodPut, "/carddav/friends/jim.vcf", nil)
+	r.SetBasicAuth("jim", "secret")
+	require.Equal(t, http.StatusOK, serve(h, r).StatusCode)
+
+	// the basic authentication is meant for address books only
+	r = httptest.NewRequest(http.MethodGet, "/api/friends", nil)
+	r.SetBasicAuth("jim", "secret")
+	resp = serve(h, r)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	require.Empty(t, resp.Header.Get("WWW-Authenticate"))
+}
+
+func TestSameOrigin(t *testing.T) {
+	t.Parallel()
+
+	h := sameOrigin(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8080/api/notes", nil)
+	r.Header.Set("Origin", "http://127.0.0.1:8080")
+	require.Equal(t, http.StatusOK, serve(h, r).StatusCode)
+
+	r = httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8080/api/notes", nil)
+	r.Header.Set("Origin", "https://evil.example.com")
+	require.Equal(t, http.StatusForbidden, serve(h, r).StatusCode)
+
+	r = httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8080/api/notes", nil)
+	r.Header.Set("Sec-Fetch-Site", "cross-site")
+	require.Equal(t, http.StatusForbidden, serve(h, r).StatusCode)
+
+	r = httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/api/notes", nil)
+	r.Header.Set("Origin", "https://evil.example.com")
+	require.Equal(t, http.StatusOK, serve(h, r).StatusCode)
+}
+
+func TestIsLoopback(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isLoopback("127.0.0.1:8080"))
+	require.True(t, isLoopback("localhost:8080"))
+	require.True(t, isLoopback("[::1]:8080"))
+	require.False(t, isLoopback(":8080"))
+	require.False(t, isLoopback("0.0.0.0:8080"))
+	require.False(t, isLoopback("192.168.1.10:8080"))
+}
+
+func TestServer_RefusesInsecureBind(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer("0.0.0.0:0", log.New(io.Discard), file.NewTOMLFileStore(t.TempDir()))
+
+	_, err := s.Start(t.Context())
+	require.ErrorIs(t, err, ErrInsecureBind)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/roma-glushko/frens/internal/calendar"
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+)
+
+// CalendarPath serves friend dates as an iCalendar feed
+const CalendarPath = "/api/calendar.ics"
+
+var calendarParams = []param{
+	{name: "friends", desc: "Comma-separated friend IDs"},
+	tagsParam,
+	{name: "years", desc: "How many years ahead to resolve non-Gregorian dates for (default: 10)"},
+}
+
+// handleGetCalendar returns friend dates as an iCalendar calendar apps can subscribe to.
+func (a *API) handleGetCalendar(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	opts := []calendar.ICSOption{calendar.WithCalendarName("frens: friend dates")}
+
+	if s := v.Get("years"); s != "" {
+		years, err := strconv.Atoi(s)
+		if err != nil || years < 0 || years > 100 {
+			http.Error(w, "invalid years '"+s+"', expected a number from 0 to 100", http.StatusBadRequest)
+			return
+		}
+
+		opts = append(opts, calendar.WithYears(years))
+	}
+
+	var data []byte
+
+	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		dates, err := j.ListFriendDates(friend.ListDateQuery{
+			Friends: queryList(v, "friends"),
+			Tags:    queryList(v, "tags"),
+		})
+		if err != nil {
+			return notFound(err)
+		}
+
+		names := make(map[string]string, len(j.Friends))
+
+		for _, f := range j.Friends {
+			names[f.ID] = f.Name
+		}
+
+		// dates that can't be parsed are skipped, so a typo doesn't break the subscription
+		data, _ = calendar.EncodeICS(dates, func(d friend.Date) string {
+			return calendar.DateTitle(names[d.Person], d)
+		}, opts...)
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="frens.ics"`)
+
+	_, _ = w.Write(data)
+}
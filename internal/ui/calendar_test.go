@@ -0,0 +1,80 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_Calendar(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	for _, body := range []string{"Jim Halpert", "Pam Beesly", "Roy Anderson"} {
+		require.Equal(t, http.StatusCreated, doRequest(t, srv, http.MethodPost, "/api/friends", "text/plain", body, nil))
+	}
+
+	dates := map[string]string{
+		"jim-halpert":  "10-01 :: birthday #office",
+		"pam-beesly":   "March 25 :: birthday",
+		"roy-anderson": "1 Nisan 5750 :: birthday",
+	}
+
+	for id, body := range dates {
+		status := doRequest(t, srv, http.MethodPost, "/api/friends/"+id+"/dates", "text/plain", body, nil)
+		require.Equal(t, http.StatusCreated, status)
+	}
+
+	get := func(query string) (int, string) {
+		resp, err := srv.Client().Get(srv.URL + CalendarPath + query)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		if resp.StatusCode == http.StatusOK {
+			require.Equal(t, "text/calendar; charset=utf-8", resp.Header.Get("Content-Type"))
+		}
+
+		return resp.StatusCode, string(body)
+	}
+
+	status, cal := get("")
+	require.Equal(t, http.StatusOK, status)
+	require.Contains(t, cal, "BEGIN:VCALENDAR\r\n")
+	require.Contains(t, cal, "SUMMARY:Jim Halpert: birthday\r\n")
+	require.Contains(t, cal, "SUMMARY:Pam Beesly: birthday\r\n")
+	require.Contains(t, cal, "SUMMARY:Roy Anderson: birthday\r\n")
+
+	status, cal = get("?tags=office")
+	require.Equal(t, http.StatusOK, status)
+	require.Contains(t, cal, "Jim Halpert")
+	require.NotContains(t, cal, "Pam Beesly")
+
+	status, cal = get("?friends=roy&years=0")
+	require.Equal(t, http.StatusOK, status)
+	require.NotContains(t, cal, "Pam Beesly")
+	require.Contains(t, cal, "Roy Anderson")
+
+	status, _ = get("?years=forever")
+	require.Equal(t, http.StatusBadRequest, status)
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/store"
+	"github.com/roma-glushko/frens/internal/store/file"
+)
+
+const (
+	ChangeCreated = "created"
+	ChangeUpdated = "updated"
+	ChangeDeleted = "deleted"
+
+	changePollRate   = time.Second
+	subscriberBuffer = 64
+)
+
+// ChangeEvent tells that a journal entity has been created, updated or deleted.
+type ChangeEvent struct {
+	Seq    uint64    `json:"seq"`
+	Type   string    `json:"type"` // e.g. "friend.created", "activity.updated", "note.deleted"
+	Entity string    `json:"entity"`
+	ID     string    `json:"id"`
+	At     time.Time `json:"at"`
+}
+
+// snapshot maps "<entity>:<id>" keys to fingerprints of the entity content
+type snapshot map[string][32]byte
+
+func takeSnapshot(j *journal.Journal) snapshot {
+	s := make(snapshot, len(j.Friends)+len(j.Locations)+len(j.Activities)+len(j.Notes))
+
+	add := func(entity, id string, v any) {
+		data, _ := json.Marshal(v)
+		s[entity+":"+id] = sha256.Sum256(data)
+	}
+
+	for _, f := range j.Friends {
+		add("friend", f.ID, f)
+	}
+
+	for _, l := range j.Locations {
+		add("location", l.ID, l)
+	}
+
+	for _, e := range j.Activities {
+		add("activity", e.ID, e)
+	}
+
+	for _, e := range j.Notes {
+		add("note", e.ID, e)
+	}
+
+	return s
+}
+
+// diff lists changes that turn the old snapshot into the new one
+func diff(o, n snapshot) []ChangeEvent {
+	var changes []ChangeEvent
+
+	change := func(key, kind string) {
+		entity, id, _ := strings.Cut(key, ":")
+
+		changes = append(changes, ChangeEvent{Type: entity + "." + kind, Entity: entity, ID: id})
+	}
+
+	for key, fp := range n {
+		old, ok := o[key]
+
+		switch {
+		case !ok:
+			change(key, ChangeCreated)
+		case old != fp:
+			change(key, ChangeUpdated)
+		}
+	}
+
+	for key := range o {
+		if _, ok := n[key]; !ok {
+			change(key, ChangeDeleted)
+		}
+	}
+
+	return changes
+}
+
+// changeFeed watches the journal and broadcasts changes to subscribers.
+// Changes are found by comparing journal snapshots, so it doesn't matter whether they come
+// from the server itself or from other processes like the CLI or the Telegram bot.
+type changeFeed struct {
+	store store.Store
+
+	// refreshMu keeps snapshots in the order the journal has been loaded
+	refreshMu sync.Mutex
+
+	mu        sync.Mutex
+	snapshot  snapshot
+	signature string
+	seq       uint64
+	subs      map[chan ChangeEvent]struct{}
+}
+
+func newChangeFeed(s store.Store) *changeFeed {
+	return &changeFeed{
+		store: s,
+		subs:  make(map[chan ChangeEvent]struct{}),
+	}
+}
+
+// Run polls the journal directory and refreshes the feed when its files change
+func (f *changeFeed) Run(ctx context.Context, onErr func(error)) {
+	ticker := time.NewTicker(changePollRate)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sig := dirSignature(f.store.Path())
+
+			f.mu.Lock()
+			changed := sig != f.signature
+			f.mu.Unlock()
+
+			if !changed {
+				continue
+			}
+
+			if err := f.Refresh(ctx); err != nil {
+				onErr(err)
+				continue
+			}
+
+			f.mu.Lock()
+			f.signature = sig
+			f.mu.Unlock()
+		}
+	}
+}
+
+// Refresh loads the journal and publishes changes since the previous refresh.
+// The first refresh only remembers the journal state.
+func (f *changeFeed) Refresh(ctx context.Context) error {
+	f.refreshMu.Lock()
+	defer f.refreshMu.Unlock()
+
+	var current snapshot
+
+	// the journal is read under the store lock, so saves of other processes are never seen half-written
+	err := f.store.Tx(ctx, func(j *journal.Journal) error {
+		current = takeSnapshot(j)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.snapshot == nil {
+		f.snapshot = current
+		return nil
+	}
+
+	changes := diff(f.snapshot, current)
+	f.snapshot = current
+
+	now := time.Now().UTC()
+
+	for _, c := range changes {
+		f.seq++
+
+		c.Seq = f.seq
+		c.At = now
+
+		f.publish(c)
+	}
+
+	return nil
+}
+
+// publish sends the change to subscribers. Subscribers that fall behind are dropped,
+// so they can reconnect and reload the state instead of missing changes silently.
+func (f *changeFeed) publish(c ChangeEvent) {
+	for ch := range f.subs {
+		select {
+		case ch <- c:
+		default:
+			delete(f.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscribe returns a channel with journal changes and a function to unsubscribe.
+// The channel is closed when the subscriber can't keep up with changes.
+func (f *changeFeed) Subscribe() (<-chan ChangeEvent, func()) {
+	ch := make(chan ChangeEvent, subscriberBuffer)
+
+	f.mu.Lock()
+	f.subs[ch] = struct{}{}
+	f.mu.Unlock()
+
+	return ch, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if _, ok := f.subs[ch]; ok {
+			delete(f.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// Close ends all subscriptions, so open streams don't hold the server shutdown
+func (f *changeFeed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for ch := range f.subs {
+		delete(f.subs, ch)
+		close(ch)
+	}
+}
+
+// dirSignature summarizes names, sizes and modification times of journal files
+func dirSignature(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	for _, e := range entries {
+		if e.IsDir() || e.Name() == file.FileNameLock || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "%s:%d:%d;", e.Name(), info.Size(), info.ModTime().UnixNano())
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/history"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/sync"
+)
+
+// handleGetFriendHistory returns changes of the friend committed to the journal repository.
+// Friends are looked up by their exact IDs, so removed friends have history too.
+func (a *API) handleGetFriendHistory(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	found := false
+
+	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		_, err := findFriend(j, id)
+		found = err == nil
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	a.writeHistory(w, r, "friend", id, found)
+}
+
+// handleGetActivityHistory returns changes of the activity committed to the journal repository.
+func (a *API) handleGetActivityHistory(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	found := false
+
+	err := a.store.Tx(r.Context(), func(j *journal.Journal) error {
+		_, err := j.GetEvent(friend.EventTypeActivity, id)
+		found = err == nil
+
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	a.writeHistory(w, r, "activity", id, found)
+}
+
+// writeHistory lists changes of the entity newest first. Journals without git repositories have no history yet.
+func (a *API) writeHistory(w http.ResponseWriter, r *http.Request, kind, id string, found bool) {
+	var revisions []history.Revision
+
+	if git := sync.New(a.store.Path()); git.Inited() == nil {
+		var err error
+
+		if revisions, err = history.Entity(r.Context(), git, kind, id); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	if len(revisions) == 0 && !found {
+		writeError(w, notFound(fmt.Errorf("%s %s not found", kind, id)))
+		return
+	}
+
+	if revisions == nil {
+		revisions = []history.Revision{}
+	}
+
+	writeJSON(w, http.StatusOK, revisions)
+}
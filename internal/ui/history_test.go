@@ -0,0 +1,81 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/roma-glushko/frens/internal/history"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/roma-glushko/frens/internal/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_History(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	s := file.NewTOMLFileStore(dir)
+	require.NoError(t, s.Init(ctx))
+
+	srv := newTestServerWith(t, s)
+
+	status := doRequest(t, srv, http.MethodPost, "/api/friends", "text/plain", "Jim Halpert :: a prankster", nil)
+	require.Equal(t, http.StatusCreated, status)
+
+	// the journal has no repository yet
+	var revisions []history.Revision
+
+	status = doRequest(t, srv, http.MethodGet, "/api/friends/jim-halpert/history", "", "", &revisions)
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, revisions)
+
+	repo := sync.NewGoGit(dir)
+	require.NoError(t, repo.Init(ctx))
+	require.NoError(t, repo.Commit(ctx, "add jim"))
+
+	status = doRequest(t, srv, http.MethodPatch, "/api/friends/jim-halpert", "application/json",
+		`{"description": "Pam's husband"}`, nil)
+	require.Equal(t, http.StatusOK, status)
+	require.NoError(t, repo.Commit(ctx, "edit jim"))
+
+	status = doRequest(t, srv, http.MethodGet, "/api/friends/jim-halpert/history", "", "", &revisions)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, revisions, 2)
+	require.Equal(t, journal.ChangeUpdated, revisions[0].Kind)
+	require.Equal(t, "edit jim", revisions[0].Message)
+	require.Equal(t, []file.FieldChange{{Field: "desc", Old: "a prankster", New: "Pam's husband"}}, revisions[0].Changes)
+	require.Equal(t, journal.ChangeAdded, revisions[1].Kind)
+
+	// removed friends are still found by their IDs
+	status = doRequest(t, srv, http.MethodDelete, "/api/friends/jim-halpert", "", "", nil)
+	require.Equal(t, http.StatusNoContent, status)
+	require.NoError(t, repo.Commit(ctx, "remove jim"))
+
+	status = doRequest(t, srv, http.MethodGet, "/api/friends/jim-halpert/history", "", "", &revisions)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, revisions, 3)
+	require.Equal(t, journal.ChangeRemoved, revisions[0].Kind)
+
+	status = doRequest(t, srv, http.MethodGet, "/api/friends/pam/history", "", "", nil)
+	require.Equal(t, http.StatusNotFound, status)
+
+	status = doRequest(t, srv, http.MethodGet, "/api/activities/unknown/history", "", "", nil)
+	require.Equal(t, http.StatusNotFound, status)
+}
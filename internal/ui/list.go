@@ -0,0 +1,350 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/markusmobius/go-dateparser"
+	"github.com/roma-glushko/frens/internal/friend"
+)
+
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Page is a slice of a list along with the total number of items that match the filters
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int    `json:"total"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type pageQuery struct {
+	limit  int
+	cursor string
+}
+
+func parsePageQuery(v url.Values) (pageQuery, error) {
+	q := pageQuery{limit: defaultPageLimit, cursor: v.Get("cursor")}
+
+	if s := v.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 {
+			return q, badRequest(fmt.Errorf("invalid limit '%s', expected a positive number", s))
+		}
+
+		q.limit = min(limit, maxPageLimit)
+	}
+
+	return q, nil
+}
+
+// A cursor points right after the last item of the previous page. It remembers the item ID,
+// so pages don't shift when items are added or removed before it, and falls back to the offset
+// if the item itself is gone.
+func encodeCursor(offset int, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset) + ":" + id))
+}
+
+func decodeCursor(cursor string) (int, string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", ErrInvalidCursor
+	}
+
+	offsetStr, id, ok := strings.Cut(string(data), ":")
+
+	offset, err := strconv.Atoi(offsetStr)
+	if !ok || err != nil || offset < 0 {
+		return 0, "", ErrInvalidCursor
+	}
+
+	return offset, id, nil
+}
+
+// paginate cuts a page out of the sorted and filtered items
+func paginate[T any](items []T, idOf func(T) string, q pageQuery) (Page[T], error) {
+	start := 0
+
+	if q.cursor != "" {
+		offset, id, err := decodeCursor(q.cursor)
+		if err != nil {
+			return Page[T]{}, badRequest(err)
+		}
+
+		start = min(offset, len(items))
+
+		if i := slices.IndexFunc(items, func(it T) bool { return idOf(it) == id }); i >= 0 {
+			start = i + 1
+		}
+	}
+
+	end := min(start+q.limit, len(items))
+
+	page := Page[T]{
+		Items: items[start:end],
+		Total: len(items),
+	}
+
+	if end < len(items) {
+		page.NextCursor = encodeCursor(end, idOf(items[end-1]))
+	}
+
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+
+	return page, nil
+}
+
+// queryList collects a list query parameter given either repeatedly or comma-separated
+func queryList(v url.Values, key string) []string {
+	var list []string
+
+	for _, s := range v[key] {
+		for part := range strings.SplitSeq(s, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				list = append(list, part)
+			}
+		}
+	}
+
+	return list
+}
+
+// queryDate parses RFC 3339 timestamps, plain dates or anything the CLI understands (e.g. "last week").
+// Plain dates can be taken inclusively, as the end of the day.
+func queryDate(v url.Values, key string, endOfDay bool) (time.Time, error) {
+	s := strings.TrimSpace(v.Get(key))
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if ts, err := time.Parse(time.RFC3339, s); err == nil {
+		return ts, nil
+	}
+
+	if ts, err := time.Parse(time.DateOnly, s); err == nil {
+		if endOfDay {
+			ts = ts.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+
+		return ts, nil
+	}
+
+	parsed, err := dateparser.Parse(nil, s)
+	if err != nil {
+		return time.Time{}, badRequest(fmt.Errorf("invalid %s date '%s'", key, s))
+	}
+
+	return parsed.Time.UTC(), nil
+}
+
+func querySort(
+	v url.Values,
+	def friend.SortOption,
+	validate func(string) error,
+) (friend.SortOption, error) {
+	s := v.Get("sort")
+	if s == "" {
+		return def, nil
+	}
+
+	if err := validate(s); err != nil {
+		return "", badRequest(err)
+	}
+
+	return friend.SortOption(s), nil
+}
+
+func queryOrder(v url.Values, def friend.SortOrderOption) (friend.SortOrderOption, error) {
+	switch o := friend.SortOrderOption(v.Get("order")); o {
+	case "":
+		return def, nil
+	case friend.SortOrderDirect, friend.SortOrderReverse:
+		return o, nil
+	default:
+		return "", badRequest(fmt.Errorf("invalid order '%s', use 'direct' or 'reverse'", o))
+	}
+}
+
+// friendQuery reads friend list filters. Friends are sorted by name by default.
+func friendQuery(v url.Values) (friend.ListFriendQuery, error) {
+	sortBy, err := querySort(v, friend.SortAlpha, friend.ValidateEntitySortOption)
+	if err != nil {
+		return friend.ListFriendQuery{}, err
+	}
+
+	order, err := queryOrder(v, friend.SortOrderDirect)
+	if err != nil {
+		return friend.ListFriendQuery{}, err
+	}
+
+	return friend.ListFriendQuery{
+		Keyword:   strings.TrimSpace(v.Get("q")),
+		Locations: queryList(v, "locations"),
+		Tags:      queryList(v, "tags"),
+		SortBy:    sortBy,
+		SortOrder: order,
+	}, nil
+}
+
+// locationQuery reads location list filters. Locations are sorted by name by default.
+func locationQuery(v url.Values) (friend.ListLocationQuery, error) {
+	sortBy, err := querySort(v, friend.SortAlpha, friend.ValidateEntitySortOption)
+	if err != nil {
+		return friend.ListLocationQuery{}, err
+	}
+
+	order, err := queryOrder(v, friend.SortOrderDirect)
+	if err != nil {
+		return friend.ListLocationQuery{}, err
+	}
+
+	return friend.ListLocationQuery{
+		Keyword:   strings.TrimSpace(v.Get("q")),
+		Countries: queryList(v, "countries"),
+		Tags:      queryList(v, "tags"),
+		SortBy:    sortBy,
+		SortOrder: order,
+	}, nil
+}
+
+// eventQuery reads activity and note list filters. Events are listed newest first by default.
+func eventQuery(v url.Values, t friend.EventType) (friend.ListEventQuery, error) {
+	sortBy, err := querySort(v, friend.SortRecency, friend.ValidateEventSortOption)
+	if err != nil {
+		return friend.ListEventQuery{}, err
+	}
+
+	order, err := queryOrder(v, friend.SortOrderReverse)
+	if err != nil {
+		return friend.ListEventQuery{}, err
+	}
+
+	since, err := queryDate(v, "since", false)
+	if err != nil {
+		return friend.ListEventQuery{}, err
+	}
+
+	until, err := queryDate(v, "until", true)
+	if err != nil {
+		return friend.ListEventQuery{}, err
+	}
+
+	return friend.ListEventQuery{
+		Type:      t,
+		Keyword:   strings.TrimSpace(v.Get("q")),
+		Friends:   queryList(v, "friends"),
+		Locations: queryList(v, "locations"),
+		Tags:      queryList(v, "tags"),
+		Since:     since,
+		Until:     until,
+		SortBy:    sortBy,
+		SortOrder: order,
+	}, nil
+}
+
+// writePage paginates the items and writes the page
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T, idOf func(T) string) {
+	pq, err := parsePageQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := paginate(items, idOf, pq)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+func personID(p friend.Person) string     { return p.ID }
+func locationID(l friend.Location) string { return l.ID }
+func eventID(e friend.Event) string       { return e.ID }
+func feedItemID(f FeedItem) string        { return f.ID }
+
+var (
+	pageParams = []param{
+		{name: "limit", desc: fmt.Sprintf("Page size (default: %d, max: %d)", defaultPageLimit, maxPageLimit)},
+		{name: "cursor", desc: "The nextCursor of the previous page"},
+	}
+	keywordParam = param{name: "q", desc: "Search by keyword"}
+	tagsParam    = param{name: "tags", desc: "Comma-separated tags, all of which must be present"}
+	orderParam   = param{
+		name: "order",
+		desc: "Sort order",
+		enum: []string{string(friend.SortOrderDirect), string(friend.SortOrderReverse)},
+	}
+	entitySortParam = param{name: "sort", desc: "Sort by (default: alpha)", enum: sortOptions(friend.EntitySortOptions)}
+
+	friendListParams = append(slices.Clone(pageParams),
+		keywordParam,
+		tagsParam,
+		param{name: "locations", desc: "Comma-separated location IDs, any of which the friend must be linked to"},
+		entitySortParam,
+		orderParam,
+	)
+	locationListParams = append(slices.Clone(pageParams),
+		keywordParam,
+		tagsParam,
+		param{name: "countries", desc: "Comma-separated countries"},
+		entitySortParam,
+		orderParam,
+	)
+	eventListParams = append(slices.Clone(pageParams),
+		keywordParam,
+		tagsParam,
+		param{name: "friends", desc: "Comma-separated friend names, nicknames or IDs, all of which must be involved"},
+		param{name: "locations", desc: "Comma-separated location names, aliases or IDs, any of which must match"},
+		param{name: "since", desc: "Earliest date (RFC 3339, YYYY-MM-DD or relative like 'last month')"},
+		param{name: "until", desc: "Latest date, inclusive (RFC 3339, YYYY-MM-DD or relative like 'yesterday')"},
+		param{
+			name: "sort",
+			desc: "Sort by (default: recency, newest first)",
+			enum: sortOptions(friend.EventSortOptions),
+		},
+		orderParam,
+	)
+	// the feed is always sorted by date
+	feedListParams = slices.DeleteFunc(slices.Clone(eventListParams), func(p param) bool {
+		return p.name == "sort"
+	})
+)
+
+func sortOptions(opts []friend.SortOption) []string {
+	s := make([]string, 0, len(opts))
+
+	for _, o := range opts {
+		s = append(s, string(o))
+	}
+
+	return s
+}
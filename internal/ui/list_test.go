@@ -0,0 +1,148 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+
+	items := []string{"a", "b", "c", "d", "e"}
+	id := func(s string) string { return s }
+
+	page, err := paginate(items, id, pageQuery{limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, page.Items)
+	require.Equal(t, 5, page.Total)
+	require.NotEmpty(t, page.NextCursor)
+
+	// an item added before the cursor doesn't shift the next page
+	page, err = paginate(append([]string{"z"}, items...), id, pageQuery{limit: 2, cursor: page.NextCursor})
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "d"}, page.Items)
+
+	// the last item of the previous page is gone, so the offset is used
+	page, err = paginate([]string{"a", "c", "d", "e"}, id, pageQuery{limit: 2, cursor: encodeCursor(2, "b")})
+	require.NoError(t, err)
+	require.Equal(t, []string{"d", "e"}, page.Items)
+	require.Empty(t, page.NextCursor)
+
+	_, err = paginate(items, id, pageQuery{limit: 2, cursor: "not a cursor"})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestAPI_ListPagination(t *testing.T) {
+	t.Parallel()
+
+	s := file.NewTOMLFileStore(t.TempDir())
+	require.NoError(t, s.Init(t.Context()))
+
+	day := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, s.Tx(t.Context(), func(j *journal.Journal) error {
+		j.AddFriend(friend.Person{Name: "Jim Halpert", Tags: []string{"sales"}})
+		j.AddFriend(friend.Person{Name: "Pam Beesly"})
+		j.AddFriend(friend.Person{Name: "Dwight Schrute", Tags: []string{"sales"}})
+
+		for i, e := range []friend.Event{
+			{Desc: "Jim pranked Dwight", Tags: []string{"pranks"}},
+			{Desc: "Pam painted"},
+			{Desc: "Jim and Pam at the beach", Tags: []string{"date"}},
+		} {
+			e.Type = friend.EventTypeActivity
+			e.Date = day.AddDate(0, 0, i)
+
+			if _, err := j.AddEvent(e); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	srv := newTestServerWith(t, s)
+
+	var friends Page[friend.Person]
+
+	status := doRequest(t, srv, http.MethodGet, "/api/friends?limit=2", "", "", &friends)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, 3, friends.Total)
+	require.Equal(t, []string{"Dwight Schrute", "Jim Halpert"}, names(friends.Items))
+
+	var next Page[friend.Person]
+
+	status = doRequest(t, srv, http.MethodGet, "/api/friends?limit=2&cursor="+friends.NextCursor, "", "", &next)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, []string{"Pam Beesly"}, names(next.Items))
+	require.Empty(t, next.NextCursor)
+
+	status = doRequest(t, srv, http.MethodGet, "/api/friends?tags=sales&order=reverse", "", "", &friends)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, []string{"Jim Halpert", "Dwight Schrute"}, names(friends.Items))
+
+	var activities Page[friend.Event]
+
+	// newest first by default
+	status = doRequest(t, srv, http.MethodGet, "/api/activities?limit=1", "", "", &activities)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, 3, activities.Total)
+	require.Equal(t, "Jim and Pam at the beach", activities.Items[0].Desc)
+
+	status = doRequest(t, srv, http.MethodGet, "/api/activities?friends=pam&until=2024-05-02", "", "", &activities)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, 1, activities.Total)
+	require.Equal(t, "Pam painted", activities.Items[0].Desc)
+
+	status = doRequest(t, srv, http.MethodGet, "/api/friends/jim-halpert/activities?tags=pranks", "", "", &activities)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, 1, activities.Total)
+
+	var feed Page[FeedItem]
+
+	status = doRequest(t, srv, http.MethodGet, "/api/feed?q=jim&limit=10", "", "", &feed)
+	require.Equal(t, http.StatusOK, status)
+	// two activities and the friend addition
+	require.Equal(t, 3, feed.Total)
+
+	for _, bad := range []string{
+		"/api/friends?limit=0",
+		"/api/friends?sort=nope",
+		"/api/notes?order=sideways",
+		"/api/notes?cursor=%21",
+		"/api/activities?friends=creed",
+		"/api/feed?sort=alpha",
+	} {
+		require.Equal(t, http.StatusBadRequest, doRequest(t, srv, http.MethodGet, bad, "", "", nil), bad)
+	}
+}
+
+func names(friends []friend.Person) []string {
+	n := make([]string, 0, len(friends))
+
+	for _, f := range friends {
+		n = append(n, f.Name)
+	}
+
+	return n
+}
@@ -0,0 +1,368 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/version"
+)
+
+const openAPIVersion = "3.0.3"
+
+var pathParamRe = regexp.MustCompile(`\{(\w+)\}`)
+
+// OpenAPI is an OpenAPI 3 document
+type OpenAPI struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       OpenAPIInfo                      `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+	Security   []map[string][]string            `json:"security"`
+}
+
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Tags        []string              `json:"tags"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+	In     string `json:"in,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+// Schema is a subset of the OpenAPI schema object that covers the API types
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// schemaRegistry derives schemas from the Go types the handlers encode,
+// registering every named struct as a reusable component.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+)
+
+func (r *schemaRegistry) schemaOf(t reflect.Type) *Schema { //nolint:cyclop
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case durationType:
+		return &Schema{Type: "integer", Format: "int64", Description: "Duration in nanoseconds"}
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		s := r.schemaOf(t.Elem())
+
+		if s.Ref != "" {
+			return s
+		}
+
+		s.Nullable = true
+
+		return s
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		return r.structRef(t)
+	default:
+		// interfaces can hold any value
+		return &Schema{}
+	}
+}
+
+func (r *schemaRegistry) structRef(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return r.structSchema(t)
+	}
+
+	name, ok := r.names[t]
+
+	if !ok {
+		name = schemaName(t)
+
+		if _, taken := r.schemas[name]; taken {
+			pkg := path.Base(t.PkgPath())
+			name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+		}
+
+		r.names[t] = name
+		r.schemas[name] = &Schema{} // placeholder for recursive types
+		r.schemas[name] = r.structSchema(t)
+	}
+
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// schemaName names generic types after their type arguments (e.g. Page[friend.Person] becomes PersonPage)
+func schemaName(t reflect.Type) string {
+	name, args, generic := strings.Cut(t.Name(), "[")
+	if !generic {
+		return name
+	}
+
+	var prefix string
+
+	for arg := range strings.SplitSeq(strings.TrimSuffix(args, "]"), ",") {
+		prefix += arg[strings.LastIndex(arg, ".")+1:]
+	}
+
+	return prefix + name
+}
+
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	r.addFields(s, t)
+
+	return s
+}
+
+func (r *schemaRegistry) addFields(s *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+
+		tag, hasTag := f.Tag.Lookup("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && !hasTag && f.Type.Kind() == reflect.Struct {
+			r.addFields(s, f.Type)
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		s.Properties[name] = r.schemaOf(f.Type)
+
+		if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// buildOpenAPI describes the API routes as an OpenAPI document
+func buildOpenAPI(routes []route) *OpenAPI {
+	reg := newSchemaRegistry()
+
+	doc := &OpenAPI{
+		OpenAPI: openAPIVersion,
+		Info: OpenAPIInfo{
+			Title: "frens",
+			Description: "API of the frens web UI (frens serve). " +
+				"Write endpoints accept JSON or frentxt bodies sent as text/plain, " +
+				"the same syntax the CLI uses.",
+			Version: version.Version,
+		},
+		Paths: make(map[string]map[string]*Operation),
+		Components: Components{
+			Schemas: reg.schemas,
+			SecuritySchemes: map[string]*SecurityScheme{
+				"bearerAuth":  {Type: "http", Scheme: "bearer"},
+				"sessionAuth": {Type: "apiKey", In: "cookie", Name: sessionCookie},
+				"feedKey":     {Type: "apiKey", In: "query", Name: FeedKeyParam},
+			},
+		},
+		Security: defaultSecurity(),
+	}
+
+	for _, rt := range routes {
+		ops, ok := doc.Paths[rt.path]
+		if !ok {
+			ops = make(map[string]*Operation)
+			doc.Paths[rt.path] = ops
+		}
+
+		ops[strings.ToLower(rt.method)] = rt.operation(reg)
+	}
+
+	return doc
+}
+
+func defaultSecurity() []map[string][]string {
+	return []map[string][]string{{"bearerAuth": {}}, {"sessionAuth": {}}}
+}
+
+func (rt route) operation(reg *schemaRegistry) *Operation {
+	op := &Operation{
+		OperationID: rt.id,
+		Summary:     rt.summary,
+		Tags:        []string{strings.TrimSuffix(strings.Split(rt.path, "/")[2], ".json")},
+		Responses: map[string]*Response{
+			"default": {
+				Description: "Error",
+				Content:     map[string]*MediaType{"text/plain": {Schema: &Schema{Type: "string"}}},
+			},
+		},
+	}
+
+	for _, m := range pathParamRe.FindAllStringSubmatch(rt.path, -1) {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+
+	for _, q := range rt.query {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:        q.name,
+			In:          "query",
+			Description: q.desc,
+			Schema:      &Schema{Type: "string", Enum: q.enum},
+		})
+	}
+
+	if rt.feed {
+		op.Security = append(defaultSecurity(), map[string][]string{"feedKey": {}})
+	}
+
+	if rt.request != nil {
+		content := map[string]*MediaType{
+			"application/json": {Schema: reg.schemaOf(reflect.TypeOf(rt.request))},
+		}
+
+		if rt.text {
+			content["text/plain"] = &MediaType{Schema: &Schema{Type: "string", Description: "frentxt"}}
+		}
+
+		op.RequestBody = &RequestBody{Required: true, Content: content}
+	}
+
+	status := rt.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	resp := &Response{Description: http.StatusText(status)}
+
+	if rt.response != nil {
+		contentType := "application/json"
+
+		if rt.stream {
+			contentType = "text/event-stream"
+			resp.Description = "Events named after the change type with the change as JSON data"
+		}
+
+		resp.Content = map[string]*MediaType{
+			contentType: {Schema: reg.schemaOf(reflect.TypeOf(rt.response))},
+		}
+	}
+
+	if rt.produces != "" {
+		resp.Content = map[string]*MediaType{rt.produces: {Schema: &Schema{Type: "string"}}}
+	}
+
+	op.Responses[strconv.Itoa(status)] = resp
+
+	return op
+}
+
+// handleGetOpenAPI returns the OpenAPI document of the API.
+func (a *API) handleGetOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, a.spec())
+}
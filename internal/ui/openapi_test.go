@@ -0,0 +1,77 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_OpenAPI(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	var doc OpenAPI
+
+	status := doRequest(t, srv, http.MethodGet, "/api/openapi.json", "", "", &doc)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, openAPIVersion, doc.OpenAPI)
+
+	ids := make(map[string]bool)
+
+	for _, rt := range NewAPI(nil).routes() {
+		op := doc.Paths[rt.path][strings.ToLower(rt.method)]
+		require.NotNil(t, op, "%s %s is not documented", rt.method, rt.path)
+
+		require.False(t, ids[op.OperationID], "duplicate operation ID %s", op.OperationID)
+		ids[op.OperationID] = true
+
+		for _, p := range op.Parameters {
+			if p.In == "path" {
+				require.Contains(t, rt.path, "{"+p.Name+"}")
+			}
+		}
+	}
+
+	person := doc.Components.Schemas["Person"]
+	require.NotNil(t, person)
+	require.Contains(t, person.Required, "name")
+	require.NotContains(t, person.Required, "nicknames")
+	require.Equal(t, "#/components/schemas/Contact", person.Properties["contacts"].Items.Ref)
+	require.Equal(t, "date-time", person.Properties["createdAt"].Format)
+	require.NotContains(t, person.Properties, "Score")
+
+	result := doc.Components.Schemas["EventResult"]
+	require.NotNil(t, result)
+	require.Contains(t, result.Properties, "description")
+	require.Contains(t, result.Properties, "unknownLocations")
+
+	createActivity := doc.Paths["/api/activities"]["post"]
+	require.Contains(t, createActivity.RequestBody.Content, "text/plain")
+	require.Equal(t, "#/components/schemas/Event", createActivity.RequestBody.Content["application/json"].Schema.Ref)
+	require.Contains(t, createActivity.Responses, "201")
+
+	deleteFriend := doc.Paths["/api/friends/{id}"]["delete"]
+	require.Len(t, deleteFriend.Parameters, 2)
+	require.Equal(t, []string{"detach", "cascade"}, deleteFriend.Parameters[1].Schema.Enum)
+	require.Empty(t, deleteFriend.Responses["204"].Content)
+
+	stream := doc.Paths["/api/events/stream"]["get"]
+	require.Contains(t, stream.Responses["200"].Content, "text/event-stream")
+}
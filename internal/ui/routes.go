@@ -0,0 +1,262 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"net/http"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/history"
+	"github.com/roma-glushko/frens/internal/journal"
+)
+
+// route describes an API endpoint. The same table feeds the mux and the OpenAPI document,
+// so the published spec can't drift away from the handlers.
+type route struct {
+	id      string
+	method  string
+	path    string
+	summary string
+	handler http.HandlerFunc
+
+	query    []param
+	request  any    // JSON request body, nil if the endpoint takes none
+	text     bool   // the body can also be sent as frentxt (Content-Type: text/plain)
+	status   int    // success status, http.StatusOK if not set
+	response any    // JSON response body, nil if the endpoint returns none
+	stream   bool   // the response is a Server-Sent Events stream
+	produces string // the content type of a non-JSON response
+	feed     bool   // the endpoint also accepts the read-only feed key
+	write    bool   // the endpoint changes the journal
+}
+
+// param is a query parameter
+type param struct {
+	name string
+	desc string
+	enum []string
+}
+
+var removeModeParam = param{
+	name: "mode",
+	desc: "What to do with activities and notes that reference the entity (default: detach). " +
+		"Cascade also deletes the ones left without any friend or location",
+	enum: []string{string(journal.RemoveModeDetach), string(journal.RemoveModeCascade)},
+}
+
+func (a *API) routes() []route { //nolint:funlen,maintidx
+	return []route{
+		{
+			id: "getOpenAPI", method: http.MethodGet, path: "/api/openapi.json",
+			summary: "Get the OpenAPI document of this API", handler: a.handleGetOpenAPI,
+			response: map[string]any{},
+		},
+		{
+			id: "listFriends", method: http.MethodGet, path: "/api/friends",
+			summary: "List friends", handler: a.handleListFriends,
+			query: friendListParams, response: Page[friend.Person]{},
+		},
+		{
+			id: "createFriend", method: http.MethodPost, path: "/api/friends",
+			summary: "Add a friend", handler: a.handleCreateFriend, write: true,
+			request: friend.Person{}, text: true, status: http.StatusCreated, response: friend.Person{},
+		},
+		{
+			id: "getFriend", method: http.MethodGet, path: "/api/friends/{id}",
+			summary: "Get a friend", handler: a.handleGetFriend,
+			response: friend.Person{},
+		},
+		{
+			id: "updateFriend", method: http.MethodPatch, path: "/api/friends/{id}",
+			summary: "Update a friend", handler: a.handleUpdateFriend, write: true,
+			request: friend.Person{}, text: true, response: friend.Person{},
+		},
+		{
+			id: "deleteFriend", method: http.MethodDelete, path: "/api/friends/{id}",
+			summary: "Delete a friend", handler: a.handleDeleteFriend, write: true,
+			query: []param{removeModeParam}, status: http.StatusNoContent,
+		},
+		{
+			id: "listFriendActivities", method: http.MethodGet, path: "/api/friends/{id}/activities",
+			summary: "List activities with a friend", handler: a.handleGetFriendActivities,
+			query: eventListParams, response: Page[friend.Event]{},
+		},
+		{
+			id: "listFriendNotes", method: http.MethodGet, path: "/api/friends/{id}/notes",
+			summary: "List notes about a friend", handler: a.handleGetFriendNotes,
+			query: eventListParams, response: Page[friend.Event]{},
+		},
+		{
+			id: "getFriendHistory", method: http.MethodGet, path: "/api/friends/{id}/history",
+			summary: "List committed changes of a friend, newest first", handler: a.handleGetFriendHistory,
+			response: []history.Revision{},
+		},
+		{
+			id: "createContacts", method: http.MethodPost, path: "/api/friends/{id}/contacts",
+			summary: "Add contacts to a friend", handler: a.handleCreateContacts, write: true,
+			request: friend.Contact{}, text: true, status: http.StatusCreated, response: []friend.Contact{},
+		},
+		{
+			id: "updateContact", method: http.MethodPatch, path: "/api/friends/{id}/contacts/{contactID}",
+			summary: "Update a friend's contact", handler: a.handleUpdateContact, write: true,
+			request: friend.Contact{}, text: true, response: friend.Contact{},
+		},
+		{
+			id: "deleteContact", method: http.MethodDelete, path: "/api/friends/{id}/contacts/{contactID}",
+			summary: "Delete a friend's contact", handler: a.handleDeleteContact, write: true,
+			status: http.StatusNoContent,
+		},
+		{
+			id: "createDate", method: http.MethodPost, path: "/api/friends/{id}/dates",
+			summary: "Add an important date to a friend", handler: a.handleCreateDate, write: true,
+			request: friend.Date{}, text: true, status: http.StatusCreated, response: friend.Date{},
+		},
+		{
+			id: "updateDate", method: http.MethodPatch, path: "/api/friends/{id}/dates/{dateID}",
+			summary: "Update a friend's date", handler: a.handleUpdateDate, write: true,
+			request: friend.Date{}, text: true, response: friend.Date{},
+		},
+		{
+			id: "deleteDate", method: http.MethodDelete, path: "/api/friends/{id}/dates/{dateID}",
+			summary: "Delete a friend's date", handler: a.handleDeleteDate, write: true,
+			status: http.StatusNoContent,
+		},
+		{
+			id: "createWishlistItem", method: http.MethodPost, path: "/api/friends/{id}/wishlist",
+			summary: "Add an item to a friend's wishlist", handler: a.handleCreateWishlistItem, write: true,
+			request: friend.WishlistItem{}, text: true, status: http.StatusCreated, response: friend.WishlistItem{},
+		},
+		{
+			id: "updateWishlistItem", method: http.MethodPatch, path: "/api/friends/{id}/wishlist/{itemID}",
+			summary: "Update a friend's wishlist item", handler: a.handleUpdateWishlistItem, write: true,
+			request: friend.WishlistItem{}, text: true, response: friend.WishlistItem{},
+		},
+		{
+			id: "deleteWishlistItem", method: http.MethodDelete, path: "/api/friends/{id}/wishlist/{itemID}",
+			summary: "Delete a friend's wishlist item", handler: a.handleDeleteWishlistItem, write: true,
+			status: http.StatusNoContent,
+		},
+		{
+			id: "listLocations", method: http.MethodGet, path: "/api/locations",
+			summary: "List locations", handler: a.handleListLocations,
+			query: locationListParams, response: Page[friend.Location]{},
+		},
+		{
+			id: "createLocation", method: http.MethodPost, path: "/api/locations",
+			summary: "Add a location", handler: a.handleCreateLocation, write: true,
+			request: friend.Location{}, text: true, status: http.StatusCreated, response: friend.Location{},
+		},
+		{
+			id: "getLocation", method: http.MethodGet, path: "/api/locations/{id}",
+			summary: "Get a location", handler: a.handleGetLocation,
+			response: friend.Location{},
+		},
+		{
+			id: "updateLocation", method: http.MethodPatch, path: "/api/locations/{id}",
+			summary: "Update a location", handler: a.handleUpdateLocation, write: true,
+			request: friend.Location{}, text: true, response: friend.Location{},
+		},
+		{
+			id: "deleteLocation", method: http.MethodDelete, path: "/api/locations/{id}",
+			summary: "Delete a location", handler: a.handleDeleteLocation, write: true,
+			query: []param{removeModeParam}, status: http.StatusNoContent,
+		},
+		{
+			id: "listLocationActivities", method: http.MethodGet, path: "/api/locations/{id}/activities",
+			summary: "List activities at a location", handler: a.handleGetLocationActivities,
+			query: eventListParams, response: Page[friend.Event]{},
+		},
+		{
+			id: "listLocationNotes", method: http.MethodGet, path: "/api/locations/{id}/notes",
+			summary: "List notes about a location", handler: a.handleGetLocationNotes,
+			query: eventListParams, response: Page[friend.Event]{},
+		},
+		{
+			id: "listActivities", method: http.MethodGet, path: "/api/activities",
+			summary: "List activities", handler: a.handleListActivities,
+			query: eventListParams, response: Page[friend.Event]{},
+		},
+		{
+			id: "createActivity", method: http.MethodPost, path: "/api/activities",
+			summary: "Log an activity", handler: a.handleCreateEvent(friend.EventTypeActivity), write: true,
+			request: friend.Event{}, text: true, status: http.StatusCreated, response: EventResult{},
+		},
+		{
+			id: "updateActivity", method: http.MethodPatch, path: "/api/activities/{id}",
+			summary: "Update an activity", handler: a.handleUpdateEvent(friend.EventTypeActivity), write: true,
+			request: friend.Event{}, text: true, response: EventResult{},
+		},
+		{
+			id: "deleteActivity", method: http.MethodDelete, path: "/api/activities/{id}",
+			summary: "Delete an activity", handler: a.handleDeleteEvent(friend.EventTypeActivity), write: true,
+			status: http.StatusNoContent,
+		},
+		{
+			id: "getActivityHistory", method: http.MethodGet, path: "/api/activities/{id}/history",
+			summary: "List committed changes of an activity, newest first", handler: a.handleGetActivityHistory,
+			response: []history.Revision{},
+		},
+		{
+			id: "listNotes", method: http.MethodGet, path: "/api/notes",
+			summary: "List notes", handler: a.handleListNotes,
+			query: eventListParams, response: Page[friend.Event]{},
+		},
+		{
+			id: "createNote", method: http.MethodPost, path: "/api/notes",
+			summary: "Add a note", handler: a.handleCreateEvent(friend.EventTypeNote), write: true,
+			request: friend.Event{}, text: true, status: http.StatusCreated, response: EventResult{},
+		},
+		{
+			id: "updateNote", method: http.MethodPatch, path: "/api/notes/{id}",
+			summary: "Update a note", handler: a.handleUpdateEvent(friend.EventTypeNote), write: true,
+			request: friend.Event{}, text: true, response: EventResult{},
+		},
+		{
+			id: "deleteNote", method: http.MethodDelete, path: "/api/notes/{id}",
+			summary: "Delete a note", handler: a.handleDeleteEvent(friend.EventTypeNote), write: true,
+			status: http.StatusNoContent,
+		},
+		{
+			id: "getStats", method: http.MethodGet, path: "/api/stats",
+			summary: "Count journal entities", handler: a.handleGetStats,
+			response: journal.Stats{},
+		},
+		{
+			id: "getComprehensiveStats", method: http.MethodGet, path: "/api/stats/comprehensive",
+			summary: "Get rankings, the activity timeline and insights", handler: a.handleGetComprehensiveStats,
+			response: ComprehensiveStats{},
+		},
+		{
+			id: "getSyncStatus", method: http.MethodGet, path: "/api/sync/status",
+			summary: "Get the git sync status of the journal", handler: a.handleGetSyncStatus,
+			response: SyncStatus{},
+		},
+		{
+			id: "getFeed", method: http.MethodGet, path: "/api/feed",
+			summary: "Get the feed of recent journal entries", handler: a.handleGetFeed,
+			query: feedListParams, response: Page[FeedItem]{},
+		},
+		{
+			id: "getCalendar", method: http.MethodGet, path: CalendarPath,
+			summary: "Get friend dates as an iCalendar feed", handler: a.handleGetCalendar,
+			query: calendarParams, produces: "text/calendar", feed: true,
+		},
+		{
+			id: "streamChanges", method: http.MethodGet, path: "/api/events/stream",
+			summary: "Stream journal changes as Server-Sent Events", handler: a.handleEventStream,
+			response: ChangeEvent{}, stream: true,
+		},
+	}
+}
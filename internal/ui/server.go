@@ -32,19 +32,49 @@ type Server struct {
 	server *http.Server
 	logger *log.Logger
 	store  store.Store
+
+	token    string
+	insecure bool
+	stopFeed context.CancelFunc
+}
+
+type Option func(*Server)
+
+// WithToken requires the access token for every request.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithInsecureBind allows serving the journal without authentication on non-loopback addresses.
+func WithInsecureBind() Option {
+	return func(s *Server) {
+		s.insecure = true
+	}
 }
 
 // NewServer creates a new UI server.
-func NewServer(addr string, logger *log.Logger, s store.Store) *Server {
-	return &Server{
+func NewServer(addr string, logger *log.Logger, s store.Store, opts ...Option) *Server {
+	srv := &Server{
 		addr:   addr,
 		logger: logger,
 		store:  s,
 	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	return srv
 }
 
 // Start starts the HTTP server and returns the actual address it's listening on.
 func (s *Server) Start(ctx context.Context) (string, error) {
+	if s.token == "" && !s.insecure && !isLoopback(s.addr) {
+		return "", fmt.Errorf("%w: %s", ErrInsecureBind, s.addr)
+	}
+
 	assets, err := Assets()
 	if err != nil {
 		return "", fmt.Errorf("failed to load UI assets: %w", err)
@@ -55,6 +85,10 @@ func (s *Server) Start(ctx context.Context) (string, error) {
 	api := NewAPI(s.store)
 	api.RegisterRoutes(mux)
 
+	if err := api.feed.Refresh(ctx); err != nil {
+		s.logger.Warn("Failed to load the journal for live updates", "error", err)
+	}
+
 	// Serve static files with SPA fallback
 	fileServer := http.FileServer(http.FS(assets))
 
@@ -99,13 +133,28 @@ func (s *Server) Start(ctx context.Context) (string, error) {
 
 	actualAddr := listener.Addr().String()
 
+	var handler http.Handler = mux
+
+	if s.token != "" {
+		handler = newAuth(s.token).middleware(handler)
+	}
+
 	s.server = &http.Server{
-		Handler:      mux,
+		Handler:      sameOrigin(handler),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
+	feedCtx, stopFeed := context.WithCancel(context.WithoutCancel(ctx))
+	s.stopFeed = stopFeed
+
+	go api.feed.Run(feedCtx, func(err error) {
+		s.logger.Warn("Failed to refresh live updates", "error", err)
+	})
+
+	s.server.RegisterOnShutdown(api.feed.Close)
+
 	go func() {
 		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			s.logger.Error("Server error", "error", err)
@@ -117,6 +166,10 @@ func (s *Server) Start(ctx context.Context) (string, error) {
 
 // Stop gracefully shuts down the server.
 func (s *Server) Stop(ctx context.Context) error {
+	if s.stopFeed != nil {
+		s.stopFeed()
+	}
+
 	if s.server == nil {
 		return nil
 	}
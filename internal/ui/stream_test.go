@@ -0,0 +1,135 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeFeed_Refresh(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	dir := t.TempDir()
+	s := file.NewTOMLFileStore(dir)
+
+	require.NoError(t, s.Init(ctx))
+	require.NoError(t, s.Tx(ctx, func(j *journal.Journal) error {
+		j.AddFriend(friend.Person{Name: "Jim Halpert"})
+		j.AddFriend(friend.Person{Name: "Dwight Schrute"})
+
+		return nil
+	}))
+
+	feed := newChangeFeed(s)
+	require.NoError(t, feed.Refresh(ctx))
+
+	changes, unsubscribe := feed.Subscribe()
+	defer unsubscribe()
+
+	// another process changes the journal
+	other := file.NewTOMLFileStore(dir)
+
+	require.NoError(t, other.Tx(ctx, func(j *journal.Journal) error {
+		jim, err := j.GetFriend("jim")
+		if err != nil {
+			return err
+		}
+
+		upd := jim
+		upd.Desc = "a prankster"
+
+		j.UpdateFriend(jim, upd)
+		j.RemoveFriends([]friend.Person{{ID: "dwight-schrute"}}, journal.RemoveModeDetach)
+
+		_, err = j.AddEvent(friend.Event{Type: friend.EventTypeNote, Date: time.Now(), Desc: "Jim likes pranks"})
+
+		return err
+	}))
+
+	require.NoError(t, feed.Refresh(ctx))
+
+	got := make(map[string]string)
+
+	for range 3 {
+		c := <-changes
+		got[c.Type] = c.ID
+	}
+
+	require.Equal(t, "jim-halpert", got["friend.updated"])
+	require.Equal(t, "dwight-schrute", got["friend.deleted"])
+	require.Contains(t, got, "note.created")
+	require.Empty(t, changes)
+}
+
+func TestAPI_EventStream(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+"/api/events/stream", nil)
+	require.NoError(t, err)
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, ": connected\n", line)
+
+	status := doRequest(t, srv, http.MethodPost, "/api/friends", "text/plain", "Pam Beesly", nil)
+	require.Equal(t, http.StatusCreated, status)
+
+	var lines []string
+
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		if line == "\n" && len(lines) > 0 {
+			break
+		}
+
+		if line != "\n" {
+			lines = append(lines, strings.TrimSuffix(line, "\n"))
+		}
+	}
+
+	require.Len(t, lines, 3)
+	require.Equal(t, "id: 1", lines[0])
+	require.Equal(t, "event: friend.created", lines[1])
+
+	var c ChangeEvent
+
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[2], "data: ")), &c))
+	require.Equal(t, "friend", c.Entity)
+	require.Equal(t, "pam-beesly", c.ID)
+}
@@ -0,0 +1,462 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcard
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/tag"
+)
+
+var ErrInvalidCard = errors.New("invalid vCard")
+
+// profileHosts recognizes social profiles by their URLs
+var profileHosts = map[string]friend.ContactType{
+	"twitter.com":   friend.ContactTypeTwitter,
+	"x.com":         friend.ContactTypeTwitter,
+	"linkedin.com":  friend.ContactTypeLinkedIn,
+	"github.com":    friend.ContactTypeGitHub,
+	"instagram.com": friend.ContactTypeInstagram,
+	"facebook.com":  friend.ContactTypeFacebook,
+}
+
+// services recognizes messengers by IMPP URI schemes and service types
+var services = map[string]friend.ContactType{
+	"tg":       friend.ContactTypeTelegram,
+	"telegram": friend.ContactTypeTelegram,
+	"whatsapp": friend.ContactTypeWhatsApp,
+	"sgnl":     friend.ContactTypeSignal,
+	"signal":   friend.ContactTypeSignal,
+	"discord":  friend.ContactTypeDiscord,
+	"slack":    friend.ContactTypeSlack,
+}
+
+// property is a content line like `TEL;TYPE=cell:+1 555 0100`
+type property struct {
+	name   string
+	params map[string][]string
+	value  string
+}
+
+func (p property) param(name string) string {
+	if v := p.params[name]; len(v) > 0 {
+		return strings.ToLower(v[0])
+	}
+
+	return ""
+}
+
+func (p property) hasParam(name, value string) bool {
+	for _, v := range p.params[name] {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Decode reads vCard 3.0 and 4.0 cards. Properties frens doesn't keep are ignored.
+func Decode(r io.Reader) ([]Card, error) { //nolint:cyclop
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		cards []Card
+		card  *Card
+		// cards may nest others (e.g. AGENT in vCard 3.0), only top-level ones are decoded
+		depth int
+	)
+
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+
+		p, err := parseProperty(l)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case p.name == "BEGIN" && strings.EqualFold(p.value, "VCARD"):
+			depth++
+
+			if depth == 1 {
+				card = &Card{}
+			}
+		case p.name == "END" && strings.EqualFold(p.value, "VCARD"):
+			if depth == 0 {
+				return nil, fmt.Errorf("%w: END without BEGIN", ErrInvalidCard)
+			}
+
+			depth--
+
+			if depth == 0 {
+				if card.complete() {
+					cards = append(cards, *card)
+				}
+
+				card = nil
+			}
+		case card == nil:
+			return nil, fmt.Errorf("%w: %s outside of a card", ErrInvalidCard, p.name)
+		case depth == 1:
+			card.apply(p)
+		}
+	}
+
+	if card != nil {
+		return nil, fmt.Errorf("%w: unterminated card", ErrInvalidCard)
+	}
+
+	return cards, nil
+}
+
+// unfold joins folded lines
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024) // photos are embedded as long lines
+
+	for sc.Scan() {
+		l := strings.TrimRight(sc.Text(), "\r")
+
+		if (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+
+		lines = append(lines, l)
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read vCards: %w", err)
+	}
+
+	return lines, nil
+}
+
+// parseProperty parses `[group.]NAME[;PARAM=VALUE[,VALUE]...]:VALUE`
+func parseProperty(line string) (property, error) { //nolint:cyclop
+	p := property{params: make(map[string][]string)}
+
+	i := strings.IndexAny(line, ";:")
+	if i < 0 {
+		return p, fmt.Errorf("%w: no value in '%s'", ErrInvalidCard, line)
+	}
+
+	p.name = strings.ToUpper(line[:i])
+
+	if dot := strings.LastIndex(p.name, "."); dot >= 0 {
+		p.name = p.name[dot+1:]
+	}
+
+	rest := line[i:]
+
+	for strings.HasPrefix(rest, ";") {
+		rest = rest[1:]
+
+		end := strings.IndexAny(rest, ";:")
+		if end < 0 {
+			return p, fmt.Errorf("%w: no value in '%s'", ErrInvalidCard, line)
+		}
+
+		key, value, hasValue := strings.Cut(rest[:end], "=")
+
+		if hasValue && strings.HasPrefix(value, `"`) {
+			// quoted values may contain separators
+			closing := strings.Index(rest[len(key)+2:], `"`)
+			if closing < 0 {
+				return p, fmt.Errorf("%w: unterminated quote in '%s'", ErrInvalidCard, line)
+			}
+
+			value = rest[len(key)+2 : len(key)+2+closing]
+			end = len(key) + 2 + closing + 1
+
+			p.params[strings.ToLower(key)] = append(p.params[strings.ToLower(key)], value)
+		} else {
+			if !hasValue {
+				// vCard 2.1 style parameters like TEL;CELL:...
+				key, value = "type", key
+			}
+
+			for v := range strings.SplitSeq(value, ",") {
+				p.params[strings.ToLower(key)] = append(p.params[strings.ToLower(key)], v)
+			}
+		}
+
+		rest = rest[end:]
+	}
+
+	p.value = strings.TrimPrefix(rest, ":")
+
+	return p, nil
+}
+
+func (c *Card) apply(p property) { //nolint:cyclop
+	switch p.name {
+	case "UID":
+		c.UID = unescape(p.value)
+	case "FN":
+		c.Person.Name = strings.TrimSpace(unescape(p.value))
+	case "N":
+		if c.Person.Name == "" {
+			n := splitUnescaped(p.value, ';')
+
+			for len(n) < 3 {
+				n = append(n, "")
+			}
+
+			c.Person.Name = strings.Join(strings.Fields(strings.Join([]string{n[1], n[2], n[0]}, " ")), " ")
+		}
+	case "NICKNAME":
+		c.Person.Nicknames = appendUnique(c.Person.Nicknames, splitList(p.value)...)
+	case "NOTE":
+		c.Person.Desc = unescape(p.value)
+	case "CATEGORIES":
+		for _, t := range splitList(p.value) {
+			// tags are single words, e.g. "Dunder Mifflin" becomes #dunder-mifflin
+			c.Person.Tags = appendUnique(c.Person.Tags, strings.Join(strings.Fields(tag.NewTag(t).Name), "-"))
+		}
+	case "EMAIL":
+		c.addContact(friend.ContactTypeEmail, strings.TrimPrefix(unescape(p.value), "mailto:"))
+	case "TEL":
+		c.addContact(friend.ContactTypePhone, strings.TrimPrefix(unescape(p.value), "tel:"))
+	case "IMPP":
+		c.addContact(imppContact(p))
+	case "X-SOCIALPROFILE", "SOCIALPROFILE":
+		c.addContact(socialContact(p))
+	case "URL":
+		c.addContact(friend.ContactTypeOther, unescape(p.value))
+	case "BDAY":
+		c.addDate("Birthday", p)
+	case "ANNIVERSARY", "X-ANNIVERSARY":
+		c.addDate("Anniversary", p)
+	case "ADR":
+		a := splitUnescaped(p.value, ';')
+
+		for len(a) < 7 {
+			a = append(a, "")
+		}
+
+		addr := Address{Street: a[2], Locality: a[3], Region: a[4], PostalCode: a[5], Country: a[6]}
+
+		if addr.Place() != "" {
+			c.Addresses = append(c.Addresses, addr)
+		}
+	}
+}
+
+// complete tells whether the card describes a person frens can add
+func (c *Card) complete() bool {
+	return c.Person.Name != ""
+}
+
+func (c *Card) addContact(t friend.ContactType, value string) {
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return
+	}
+
+	c.Person.Contacts = append(c.Person.Contacts, &friend.Contact{Type: t, Value: value})
+}
+
+func (c *Card) addDate(desc string, p property) {
+	d := parseDate(p)
+	if d.DateExpr == "" {
+		return
+	}
+
+	d.Desc = desc
+	c.Person.Dates = append(c.Person.Dates, &d)
+}
+
+// imppContact maps instant messaging URIs like "tg:bigtuna" or "x-apple:bigtuna" (with X-SERVICE-TYPE=Telegram)
+func imppContact(p property) (friend.ContactType, string) {
+	value := unescape(p.value)
+	scheme, handle, ok := strings.Cut(value, ":")
+
+	if !ok {
+		scheme, handle = "", value
+	}
+
+	if t, ok := services[p.param("x-service-type")]; ok {
+		return t, handle
+	}
+
+	if t, ok := services[strings.ToLower(scheme)]; ok {
+		return t, handle
+	}
+
+	return friend.ContactTypeOther, value
+}
+
+// socialContact maps social profiles by their type or URL, e.g. "https://github.com/bigtuna"
+func socialContact(p property) (friend.ContactType, string) {
+	value := unescape(p.value)
+
+	for _, t := range p.params["type"] {
+		if ct := friend.ParseContactType(t); ct != friend.ContactTypeOther {
+			return ct, value
+		}
+
+		if strings.EqualFold(t, "x") {
+			return friend.ContactTypeTwitter, value
+		}
+	}
+
+	if u, err := url.Parse(value); err == nil {
+		if t, ok := profileHosts[strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")]; ok {
+			return t, value
+		}
+	}
+
+	return friend.ContactTypeOther, value
+}
+
+// parseDate converts vCard dates like "19781001", "1978-10-01", "--1001" or "--10-01" to date expressions
+func parseDate(p property) friend.Date {
+	value := strings.TrimSpace(unescape(p.value))
+
+	if cal := p.param("calscale"); cal != "" && cal != friend.CalendarGregorian {
+		return friend.Date{Calendar: cal, DateExpr: value}
+	}
+
+	d := friend.Date{Calendar: friend.CalendarGregorian, DateExpr: value}
+
+	if p.param("value") == "text" {
+		return d
+	}
+
+	date, _, _ := strings.Cut(value, "T")
+	noYear := strings.HasPrefix(date, "--")
+	digits := strings.ReplaceAll(strings.TrimPrefix(date, "--"), "-", "")
+
+	var year, month, day int
+
+	switch {
+	case noYear && len(digits) == 4:
+		month, _ = strconv.Atoi(digits[:2])
+		day, _ = strconv.Atoi(digits[2:])
+	case !noYear && len(digits) == 8:
+		year, _ = strconv.Atoi(digits[:4])
+		month, _ = strconv.Atoi(digits[4:6])
+		day, _ = strconv.Atoi(digits[6:])
+	default:
+		return d
+	}
+
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return d
+	}
+
+	// Apple Contacts stores dates without a year with a placeholder year
+	if omit := p.param("x-apple-omit-year"); omit != "" && omit == strconv.Itoa(year) {
+		year = 0
+	}
+
+	if year == 0 {
+		d.DateExpr = fmt.Sprintf("%s %d", time.Month(month), day)
+	} else {
+		d.DateExpr = fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+	}
+
+	return d
+}
+
+func unescape(s string) string {
+	var sb strings.Builder
+
+	escaped := false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			if r == 'n' || r == 'N' {
+				r = '\n'
+			}
+
+			sb.WriteRune(r)
+
+			escaped = false
+		case r == '\\':
+			escaped = true
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
+
+// splitUnescaped splits the value by the separator unless it's escaped and unescapes the parts
+func splitUnescaped(s string, sep rune) []string {
+	var (
+		parts   []string
+		start   int
+		escaped bool
+	)
+
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == sep:
+			parts = append(parts, unescape(s[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(parts, unescape(s[start:]))
+}
+
+// splitList splits comma-separated values skipping empty ones
+func splitList(s string) []string {
+	var values []string
+
+	for _, v := range splitUnescaped(s, ',') {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(list, v) {
+			list = append(list, v)
+		}
+	}
+
+	return list
+}
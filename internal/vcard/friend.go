@@ -0,0 +1,134 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcard
+
+import (
+	"strings"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/roma-glushko/frens/internal/journal"
+)
+
+// FromFriend makes the card of the friend with addresses of their locations
+func FromFriend(jr *journal.Journal, f friend.Person) Card {
+	card := Card{Person: f}
+
+	for _, ref := range f.Locations {
+		addr := Address{Locality: ref}
+
+		if l, err := jr.GetLocation(ref); err == nil {
+			addr = Address{Locality: l.Name, Country: l.Country}
+		}
+
+		card.Addresses = append(card.Addresses, addr)
+	}
+
+	return card
+}
+
+// Locations links addresses of the card to known locations by their city, region or country.
+// Unknown places are referred to by their names.
+func (c Card) Locations(jr *journal.Journal) []string {
+	refs := make([]string, 0, len(c.Addresses))
+
+	for _, a := range c.Addresses {
+		ref := a.Place()
+
+		for _, place := range []string{a.Locality, a.Region, a.Country} {
+			if place == "" {
+				continue
+			}
+
+			if l, err := jr.GetLocation(place); err == nil {
+				ref = l.ID
+				break
+			}
+		}
+
+		refs = appendUnique(refs, ref)
+	}
+
+	return refs
+}
+
+// Apply updates the friend with details the card carries, e.g. when the card is edited in an address book.
+// Details vCard can't carry are kept: dates other than the birthday and the anniversary, contact tags, etc.
+// Locations of the card must be resolved beforehand.
+func (c Card) Apply(f friend.Person) friend.Person {
+	n := f
+
+	n.Name = c.Person.Name
+	n.Nicknames = c.Person.Nicknames
+	n.Desc = c.Person.Desc
+	n.Tags = c.Person.Tags
+	n.Locations = c.Person.Locations
+	n.Contacts = make([]*friend.Contact, 0, len(c.Person.Contacts))
+
+	for _, ct := range c.Person.Contacts {
+		nc := *ct
+
+		for _, old := range f.Contacts {
+			if old.Type == nc.Type && strings.EqualFold(old.Value, nc.Value) {
+				nc.ID, nc.Tags = old.ID, old.Tags
+				break
+			}
+		}
+
+		n.Contacts = append(n.Contacts, &nc)
+	}
+
+	// dates written to the card are replaced by the card ones
+	written := writtenDates(f.Dates)
+	n.Dates = make([]*friend.Date, 0, len(f.Dates))
+
+	for _, d := range f.Dates {
+		if written[dateProp(*d)] != d {
+			n.Dates = append(n.Dates, d)
+		}
+	}
+
+	for _, d := range c.Person.Dates {
+		nd := *d
+
+		if old := written[dateProp(nd)]; old != nil {
+			params, value := dateValue(nd)
+			oldParams, oldValue := dateValue(*old)
+
+			if params == oldParams && value == oldValue {
+				// keep the original wording of the date
+				nd.Calendar, nd.DateExpr = old.Calendar, old.DateExpr
+			}
+
+			nd.ID, nd.Desc, nd.Tags, nd.Reminders = old.ID, old.Desc, old.Tags, old.Reminders
+		}
+
+		n.Dates = append(n.Dates, &nd)
+	}
+
+	return n
+}
+
+// writtenDates returns dates that make it to the card by their properties
+func writtenDates(dates []*friend.Date) map[string]*friend.Date {
+	written := make(map[string]*friend.Date, 2)
+
+	for _, d := range dates {
+		if prop := dateProp(*d); prop != "" && written[prop] == nil {
+			written[prop] = d
+		}
+	}
+
+	return written
+}
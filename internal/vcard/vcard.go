@@ -0,0 +1,273 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vcard converts friends to and from vCard 4.0 (RFC 6350) contact cards.
+package vcard
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/roma-glushko/frens/internal/calendar"
+	"github.com/roma-glushko/frens/internal/friend"
+)
+
+const lineLimit = 75
+
+// Card is a contact card of a friend
+type Card struct {
+	// UID identifies the card across exports. Cards exported by frens use friend IDs.
+	UID       string
+	Person    friend.Person
+	Addresses []Address
+}
+
+// Address is a postal address of a friend. Friends are linked to places, so streets are kept for reference only.
+type Address struct {
+	Street     string
+	Locality   string
+	Region     string
+	PostalCode string
+	Country    string
+}
+
+// Place returns the most specific named place of the address (e.g. the city)
+func (a Address) Place() string {
+	for _, p := range []string{a.Locality, a.Region, a.Country} {
+		if p != "" {
+			return p
+		}
+	}
+
+	return ""
+}
+
+// socialProfiles are contact types exported as X-SOCIALPROFILE
+var socialProfiles = map[friend.ContactType]bool{
+	friend.ContactTypeTwitter:   true,
+	friend.ContactTypeLinkedIn:  true,
+	friend.ContactTypeGitHub:    true,
+	friend.ContactTypeInstagram: true,
+	friend.ContactTypeFacebook:  true,
+}
+
+// messengers are contact types exported as IMPP with the URI scheme of the messenger
+var messengers = map[friend.ContactType]string{
+	friend.ContactTypeTelegram: "tg",
+	friend.ContactTypeWhatsApp: "whatsapp",
+	friend.ContactTypeSignal:   "sgnl",
+	friend.ContactTypeDiscord:  "discord",
+	friend.ContactTypeSlack:    "slack",
+}
+
+// Encode writes the cards in the vCard 4.0 format
+func Encode(w io.Writer, cards []Card) error {
+	var cw writer
+
+	for _, c := range cards {
+		c.write(&cw)
+	}
+
+	if _, err := io.WriteString(w, cw.String()); err != nil {
+		return fmt.Errorf("failed to write vCards: %w", err)
+	}
+
+	return nil
+}
+
+func (c Card) write(w *writer) {
+	p := c.Person
+
+	w.line("BEGIN:VCARD")
+	w.prop("VERSION", "4.0")
+
+	if uid := c.UID; uid != "" || p.ID != "" {
+		if uid == "" {
+			uid = p.ID
+		}
+
+		w.prop("UID", escape(uid))
+	}
+
+	w.prop("FN", escape(p.Name))
+	w.prop("N", structured(nameParts(p.Name)...))
+
+	if len(p.Nicknames) > 0 {
+		w.prop("NICKNAME", list(p.Nicknames))
+	}
+
+	if p.Desc != "" {
+		w.prop("NOTE", escape(p.Desc))
+	}
+
+	if len(p.Tags) > 0 {
+		w.prop("CATEGORIES", list(p.Tags))
+	}
+
+	for _, ct := range p.Contacts {
+		writeContact(w, *ct)
+	}
+
+	// vCard keeps a single birthday and anniversary
+	written := writtenDates(p.Dates)
+
+	for _, prop := range []string{"BDAY", "ANNIVERSARY"} {
+		if d := written[prop]; d != nil {
+			params, value := dateValue(*d)
+
+			w.prop(prop+params, value)
+		}
+	}
+
+	for _, a := range c.Addresses {
+		w.prop("ADR", structured("", "", a.Street, a.Locality, a.Region, a.PostalCode, a.Country))
+	}
+
+	w.line("END:VCARD")
+}
+
+func writeContact(w *writer, c friend.Contact) {
+	value := escape(c.Value)
+
+	switch {
+	case c.Type == friend.ContactTypeEmail:
+		w.prop("EMAIL", value)
+	case c.Type == friend.ContactTypePhone:
+		w.prop("TEL;VALUE=text", value)
+	case messengers[c.Type] != "":
+		w.prop("IMPP", messengers[c.Type]+":"+value)
+	case socialProfiles[c.Type]:
+		w.prop("X-SOCIALPROFILE;TYPE="+string(c.Type), value)
+	default:
+		w.prop("URL", value)
+	}
+}
+
+// dateProp returns the vCard property of birthdays and anniversaries
+func dateProp(d friend.Date) string {
+	kind := strings.ToLower(d.Desc + " " + strings.Join(d.Tags, " "))
+
+	switch {
+	case strings.Contains(kind, "birthday"):
+		return "BDAY"
+	case strings.Contains(kind, "anniversary"):
+		return "ANNIVERSARY"
+	default:
+		return ""
+	}
+}
+
+// dateValue formats the date and its parameters (e.g. "19781001", "--1001").
+// Dates in other calendars are kept as text, as vCard dates are Gregorian.
+func dateValue(d friend.Date) (string, string) {
+	e, err := calendar.Parse(d)
+
+	switch {
+	case err != nil:
+		return ";VALUE=text", escape(d.DateExpr)
+	case e.Calendar != friend.CalendarGregorian:
+		return ";VALUE=text;CALSCALE=" + e.Calendar, escape(d.DateExpr)
+	case e.HasYear():
+		return "", fmt.Sprintf("%04d%02d%02d", e.Year, e.Month, e.Day)
+	default:
+		return "", fmt.Sprintf("--%02d%02d", e.Month, e.Day)
+	}
+}
+
+// nameParts splits the full name into the family, given and additional names
+func nameParts(name string) []string {
+	parts := strings.Fields(name)
+
+	switch len(parts) {
+	case 0:
+		return []string{"", ""}
+	case 1:
+		return []string{"", parts[0]}
+	default:
+		return []string{
+			parts[len(parts)-1],
+			parts[0],
+			strings.Join(parts[1:len(parts)-1], " "),
+		}
+	}
+}
+
+// structured joins components of values like N or ADR
+func structured(parts ...string) string {
+	escaped := make([]string, 0, 5)
+
+	for _, p := range parts {
+		escaped = append(escaped, escape(p))
+	}
+
+	for len(escaped) < 5 {
+		escaped = append(escaped, "")
+	}
+
+	return strings.Join(escaped, ";")
+}
+
+func list(values []string) string {
+	escaped := make([]string, 0, len(values))
+
+	for _, v := range values {
+		escaped = append(escaped, escape(v))
+	}
+
+	return strings.Join(escaped, ",")
+}
+
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writer writes content lines folded at 75 octets and terminated with CRLF
+type writer struct {
+	strings.Builder
+}
+
+func (w *writer) prop(name, value string) {
+	w.line(name + ":" + value)
+}
+
+func (w *writer) line(s string) {
+	limit := lineLimit
+
+	for len(s) > limit {
+		cut := limit
+
+		// don't split multibyte characters
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+
+		s = s[cut:]
+		// the leading space of continuation lines counts towards the limit
+		limit = lineLimit - 1
+	}
+
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/roma-glushko/frens/internal/friend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	card := Card{
+		Person: friend.Person{
+			ID:        "jim-halpert",
+			Name:      "Jim Halpert",
+			Desc:      "a prankster; sells paper, lives in Scranton",
+			Nicknames: []string{"Big Tuna", "Jimbo"},
+			Tags:      []string{"office"},
+			Contacts: []*friend.Contact{
+				{Type: friend.ContactTypeEmail, Value: "jim@dundermifflin.com"},
+				{Type: friend.ContactTypePhone, Value: "+1 570 555 0100"},
+				{Type: friend.ContactTypeTelegram, Value: "bigtuna"},
+				{Type: friend.ContactTypeGitHub, Value: "https://github.com/bigtuna"},
+				{Type: friend.ContactTypeOther, Value: "https://athleap.com"},
+			},
+			Dates: []*friend.Date{
+				{DateExpr: "October 1 1978", Desc: "Birthday"},
+				{DateExpr: "October 8", Desc: "Wedding anniversary"},
+				{DateExpr: "March 5", Desc: "Another birthday"},
+				{Calendar: friend.CalendarHebrew, DateExpr: "1 Nisan", Desc: "Hebrew birthday"},
+			},
+		},
+		Addresses: []Address{{Locality: "Scranton", Region: "PA", Country: "USA"}},
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, Encode(&buf, []Card{card}))
+
+	out := buf.String()
+
+	require.Contains(t, out, "BEGIN:VCARD\r\nVERSION:4.0\r\nUID:jim-halpert\r\nFN:Jim Halpert\r\n")
+	require.Contains(t, out, "N:Halpert;Jim;;;\r\n")
+	require.Contains(t, out, `NOTE:a prankster\; sells paper\, lives in Scranton`+"\r\n")
+	require.Contains(t, out, "IMPP:tg:bigtuna\r\n")
+	require.Contains(t, out, "X-SOCIALPROFILE;TYPE=github:https://github.com/bigtuna\r\n")
+	require.Contains(t, out, "BDAY:19781001\r\n")
+	require.Contains(t, out, "ANNIVERSARY:--1008\r\n")
+	require.Contains(t, out, "ADR:;;;Scranton;PA;;USA\r\n")
+	// only the first birthday fits
+	require.NotContains(t, out, "0305")
+	require.NotContains(t, out, "Nisan")
+
+	cards, err := Decode(&buf)
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+
+	got := cards[0]
+
+	require.Equal(t, "jim-halpert", got.UID)
+	require.Equal(t, card.Person.Name, got.Person.Name)
+	require.Equal(t, card.Person.Desc, got.Person.Desc)
+	require.Equal(t, card.Person.Nicknames, got.Person.Nicknames)
+	require.Equal(t, card.Person.Tags, got.Person.Tags)
+	require.Equal(t, card.Person.Contacts, got.Person.Contacts)
+	require.Equal(t, card.Addresses, got.Addresses)
+	require.Equal(t, []*friend.Date{
+		{Calendar: friend.CalendarGregorian, DateExpr: "1978-10-01", Desc: "Birthday"},
+		{Calendar: friend.CalendarGregorian, DateExpr: "October 8", Desc: "Anniversary"},
+	}, got.Person.Dates)
+}
+
+func TestEncode_HebrewDate(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, Encode(&buf, []Card{{Person: friend.Person{
+		Name:  "Roy Anderson",
+		Dates: []*friend.Date{{Calendar: friend.CalendarHebrew, DateExpr: "1 Nisan 5750", Desc: "Birthday"}},
+	}}}))
+
+	require.Contains(t, buf.String(), "BDAY;VALUE=text;CALSCALE=hebrew:1 Nisan 5750\r\n")
+
+	cards, err := Decode(&buf)
+	require.NoError(t, err)
+	require.Equal(t, []*friend.Date{
+		{Calendar: friend.CalendarHebrew, DateExpr: "1 Nisan 5750", Desc: "Birthday"},
+	}, cards[0].Person.Dates)
+}
+
+func TestDecode_AddressBooks(t *testing.T) {
+	t.Parallel()
+
+	// vCard 3.0 as exported by Apple Contacts and Google Contacts
+	data := strings.Join([]string{
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"PRODID:-//Apple Inc.//macOS 14.0//EN",
+		"N:Beesly;Pamela;Morgan;;",
+		"FN:Pam Beesly",
+		"NICKNAME:Pam,Beesly",
+		"item1.EMAIL;type=INTERNET;type=pref:pam@dundermifflin.com",
+		"TEL;type=CELL;type=VOICE;type=pref:(570) 555-0101",
+		"IMPP;X-SERVICE-TYPE=WhatsApp;type=pref:x-apple:+15705550101",
+		"IMPP;X-SERVICE-TYPE=Skype:skype:pambeesly",
+		`X-SOCIALPROFILE;type=instagram;x-user=pamb:http://instagram.com/pamb`,
+		"X-SOCIALPROFILE:https://www.linkedin.com/in/pam",
+		"item2.ADR;type=HOME;type=pref:;;1725 Slough Avenue;Scranton;PA;18505;",
+		" United States",
+		"BDAY;X-APPLE-OMIT-YEAR=1604:1604-03-25",
+		"ANNIVERSARY:2009-10-08T00:00:00Z",
+		"CATEGORIES:Dunder Mifflin,myContacts",
+		"NOTE:Receptionist\\nArtist",
+		"PHOTO;ENCODING=b;TYPE=JPEG:/9j/4AAQSkZJRgABAQAAAQABAAD/",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"ORG:Dunder Mifflin",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:4.0",
+		"N:Schrute;Dwight;Kurt;;",
+		"EMAIL:mailto:dwight@schrutefarms.com",
+		"TEL;VALUE=uri:tel:+1-570-555-0102",
+		"BDAY:--0120",
+		"URL:https://schrutefarms.com",
+		"END:VCARD",
+		"",
+	}, "\r\n")
+
+	cards, err := Decode(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, cards, 2)
+
+	pam := cards[0].Person
+
+	require.Equal(t, "Pam Beesly", pam.Name)
+	require.Equal(t, "Receptionist\nArtist", pam.Desc)
+	require.Equal(t, []string{"Pam", "Beesly"}, pam.Nicknames)
+	require.Equal(t, []string{"dunder-mifflin", "mycontacts"}, pam.Tags)
+	require.Equal(t, []*friend.Contact{
+		{Type: friend.ContactTypeEmail, Value: "pam@dundermifflin.com"},
+		{Type: friend.ContactTypePhone, Value: "(570) 555-0101"},
+		{Type: friend.ContactTypeWhatsApp, Value: "+15705550101"},
+		{Type: friend.ContactTypeOther, Value: "skype:pambeesly"},
+		{Type: friend.ContactTypeInstagram, Value: "http://instagram.com/pamb"},
+		{Type: friend.ContactTypeLinkedIn, Value: "https://www.linkedin.com/in/pam"},
+	}, pam.Contacts)
+	require.Equal(t, []*friend.Date{
+		{Calendar: friend.CalendarGregorian, DateExpr: "March 25", Desc: "Birthday"},
+		{Calendar: friend.CalendarGregorian, DateExpr: "2009-10-08", Desc: "Anniversary"},
+	}, pam.Dates)
+	require.Equal(t, []Address{{
+		Street:     "1725 Slough Avenue",
+		Locality:   "Scranton",
+		Region:     "PA",
+		PostalCode: "18505",
+		Country:    "United States",
+	}}, cards[0].Addresses)
+
+	dwight := cards[1].Person
+
+	require.Equal(t, "Dwight Kurt Schrute", dwight.Name)
+	require.Equal(t, []*friend.Contact{
+		{Type: friend.ContactTypeEmail, Value: "dwight@schrutefarms.com"},
+		{Type: friend.ContactTypePhone, Value: "+1-570-555-0102"},
+		{Type: friend.ContactTypeOther, Value: "https://schrutefarms.com"},
+	}, dwight.Contacts)
+	require.Equal(t, "January 20", dwight.Dates[0].DateExpr)
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := Decode(strings.NewReader("BEGIN:VCARD\r\nFN:Jan Levinson\r\n"))
+	require.ErrorIs(t, err, ErrInvalidCard)
+
+	_, err = Decode(strings.NewReader("FN:Jan Levinson\r\n"))
+	require.ErrorIs(t, err, ErrInvalidCard)
+
+	_, err = Decode(strings.NewReader("BEGIN:VCARD\r\nFN\r\nEND:VCARD\r\n"))
+	require.ErrorIs(t, err, ErrInvalidCard)
+}
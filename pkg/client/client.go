@@ -0,0 +1,267 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package client is a typed client for the API served by `frens serve`.
+//
+// The API is described by the OpenAPI document at /api/openapi.json.
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Error is returned when the server responds with a non-successful status
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("frens: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsNotFound reports whether the error is a 404 response
+func IsNotFound(err error) bool {
+	var apiErr *Error
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Client talks to a running `frens serve`.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	token      string
+}
+
+type Option func(*Client)
+
+// WithToken authenticates requests with the access token of the server (see `frens serve --token-file`)
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests.
+// Avoid client-wide timeouts if you stream changes, use contexts instead.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// New creates a client for the server at the base URL (e.g. http://127.0.0.1:8080)
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("base URL must be absolute: %s", baseURL)
+	}
+
+	// the token from the URL printed by `frens serve` works too
+	token := u.Query().Get("token")
+
+	u.RawQuery = ""
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		token:      token,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+func (c *Client) newRequest(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	r io.Reader,
+) (*http.Request, error) {
+	u := *c.baseURL
+	u.Path += path
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return req, nil
+}
+
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s %s: %w", req.Method, req.URL.Path, err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+		return nil, &Error{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(msg))}
+	}
+
+	return resp, nil
+}
+
+// do sends the request with an optional body and decodes the JSON response into out if it's not nil
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in body, out any) error {
+	var (
+		r           io.Reader
+		contentType string
+	)
+
+	switch {
+	case in.text != nil:
+		r = strings.NewReader(*in.text)
+		contentType = "text/plain"
+	case in.json != nil:
+		data, err := json.Marshal(in.json)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+
+		r = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+
+	req, err := c.newRequest(ctx, method, path, query, r)
+	if err != nil {
+		return err
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.send(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", method, path, err)
+	}
+
+	return nil
+}
+
+// call sends the request and decodes the JSON response
+func call[T any](ctx context.Context, c *Client, method, path string, query url.Values, in body) (T, error) {
+	var out T
+
+	err := c.do(ctx, method, path, query, in, &out)
+
+	return out, err
+}
+
+// body is either a JSON value or a frentxt string
+type body struct {
+	json any
+	text *string
+}
+
+func jsonBody(v any) body {
+	return body{json: v}
+}
+
+func textBody(s string) body {
+	return body{text: &s}
+}
+
+// StreamChanges calls fn for every journal change until the context is canceled,
+// the server closes the stream, or fn returns an error.
+func (c *Client) StreamChanges(ctx context.Context, fn func(ChangeEvent) error) error {
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/events/stream", nil, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.send(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	var data strings.Builder
+
+	scanner := bufio.NewScanner(resp.Body)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if data.Len() == 0 {
+				continue
+			}
+
+			var change ChangeEvent
+
+			if err := json.Unmarshal([]byte(data.String()), &change); err != nil {
+				return fmt.Errorf("failed to decode change event: %w", err)
+			}
+
+			data.Reset()
+
+			if err := fn(change); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to read change stream: %w", err)
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/roma-glushko/frens/internal/ui"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "s3cr3t"
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	s := file.NewTOMLFileStore(t.TempDir())
+	require.NoError(t, s.Init(t.Context()))
+
+	srv := ui.NewServer("127.0.0.1:0", log.New(io.Discard), s, ui.WithToken(testToken))
+
+	addr, err := srv.Start(t.Context())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = srv.Stop(context.Background())
+	})
+
+	c, err := New("http://"+addr+"/?token="+testToken, WithHTTPClient(&http.Client{}))
+	require.NoError(t, err)
+
+	return c
+}
+
+// jsonFields lists JSON property names of a struct type including embedded structs
+func jsonFields(t reflect.Type) []string {
+	var fields []string
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+
+		tag, hasTag := f.Tag.Lookup("json")
+		if tag == "-" {
+			continue
+		}
+
+		if f.Anonymous && !hasTag {
+			fields = append(fields, jsonFields(f.Type)...)
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		fields = append(fields, name)
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
+
+func TestTypesMatchOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t)
+
+	raw, err := c.OpenAPI(t.Context())
+	require.NoError(t, err)
+
+	data, err := json.Marshal(raw)
+	require.NoError(t, err)
+
+	var doc ui.OpenAPI
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	types := map[string]reflect.Type{}
+
+	for _, v := range []any{
+		Person{}, Contact{}, Date{}, Occurrence{}, Reminder{}, WishlistItem{}, Location{}, Event{},
+		EventResult{}, Stats{}, ComprehensiveStats{}, RankedItem{}, TimelineDataPoint{}, Insight{},
+		SyncStatus{}, FeedItem{}, ChangeEvent{}, Revision{}, FieldChange{},
+	} {
+		types[reflect.TypeOf(v).Name()] = reflect.TypeOf(v)
+	}
+
+	types["PersonPage"] = reflect.TypeFor[Page[Person]]()
+	types["LocationPage"] = reflect.TypeFor[Page[Location]]()
+	types["EventPage"] = reflect.TypeFor[Page[Event]]()
+	types["FeedItemPage"] = reflect.TypeFor[Page[FeedItem]]()
+
+	for name, schema := range doc.Components.Schemas {
+		typ, ok := types[name]
+		require.True(t, ok, "schema %s has no client type", name)
+
+		props := make([]string, 0, len(schema.Properties))
+
+		for p := range schema.Properties {
+			props = append(props, p)
+		}
+
+		sort.Strings(props)
+
+		require.Equal(t, props, jsonFields(typ), "client type %s doesn't match its schema", name)
+	}
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	c := newTestClient(t)
+
+	jim, err := c.CreateFriendText(ctx, "Jim Halpert (aka Big Tuna) :: a prankster #office")
+	require.NoError(t, err)
+	require.Equal(t, "jim-halpert", jim.ID)
+
+	jim, err = c.UpdateFriend(ctx, jim.ID, Person{Desc: "a salesman"})
+	require.NoError(t, err)
+	require.Equal(t, "Jim Halpert", jim.Name)
+	require.Equal(t, "a salesman", jim.Desc)
+	require.Equal(t, []string{"Big Tuna"}, jim.Nicknames)
+
+	contact, err := c.CreateContact(ctx, jim.ID, Contact{Type: "email", Value: "jim@dundermifflin.com"})
+	require.NoError(t, err)
+	require.NotEmpty(t, contact.ID)
+
+	res, err := c.CreateActivityText(ctx, "yesterday :: Big Tuna put a stapler in jello @scranton #pranks")
+	require.NoError(t, err)
+	require.Equal(t, []string{jim.ID}, res.FriendIDs)
+	require.Equal(t, []string{"scranton"}, res.UnknownLocations)
+
+	activities, err := c.ListFriendActivities(ctx, jim.ID, ListOptions{Tags: []string{"pranks"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, activities.Total)
+
+	stats, err := c.Stats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, Stats{Friends: 1, Activities: 1}, stats)
+
+	_, err = c.CreateDate(ctx, jim.ID, Date{DateExpr: "10-01", Desc: "birthday"})
+	require.NoError(t, err)
+
+	cal, err := c.Calendar(ctx, ListOptions{Friends: []string{jim.ID}})
+	require.NoError(t, err)
+	require.Contains(t, string(cal), "SUMMARY:Jim Halpert: birthday")
+
+	require.NoError(t, c.DeleteFriend(ctx, jim.ID, RemoveModeCascade))
+
+	_, err = c.GetFriend(ctx, jim.ID)
+	require.True(t, IsNotFound(err))
+
+	activities, err = c.ListActivities(ctx, ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, activities.Items)
+}
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	c := newTestClient(t)
+
+	for _, name := range []string{"Jim Halpert", "Pam Beesly", "Dwight Schrute", "Stanley Hudson", "Kevin Malone"} {
+		_, err := c.CreateFriend(ctx, Person{Name: name})
+		require.NoError(t, err)
+	}
+
+	var names []string
+
+	for f, err := range All(ctx, ListOptions{Limit: 2, Order: "reverse"}, c.ListFriends) {
+		require.NoError(t, err)
+
+		names = append(names, f.Name)
+	}
+
+	require.Equal(t, []string{"Stanley Hudson", "Pam Beesly", "Kevin Malone", "Jim Halpert", "Dwight Schrute"}, names)
+}
+
+func TestClient_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t)
+	c.token = "wrong"
+
+	_, err := c.ListFriends(t.Context(), ListOptions{})
+
+	var apiErr *Error
+
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
+
+func TestClient_StreamChanges(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	changes := make(chan ChangeEvent, 1)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- c.StreamChanges(ctx, func(change ChangeEvent) error {
+			changes <- change
+			return errors.New("stop")
+		})
+	}()
+
+	// the stream may not be subscribed yet, so keep writing until a change comes through
+	for i := 0; ; i++ {
+		_, err := c.CreateNoteText(ctx, "Angela has a new cat #cats "+strings.Repeat("!", i))
+		require.NoError(t, err)
+
+		select {
+		case change := <-changes:
+			require.Equal(t, "note.created", change.Type)
+			require.Equal(t, "note", change.Entity)
+			require.EqualError(t, <-done, "stop")
+
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+func friendPath(id string, sub ...string) string {
+	p := "/api/friends/" + url.PathEscape(id)
+
+	for _, s := range sub {
+		p += "/" + url.PathEscape(s)
+	}
+
+	return p
+}
+
+func removeQuery(mode RemoveMode) url.Values {
+	if mode == "" {
+		return nil
+	}
+
+	return url.Values{"mode": {string(mode)}}
+}
+
+// ListFriends returns a page of friends, sorted by name by default
+func (c *Client) ListFriends(ctx context.Context, opts ListOptions) (Page[Person], error) {
+	return call[Page[Person]](ctx, c, http.MethodGet, "/api/friends", opts.values(), body{})
+}
+
+// GetFriend returns a friend by ID
+func (c *Client) GetFriend(ctx context.Context, id string) (Person, error) {
+	return call[Person](ctx, c, http.MethodGet, friendPath(id), nil, body{})
+}
+
+// CreateFriend adds a friend
+func (c *Client) CreateFriend(ctx context.Context, f Person) (Person, error) {
+	return call[Person](ctx, c, http.MethodPost, "/api/friends", nil, jsonBody(f))
+}
+
+// CreateFriendText adds a friend described in frentxt (e.g. "Jim Halpert (aka Big Tuna) :: a prankster #office")
+func (c *Client) CreateFriendText(ctx context.Context, info string) (Person, error) {
+	return call[Person](ctx, c, http.MethodPost, "/api/friends", nil, textBody(info))
+}
+
+// UpdateFriend updates a friend. Empty fields keep their current values.
+func (c *Client) UpdateFriend(ctx context.Context, id string, f Person) (Person, error) {
+	return call[Person](ctx, c, http.MethodPatch, friendPath(id), nil, jsonBody(f))
+}
+
+// DeleteFriend deletes a friend
+func (c *Client) DeleteFriend(ctx context.Context, id string, mode RemoveMode) error {
+	return c.do(ctx, http.MethodDelete, friendPath(id), removeQuery(mode), body{}, nil)
+}
+
+// ListFriendActivities returns a page of activities with the friend
+func (c *Client) ListFriendActivities(ctx context.Context, id string, opts ListOptions) (Page[Event], error) {
+	return call[Page[Event]](ctx, c, http.MethodGet, friendPath(id, "activities"), opts.values(), body{})
+}
+
+// ListFriendNotes returns a page of notes about the friend
+func (c *Client) ListFriendNotes(ctx context.Context, id string, opts ListOptions) (Page[Event], error) {
+	return call[Page[Event]](ctx, c, http.MethodGet, friendPath(id, "notes"), opts.values(), body{})
+}
+
+// FriendHistory returns changes of a friend committed to the journal repository, newest first
+func (c *Client) FriendHistory(ctx context.Context, id string) ([]Revision, error) {
+	return call[[]Revision](ctx, c, http.MethodGet, friendPath(id, "history"), nil, body{})
+}
+
+// CreateContact adds a contact to a friend
+func (c *Client) CreateContact(ctx context.Context, friendID string, contact Contact) (Contact, error) {
+	created, err := call[[]Contact](ctx, c, http.MethodPost, friendPath(friendID, "contacts"), nil, jsonBody(contact))
+	if err != nil {
+		return Contact{}, err
+	}
+
+	if len(created) == 0 {
+		return Contact{}, nil
+	}
+
+	return created[0], nil
+}
+
+// CreateContactsText adds contacts described in frentxt (e.g. "@jim #work jim@dundermifflin.com")
+func (c *Client) CreateContactsText(ctx context.Context, friendID, info string) ([]Contact, error) {
+	return call[[]Contact](ctx, c, http.MethodPost, friendPath(friendID, "contacts"), nil, textBody(info))
+}
+
+// UpdateContact updates a friend's contact
+func (c *Client) UpdateContact(ctx context.Context, friendID, contactID string, contact Contact) (Contact, error) {
+	return call[Contact](ctx, c, http.MethodPatch, friendPath(friendID, "contacts", contactID), nil, jsonBody(contact))
+}
+
+// DeleteContact deletes a friend's contact
+func (c *Client) DeleteContact(ctx context.Context, friendID, contactID string) error {
+	return c.do(ctx, http.MethodDelete, friendPath(friendID, "contacts", contactID), nil, body{}, nil)
+}
+
+// CreateDate adds an important date to a friend
+func (c *Client) CreateDate(ctx context.Context, friendID string, d Date) (Date, error) {
+	return call[Date](ctx, c, http.MethodPost, friendPath(friendID, "dates"), nil, jsonBody(d))
+}
+
+// UpdateDate updates a friend's date
+func (c *Client) UpdateDate(ctx context.Context, friendID, dateID string, d Date) (Date, error) {
+	return call[Date](ctx, c, http.MethodPatch, friendPath(friendID, "dates", dateID), nil, jsonBody(d))
+}
+
+// DeleteDate deletes a friend's date
+func (c *Client) DeleteDate(ctx context.Context, friendID, dateID string) error {
+	return c.do(ctx, http.MethodDelete, friendPath(friendID, "dates", dateID), nil, body{}, nil)
+}
+
+// CreateWishlistItem adds an item to a friend's wishlist
+func (c *Client) CreateWishlistItem(ctx context.Context, friendID string, item WishlistItem) (WishlistItem, error) {
+	return call[WishlistItem](ctx, c, http.MethodPost, friendPath(friendID, "wishlist"), nil, jsonBody(item))
+}
+
+// UpdateWishlistItem updates a friend's wishlist item
+func (c *Client) UpdateWishlistItem(
+	ctx context.Context,
+	friendID, itemID string,
+	item WishlistItem,
+) (WishlistItem, error) {
+	return call[WishlistItem](ctx, c, http.MethodPatch, friendPath(friendID, "wishlist", itemID), nil, jsonBody(item))
+}
+
+// DeleteWishlistItem deletes a friend's wishlist item
+func (c *Client) DeleteWishlistItem(ctx context.Context, friendID, itemID string) error {
+	return c.do(ctx, http.MethodDelete, friendPath(friendID, "wishlist", itemID), nil, body{}, nil)
+}
+
+func locationPath(id string, sub ...string) string {
+	p := "/api/locations/" + url.PathEscape(id)
+
+	for _, s := range sub {
+		p += "/" + url.PathEscape(s)
+	}
+
+	return p
+}
+
+// ListLocations returns a page of locations, sorted by name by default
+func (c *Client) ListLocations(ctx context.Context, opts ListOptions) (Page[Location], error) {
+	return call[Page[Location]](ctx, c, http.MethodGet, "/api/locations", opts.values(), body{})
+}
+
+// GetLocation returns a location by ID
+func (c *Client) GetLocation(ctx context.Context, id string) (Location, error) {
+	return call[Location](ctx, c, http.MethodGet, locationPath(id), nil, body{})
+}
+
+// CreateLocation adds a location
+func (c *Client) CreateLocation(ctx context.Context, l Location) (Location, error) {
+	return call[Location](ctx, c, http.MethodPost, "/api/locations", nil, jsonBody(l))
+}
+
+// CreateLocationText adds a location described in frentxt (e.g. "Scranton, USA :: the Electric City")
+func (c *Client) CreateLocationText(ctx context.Context, info string) (Location, error) {
+	return call[Location](ctx, c, http.MethodPost, "/api/locations", nil, textBody(info))
+}
+
+// UpdateLocation updates a location. Empty fields keep their current values.
+func (c *Client) UpdateLocation(ctx context.Context, id string, l Location) (Location, error) {
+	return call[Location](ctx, c, http.MethodPatch, locationPath(id), nil, jsonBody(l))
+}
+
+// DeleteLocation deletes a location
+func (c *Client) DeleteLocation(ctx context.Context, id string, mode RemoveMode) error {
+	return c.do(ctx, http.MethodDelete, locationPath(id), removeQuery(mode), body{}, nil)
+}
+
+// ListLocationActivities returns a page of activities at the location
+func (c *Client) ListLocationActivities(ctx context.Context, id string, opts ListOptions) (Page[Event], error) {
+	return call[Page[Event]](ctx, c, http.MethodGet, locationPath(id, "activities"), opts.values(), body{})
+}
+
+// ListLocationNotes returns a page of notes about the location
+func (c *Client) ListLocationNotes(ctx context.Context, id string, opts ListOptions) (Page[Event], error) {
+	return call[Page[Event]](ctx, c, http.MethodGet, locationPath(id, "notes"), opts.values(), body{})
+}
+
+// ListActivities returns a page of activities, newest first by default
+func (c *Client) ListActivities(ctx context.Context, opts ListOptions) (Page[Event], error) {
+	return call[Page[Event]](ctx, c, http.MethodGet, "/api/activities", opts.values(), body{})
+}
+
+// CreateActivity logs an activity. Friends are detected in the description by the server.
+func (c *Client) CreateActivity(ctx context.Context, e Event) (EventResult, error) {
+	return call[EventResult](ctx, c, http.MethodPost, "/api/activities", nil, jsonBody(e))
+}
+
+// CreateActivityText logs an activity described in frentxt (e.g. "yesterday :: Jim put my stapler in jello #pranks")
+func (c *Client) CreateActivityText(ctx context.Context, info string) (EventResult, error) {
+	return call[EventResult](ctx, c, http.MethodPost, "/api/activities", nil, textBody(info))
+}
+
+// UpdateActivity updates an activity. Empty fields keep their current values.
+func (c *Client) UpdateActivity(ctx context.Context, id string, e Event) (EventResult, error) {
+	return call[EventResult](ctx, c, http.MethodPatch, "/api/activities/"+url.PathEscape(id), nil, jsonBody(e))
+}
+
+// DeleteActivity deletes an activity
+func (c *Client) DeleteActivity(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/api/activities/"+url.PathEscape(id), nil, body{}, nil)
+}
+
+// ActivityHistory returns changes of an activity committed to the journal repository, newest first
+func (c *Client) ActivityHistory(ctx context.Context, id string) ([]Revision, error) {
+	return call[[]Revision](ctx, c, http.MethodGet, "/api/activities/"+url.PathEscape(id)+"/history", nil, body{})
+}
+
+// ListNotes returns a page of notes, newest first by default
+func (c *Client) ListNotes(ctx context.Context, opts ListOptions) (Page[Event], error) {
+	return call[Page[Event]](ctx, c, http.MethodGet, "/api/notes", opts.values(), body{})
+}
+
+// CreateNote adds a note. Friends are detected in the description by the server.
+func (c *Client) CreateNote(ctx context.Context, e Event) (EventResult, error) {
+	return call[EventResult](ctx, c, http.MethodPost, "/api/notes", nil, jsonBody(e))
+}
+
+// CreateNoteText adds a note described in frentxt (e.g. "Angela has a new cat named Sprinkles #cats")
+func (c *Client) CreateNoteText(ctx context.Context, info string) (EventResult, error) {
+	return call[EventResult](ctx, c, http.MethodPost, "/api/notes", nil, textBody(info))
+}
+
+// UpdateNote updates a note. Empty fields keep their current values.
+func (c *Client) UpdateNote(ctx context.Context, id string, e Event) (EventResult, error) {
+	return call[EventResult](ctx, c, http.MethodPatch, "/api/notes/"+url.PathEscape(id), nil, jsonBody(e))
+}
+
+// DeleteNote deletes a note
+func (c *Client) DeleteNote(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/api/notes/"+url.PathEscape(id), nil, body{}, nil)
+}
+
+// Stats counts journal entities
+func (c *Client) Stats(ctx context.Context) (Stats, error) {
+	return call[Stats](ctx, c, http.MethodGet, "/api/stats", nil, body{})
+}
+
+// ComprehensiveStats returns rankings, the activity timeline and insights
+func (c *Client) ComprehensiveStats(ctx context.Context) (ComprehensiveStats, error) {
+	return call[ComprehensiveStats](ctx, c, http.MethodGet, "/api/stats/comprehensive", nil, body{})
+}
+
+// SyncStatus returns the git sync status of the journal
+func (c *Client) SyncStatus(ctx context.Context) (SyncStatus, error) {
+	return call[SyncStatus](ctx, c, http.MethodGet, "/api/sync/status", nil, body{})
+}
+
+// Feed returns a page of journal entries, newest first by default
+func (c *Client) Feed(ctx context.Context, opts ListOptions) (Page[FeedItem], error) {
+	return call[Page[FeedItem]](ctx, c, http.MethodGet, "/api/feed", opts.values(), body{})
+}
+
+// Calendar returns friend dates as an iCalendar document, filtered by friends and date tags
+func (c *Client) Calendar(ctx context.Context, opts ListOptions) ([]byte, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/calendar.ics", opts.values(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	return data, nil
+}
+
+// OpenAPI returns the OpenAPI document of the server
+func (c *Client) OpenAPI(ctx context.Context) (map[string]any, error) {
+	return call[map[string]any](ctx, c, http.MethodGet, "/api/openapi.json", nil, body{})
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Page is a slice of a list along with the total number of items that match the filters
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int    `json:"total"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// ListOptions paginates, filters and sorts lists. Filters that don't apply to a list are ignored by the server.
+type ListOptions struct {
+	// Limit is the page size (the server defaults to 100 and allows at most 1000)
+	Limit int
+	// Cursor is the NextCursor of the previous page
+	Cursor string
+
+	Query     string
+	Tags      []string
+	Friends   []string // names, nicknames or IDs (activities and notes)
+	Locations []string
+	Countries []string // locations only
+	Since     time.Time
+	Until     time.Time
+
+	// Sort is "alpha", "activities" or "recency"
+	Sort string
+	// Order is "direct" or "reverse"
+	Order string
+}
+
+func (o ListOptions) values() url.Values {
+	v := url.Values{}
+
+	set := func(key, value string) {
+		if value != "" {
+			v.Set(key, value)
+		}
+	}
+
+	if o.Limit > 0 {
+		v.Set("limit", strconv.Itoa(o.Limit))
+	}
+
+	set("cursor", o.Cursor)
+	set("q", o.Query)
+	set("tags", strings.Join(o.Tags, ","))
+	set("friends", strings.Join(o.Friends, ","))
+	set("locations", strings.Join(o.Locations, ","))
+	set("countries", strings.Join(o.Countries, ","))
+	set("sort", o.Sort)
+	set("order", o.Order)
+
+	if !o.Since.IsZero() {
+		v.Set("since", o.Since.Format(time.RFC3339))
+	}
+
+	if !o.Until.IsZero() {
+		v.Set("until", o.Until.Format(time.RFC3339))
+	}
+
+	return v
+}
+
+// All walks through every page of a list, e.g.:
+//
+//	for f, err := range client.All(ctx, opts, c.ListFriends) { ... }
+func All[T any](
+	ctx context.Context,
+	opts ListOptions,
+	list func(context.Context, ListOptions) (Page[T], error),
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			page, err := list(ctx, opts)
+			if err != nil {
+				var zero T
+
+				yield(zero, err)
+
+				return
+			}
+
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if page.NextCursor == "" {
+				return
+			}
+
+			opts.Cursor = page.NextCursor
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import "time"
+
+// The types mirror the schemas of the OpenAPI document served at /api/openapi.json.
+// Unlike the server, entity types omit all empty fields, so that they work as partial updates.
+
+// Person is a friend
+type Person struct {
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Desc      string          `json:"description,omitempty"`
+	Nicknames []string        `json:"nicknames,omitempty"`
+	Tags      []string        `json:"tags,omitempty"`
+	Locations []string        `json:"locations,omitempty"`
+	Contacts  []*Contact      `json:"contacts,omitempty"`
+	Dates     []*Date         `json:"dates,omitempty"`
+	Wishlist  []*WishlistItem `json:"wishlist,omitempty"`
+	CreatedAt time.Time       `json:"createdAt,omitzero"`
+
+	Activities         int       `json:"activitiesCount,omitempty"`
+	Notes              int       `json:"notesCount,omitempty"`
+	MostRecentActivity time.Time `json:"lastActivity,omitzero"`
+}
+
+type Contact struct {
+	ID    string   `json:"id,omitempty"`
+	Type  string   `json:"type,omitempty"`
+	Value string   `json:"value,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+// Date is an important date in a friend's life (e.g. a birthday)
+type Date struct {
+	ID        string      `json:"id,omitempty"`
+	Calendar  string      `json:"calendar,omitempty"`
+	DateExpr  string      `json:"dateExpr,omitempty"`
+	Desc      string      `json:"description,omitempty"`
+	Tags      []string    `json:"tags,omitempty"`
+	Reminders []*Reminder `json:"reminders,omitempty"`
+	Person    string      `json:"person,omitempty"`
+	Next      *Occurrence `json:"next,omitempty"`
+}
+
+// Occurrence is the next time a date comes around
+type Occurrence struct {
+	Date     time.Time `json:"date,omitzero"`
+	DaysLeft int       `json:"daysLeft,omitempty"`
+	Years    int       `json:"years,omitempty"`
+}
+
+type Reminder struct {
+	ID              string         `json:"id,omitempty"`
+	Desc            string         `json:"description,omitempty"`
+	DateExpr        string         `json:"dateExpr,omitempty"`
+	Recurrence      string         `json:"recurrence,omitempty"`
+	OffsetDirection string         `json:"offsetDirection,omitempty"`
+	Offset          *time.Duration `json:"offset,omitempty"`
+	Tags            []string       `json:"tags,omitempty"`
+	CreatedAt       time.Time      `json:"createdAt,omitzero"`
+	DateID          string         `json:"dateId,omitempty"`
+	Person          string         `json:"person,omitempty"`
+	NextAt          time.Time      `json:"nextAt,omitzero"`
+}
+
+type WishlistItem struct {
+	ID        string    `json:"id,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitzero"`
+	Desc      string    `json:"description,omitempty"`
+	Link      string    `json:"link,omitempty"`
+	Price     string    `json:"price,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	Location  []string  `json:"locations,omitempty"`
+	Person    string    `json:"person,omitempty"`
+}
+
+type Location struct {
+	ID        string    `json:"id,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Country   string    `json:"country,omitempty"`
+	Desc      string    `json:"description,omitempty"`
+	Aliases   []string  `json:"aliases,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	Lat       *float64  `json:"lat,omitempty"`
+	Lng       *float64  `json:"lng,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitzero"`
+
+	Notes              int       `json:"notesCount,omitempty"`
+	Activities         int       `json:"activitiesCount,omitempty"`
+	MostRecentActivity time.Time `json:"lastActivity,omitzero"`
+}
+
+// Event is an activity or a note
+type Event struct {
+	ID          string    `json:"id,omitempty"`
+	Type        string    `json:"type,omitempty"`
+	Date        time.Time `json:"date,omitzero"`
+	Desc        string    `json:"description,omitempty"`
+	FriendIDs   []string  `json:"friendIds,omitempty"`
+	LocationIDs []string  `json:"locationIds,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+}
+
+// EventResult is an added or updated event along with location markers that matched no known location
+type EventResult struct {
+	Event
+
+	UnknownLocations []string `json:"unknownLocations,omitempty"`
+}
+
+// RemoveMode defines what happens to activities and notes that reference a deleted friend or location
+type RemoveMode string
+
+const (
+	// RemoveModeDetach keeps the events, dropping the reference (the server default)
+	RemoveModeDetach RemoveMode = "detach"
+	// RemoveModeCascade deletes the events too
+	RemoveModeCascade RemoveMode = "cascade"
+)
+
+type Stats struct {
+	Friends    int `json:"friends"`
+	Locations  int `json:"locations"`
+	Activities int `json:"activities"`
+	Notes      int `json:"notes"`
+}
+
+type ComprehensiveStats struct {
+	Counts           Stats               `json:"counts"`
+	TopFriends       []RankedItem        `json:"topFriends"`
+	TopLocations     []RankedItem        `json:"topLocations"`
+	TopTags          []RankedItem        `json:"topTags"`
+	ActivityTimeline []TimelineDataPoint `json:"activityTimeline"`
+	Insights         []Insight           `json:"insights"`
+}
+
+type RankedItem struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Count        int    `json:"count"`
+	LastActivity string `json:"lastActivity,omitempty"`
+}
+
+type TimelineDataPoint struct {
+	Month      string `json:"month"`
+	Activities int    `json:"activities"`
+	Notes      int    `json:"notes"`
+}
+
+type Insight struct {
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	FriendID    string `json:"friendId,omitempty"`
+}
+
+type SyncStatus struct {
+	GitInstalled bool   `json:"gitInstalled"`
+	GitInited    bool   `json:"gitInited"`
+	Branch       string `json:"branch,omitempty"`
+	HasChanges   bool   `json:"hasChanges"`
+	ChangeCount  int    `json:"changeCount"`
+}
+
+// Revision is a change of an entity committed to the journal repository.
+// Kind is "+" for additions, "~" for updates and "-" for removals.
+type Revision struct {
+	Commit  string        `json:"commit"`
+	Author  string        `json:"author"`
+	Date    time.Time     `json:"date"`
+	Message string        `json:"message"`
+	Kind    string        `json:"kind"`
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// FieldChange is a change of an entity field. Old is empty for values added, New for values removed.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// FeedItem is an entry of the journal feed ("activity", "note", "friend_added" or "location_added")
+type FeedItem struct {
+	ID          string   `json:"id"`
+	Type        string   `json:"type"`
+	Date        string   `json:"date"`
+	Description string   `json:"description"`
+	FriendIDs   []string `json:"friendIds,omitempty"`
+	LocationIDs []string `json:"locationIds,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	EntityID    string   `json:"entityId,omitempty"`
+	EntityName  string   `json:"entityName,omitempty"`
+}
+
+// ChangeEvent is a journal change (e.g. "friend.created", "activity.updated", "note.deleted")
+type ChangeEvent struct {
+	Seq    uint64    `json:"seq"`
+	Type   string    `json:"type"`
+	Entity string    `json:"entity"`
+	ID     string    `json:"id"`
+	At     time.Time `json:"at"`
+}
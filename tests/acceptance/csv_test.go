@@ -0,0 +1,98 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acceptance
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/roma-glushko/frens/cmd"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSV_ExportImport(t *testing.T) {
+	ctx := t.Context()
+	app := cmd.NewApp()
+
+	jDir, err := InitJournal(t, app)
+	require.NoError(t, err)
+
+	for _, args := range [][]string{
+		{"friend", "add", "Jim Halpert (aka Big Tuna) :: a prankster #office"},
+		{"friend", "contact", "add", "jim", "jim@dundermifflin.com"},
+		{"activity", "add", "2009/09/08 :: Jim got married at Niagara Falls #wedding"},
+	} {
+		require.NoError(t, app.RunContext(ctx, append([]string{"frens", "-j", jDir}, args...)))
+	}
+
+	dir := t.TempDir()
+
+	for _, entity := range [][]string{{"friend"}, {"friend", "contact"}, {"activity"}} {
+		out := filepath.Join(dir, strings.Join(entity, "-")+".csv")
+		args := append(append([]string{"frens", "-j", jDir}, entity...), "export", "--csv", "-o", out)
+
+		require.NoError(t, app.RunContext(ctx, args))
+	}
+
+	friends := filepath.Join(dir, "friend.csv")
+
+	data, err := os.ReadFile(friends)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "jim-halpert,Jim Halpert,Big Tuna,a prankster,office,,")
+
+	// edit the table the way a spreadsheet would
+	edited := strings.Replace(string(data), "a prankster", "a salesman", 1) + ",Pam Beesly,,a receptionist,office,,\n"
+	require.NoError(t, os.WriteFile(friends, []byte(edited), 0o600))
+
+	err = app.RunContext(ctx, []string{"frens", "-j", jDir, "friend", "import", "--csv", "--dry-run", friends})
+	require.NoError(t, err)
+
+	jr, err := file.NewTOMLFileStore(jDir).Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, jr.Friends, 1)
+	require.Equal(t, "a prankster", jr.Friends[0].Desc)
+
+	err = app.RunContext(ctx, []string{"frens", "-j", jDir, "friend", "import", "--csv", friends})
+	require.NoError(t, err)
+
+	jr, err = file.NewTOMLFileStore(jDir).Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, jr.Friends, 2)
+
+	jim, err := jr.GetFriend("jim")
+	require.NoError(t, err)
+	require.Equal(t, "a salesman", jim.Desc)
+	require.Len(t, jim.Contacts, 1)
+
+	// unchanged tables don't duplicate records
+	for _, entity := range [][]string{{"friend", "contact"}, {"activity"}} {
+		in := filepath.Join(dir, strings.Join(entity, "-")+".csv")
+		args := append(append([]string{"frens", "-j", jDir}, entity...), "import", "--csv", in)
+
+		require.NoError(t, app.RunContext(ctx, args))
+	}
+
+	jr, err = file.NewTOMLFileStore(jDir).Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, jr.Activities, 1)
+	require.Equal(t, []string{"jim-halpert"}, jr.Activities[0].FriendIDs)
+
+	jim, err = jr.GetFriend("jim")
+	require.NoError(t, err)
+	require.Len(t, jim.Contacts, 1)
+}
@@ -256,3 +256,47 @@ func TestFriendDate_List_WithSearch(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestFriendDate_List_Upcoming(t *testing.T) {
+	app := cmd.NewApp()
+
+	jDir, err := InitJournal(t, app)
+	require.NoError(t, err)
+
+	err = app.RunContext(t.Context(), []string{
+		"frens", "-j", jDir,
+		"friend", "add",
+		"John Doe :: A good friend #friends @NewYork $id:john_doe",
+	})
+	require.NoError(t, err)
+
+	err = app.RunContext(t.Context(), []string{
+		"frens", "-j", jDir,
+		"friend", "date", "add",
+		"john_doe",
+		"1990-05-13 :: birthday",
+	})
+	require.NoError(t, err)
+
+	err = app.RunContext(t.Context(), []string{
+		"frens", "-j", jDir,
+		"friend", "date", "add",
+		"john_doe",
+		"15 Nisan :: seder #hebrew $cal:hebrew",
+	})
+	require.NoError(t, err)
+
+	err = app.RunContext(t.Context(), []string{
+		"frens", "-j", jDir,
+		"friend", "date", "list",
+		"--upcoming", "52w",
+	})
+	require.NoError(t, err)
+
+	err = app.RunContext(t.Context(), []string{
+		"frens", "-j", jDir,
+		"friend", "date", "list",
+		"--upcoming", "soon",
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acceptance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/roma-glushko/frens/cmd"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFriend_ExportImportVCard(t *testing.T) {
+	ctx := t.Context()
+	app := cmd.NewApp()
+
+	jDir, err := InitJournal(t, app)
+	require.NoError(t, err)
+
+	for _, args := range [][]string{
+		{"location", "add", "Scranton :: the Electric City @USA"},
+		{"friend", "add", "Jim Halpert (aka Big Tuna) :: a prankster #office @Scranton"},
+		{"friend", "contact", "add", "jim", "jim@dundermifflin.com tg:@bigtuna"},
+		{"friend", "date", "add", "jim", "October 1 1978 :: birthday"},
+		{"friend", "add", "Pam Beesly :: a receptionist #office"},
+	} {
+		require.NoError(t, app.RunContext(ctx, append([]string{"frens", "-j", jDir}, args...)))
+	}
+
+	vcf := filepath.Join(t.TempDir(), "office.vcf")
+
+	err = app.RunContext(ctx, []string{"frens", "-j", jDir, "friend", "export", "--vcf", "-o", vcf, "-t", "office"})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(vcf)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "FN:Jim Halpert\r\n")
+	require.Contains(t, string(data), "BDAY:19781001\r\n")
+
+	// importing into a new journal adds everyone
+	otherDir, err := InitJournal(t, app)
+	require.NoError(t, err)
+
+	err = app.RunContext(ctx, []string{"frens", "-j", otherDir, "friend", "import", "-t", "imported", vcf})
+	require.NoError(t, err)
+
+	jr, err := file.NewTOMLFileStore(otherDir).Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, jr.Friends, 2)
+
+	jim, err := jr.GetFriend("Big Tuna")
+	require.NoError(t, err)
+	require.Equal(t, "jim-halpert", jim.ID)
+	require.ElementsMatch(t, []string{"office", "imported"}, jim.Tags)
+	require.Equal(t, []string{"Scranton"}, jim.Locations)
+	require.Len(t, jim.Contacts, 2)
+	require.Len(t, jim.Dates, 1)
+	require.NotEmpty(t, jim.Dates[0].ID)
+
+	// importing back into the original journal merges duplicates
+	err = app.RunContext(ctx, []string{"frens", "-j", jDir, "friend", "import", "--duplicates", "merge", vcf})
+	require.NoError(t, err)
+
+	jr, err = file.NewTOMLFileStore(jDir).Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, jr.Friends, 2)
+
+	jim, err = jr.GetFriend("jim")
+	require.NoError(t, err)
+	require.Len(t, jim.Contacts, 2)
+	require.Len(t, jim.Dates, 1)
+	require.Equal(t, []string{"Scranton"}, jim.Locations)
+}
@@ -15,10 +15,12 @@
 package acceptance
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/roma-glushko/frens/cmd"
+	"github.com/roma-glushko/frens/internal/store/file"
 	"github.com/stretchr/testify/require"
 )
 
@@ -109,3 +111,36 @@ func TestJournal_AddNote(t *testing.T) {
 	err = app.RunContext(t.Context(), a)
 	require.NoError(t, err)
 }
+
+func TestJournal_ImportFriendsMD(t *testing.T) {
+	app := cmd.NewApp()
+
+	jDir, err := InitJournal(t, app)
+	require.NoError(t, err)
+
+	md := filepath.Join(t.TempDir(), "friends.md")
+
+	err = os.WriteFile(md, []byte(`### Activities:
+- 2017-11-01: Lunch with **Grace Hopper** at _Paris_. @food
+
+### Friends:
+- Grace Hopper (a.k.a. The Admiral) [Paris] @navy
+
+### Locations:
+- Paris
+`), 0o600)
+	require.NoError(t, err)
+
+	err = app.RunContext(t.Context(), []string{"frens", "-j", jDir, "journal", "import", "--from", "friends-md", md})
+	require.NoError(t, err)
+
+	jr, err := file.NewTOMLFileStore(jDir).Load(t.Context())
+	require.NoError(t, err)
+	require.Len(t, jr.Friends, 1)
+	require.Len(t, jr.Locations, 1)
+	require.Len(t, jr.Activities, 1)
+	require.Equal(t, []string{"grace-hopper"}, jr.Activities[0].FriendIDs)
+
+	err = app.RunContext(t.Context(), []string{"frens", "-j", jDir, "journal", "import", "--from", "csv", md})
+	require.Error(t, err)
+}
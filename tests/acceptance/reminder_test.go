@@ -0,0 +1,114 @@
+// Copyright 2026 Roma Hlushko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acceptance
+
+import (
+	"testing"
+
+	"github.com/roma-glushko/frens/cmd"
+	"github.com/roma-glushko/frens/internal/store/file"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminder_CRUD(t *testing.T) {
+	app := cmd.NewApp()
+
+	jDir, err := InitJournal(t, app)
+	require.NoError(t, err)
+
+	err = app.RunContext(t.Context(), []string{
+		"frens", "-j", jDir,
+		"friend", "add",
+		"John Doe :: A good friend #friends @NewYork $id:john_doe",
+	})
+	require.NoError(t, err)
+
+	err = app.RunContext(t.Context(), []string{
+		"frens", "-j", jDir,
+		"friend", "date", "add",
+		"john_doe",
+		"May 13th :: birthday",
+	})
+	require.NoError(t, err)
+
+	jr, err := file.NewTOMLFileStore(jDir).Load(t.Context())
+	require.NoError(t, err)
+	require.Len(t, jr.Friends[0].Dates, 1)
+
+	dateID := jr.Friends[0].Dates[0].ID
+
+	// Attach a reminder to the friend date
+	err = app.RunContext(t.Context(), []string{
+		"frens", "-j", jDir,
+		"reminder", "add",
+		"--date", dateID,
+		"buy a gift #gifts $before:1w",
+	})
+	require.NoError(t, err)
+
+	// Add a standalone reminder
+	err = app.RunContext(t.Context(), []string{
+		"frens", "-j", jDir,
+		"reminder", "add",
+		"today :: call mom $every:monthly",
+	})
+	require.NoError(t, err)
+
+	jr, err = file.NewTOMLFileStore(jDir).Load(t.Context())
+	require.NoError(t, err)
+	require.Len(t, jr.Friends[0].Dates[0].Reminders, 1)
+	require.Len(t, jr.Reminders, 1)
+
+	err = app.RunContext(t.Context(), []string{
+		"frens", "-j", jDir,
+		"reminder", "list",
+		"--with", "john_doe",
+	})
+	require.NoError(t, err)
+
+	err = app.RunContext(t.Context(), []string{
+		"frens", "-j", jDir,
+		"reminder", "due",
+	})
+	require.NoError(t, err)
+
+	err = app.RunContext(t.Context(), []string{
+		"frens", "-j", jDir,
+		"reminder", "delete", "-f",
+		jr.Friends[0].Dates[0].Reminders[0].ID,
+		jr.Reminders[0].ID,
+	})
+	require.NoError(t, err)
+
+	jr, err = file.NewTOMLFileStore(jDir).Load(t.Context())
+	require.NoError(t, err)
+	require.Empty(t, jr.Friends[0].Dates[0].Reminders)
+	require.Empty(t, jr.Reminders)
+}
+
+func TestReminder_Add_UnknownDate(t *testing.T) {
+	app := cmd.NewApp()
+
+	jDir, err := InitJournal(t, app)
+	require.NoError(t, err)
+
+	err = app.RunContext(t.Context(), []string{
+		"frens", "-j", jDir,
+		"reminder", "add",
+		"--date", "unknown",
+		"buy a gift",
+	})
+	require.Error(t, err)
+}
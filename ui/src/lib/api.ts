@@ -66,6 +66,22 @@ export interface SyncStatus {
   changeCount: number;
 }
 
+export interface FieldChange {
+  field: string;
+  old?: string;
+  new?: string;
+}
+
+// Revision is a change of an entity committed to the journal repository
+export interface Revision {
+  commit: string;
+  author: string;
+  date: string;
+  message: string;
+  kind: "+" | "~" | "-";
+  changes?: FieldChange[];
+}
+
 export type FeedItemType = "activity" | "note" | "friend_added" | "location_added";
 
 export interface FeedItem {
@@ -106,6 +122,58 @@ export interface Location {
   lastActivity?: string;
 }
 
+export interface Page<T> {
+  items: T[];
+  total: number;
+  nextCursor?: string;
+}
+
+// Pagination, filters and sorting supported by list endpoints (see /api/openapi.json)
+export interface ListParams {
+  limit?: number;
+  cursor?: string;
+  q?: string;
+  tags?: string[];
+  friends?: string[];
+  locations?: string[];
+  countries?: string[];
+  since?: string;
+  until?: string;
+  sort?: "alpha" | "activities" | "recency";
+  order?: "direct" | "reverse";
+}
+
+function listQuery(params: ListParams = {}): string {
+  const query = new URLSearchParams();
+
+  for (const [key, value] of Object.entries(params)) {
+    if (value === undefined || value === "" || (Array.isArray(value) && value.length === 0)) continue;
+
+    query.set(key, Array.isArray(value) ? value.join(",") : String(value));
+  }
+
+  const qs = query.toString();
+
+  return qs ? `?${qs}` : "";
+}
+
+// Follows cursors until every page of the list is loaded
+export async function listAll<T>(
+  list: (params: ListParams) => Promise<Page<T>>,
+  params: ListParams = {}
+): Promise<T[]> {
+  const items: T[] = [];
+  let cursor: string | undefined;
+
+  do {
+    const page = await list({ limit: 1000, ...params, cursor });
+    items.push(...page.items);
+    cursor = page.nextCursor;
+  } while (cursor);
+
+  return items;
+}
+
 class ApiError extends Error {
   constructor(
     public status: number,
@@ -127,28 +195,126 @@ async function fetchJson<T>(path: string): Promise<T> {
   return response.json();
 }
 
+// The server expects the CSRF cookie value echoed in a header on every state-changing request
+function csrfHeaders(): Record<string, string> {
+  const match = document.cookie.match(/(?:^|;\s*)frens_csrf=([^;]*)/);
+
+  return match ? { "X-CSRF-Token": decodeURIComponent(match[1]) } : {};
+}
+
+async function sendText<T>(method: string, path: string, text: string): Promise<T> {
+  const response = await fetch(`${API_BASE}${path}`, {
+    method,
+    headers: { "Content-Type": "text/plain", ...csrfHeaders() },
+    body: text,
+  });
+
+  if (!response.ok) {
+    const body = await response.text();
+    throw new ApiError(response.status, body || response.statusText);
+  }
+
+  return response.json();
+}
+
+async function sendDelete(path: string): Promise<void> {
+  const response = await fetch(`${API_BASE}${path}`, {
+    method: "DELETE",
+    headers: csrfHeaders(),
+  });
+
+  if (!response.ok) {
+    const body = await response.text();
+    throw new ApiError(response.status, body || response.statusText);
+  }
+}
+
+export type RemoveMode = "detach" | "cascade";
+
+export interface EventResult extends Event {
+  unknownLocations?: string[];
+}
+
+export interface ChangeEvent {
+  seq: number;
+  type: string; // e.g. "friend.created", "activity.updated", "note.deleted"
+  entity: "friend" | "location" | "activity" | "note";
+  id: string;
+  at: string;
+}
+
+// Subscribes to journal changes. Returns a function that closes the subscription.
+export function subscribeChanges(onChange: (change: ChangeEvent) => void): () => void {
+  const source = new EventSource(`${API_BASE}/events/stream`);
+  const kinds = ["created", "updated", "deleted"];
+  const entities = ["friend", "location", "activity", "note"];
+
+  for (const entity of entities) {
+    for (const kind of kinds) {
+      source.addEventListener(`${entity}.${kind}`, (e) => {
+        onChange(JSON.parse((e as MessageEvent).data) as ChangeEvent);
+      });
+    }
+  }
+
+  return () => source.close();
+}
+
 export const api = {
   friends: {
-    list: (): Promise<Friend[]> => fetchJson<Friend[]>("/friends"),
+    list: (params?: ListParams): Promise<Page<Friend>> =>
+      fetchJson<Page<Friend>>(`/friends${listQuery(params)}`),
     get: (id: string): Promise<Friend> => fetchJson<Friend>(`/friends/${id}`),
-    activities: (id: string): Promise<Event[]> =>
-      fetchJson<Event[]>(`/friends/${id}/activities`),
-    notes: (id: string): Promise<Event[]> =>
-      fetchJson<Event[]>(`/friends/${id}/notes`),
+    activities: (id: string, params?: ListParams): Promise<Page<Event>> =>
+      fetchJson<Page<Event>>(`/friends/${id}/activities${listQuery(params)}`),
+    notes: (id: string, params?: ListParams): Promise<Page<Event>> =>
+      fetchJson<Page<Event>>(`/friends/${id}/notes${listQuery(params)}`),
+    history: (id: string): Promise<Revision[]> =>
+      fetchJson<Revision[]>(`/friends/${id}/history`),
+    create: (text: string): Promise<Friend> => sendText<Friend>("POST", "/friends", text),
+    update: (id: string, text: string): Promise<Friend> =>
+      sendText<Friend>("PATCH", `/friends/${id}`, text),
+    delete: (id: string, mode: RemoveMode = "detach"): Promise<void> =>
+      sendDelete(`/friends/${id}?mode=${mode}`),
+    addContacts: (id: string, text: string): Promise<Contact[]> =>
+      sendText<Contact[]>("POST", `/friends/${id}/contacts`, text),
+    addDate: (id: string, text: string): Promise<unknown> =>
+      sendText("POST", `/friends/${id}/dates`, text),
+    addWishlistItem: (id: string, text: string): Promise<unknown> =>
+      sendText("POST", `/friends/${id}/wishlist`, text),
   },
   locations: {
-    list: (): Promise<Location[]> => fetchJson<Location[]>("/locations"),
+    list: (params?: ListParams): Promise<Page<Location>> =>
+      fetchJson<Page<Location>>(`/locations${listQuery(params)}`),
     get: (id: string): Promise<Location> => fetchJson<Location>(`/locations/${id}`),
-    activities: (id: string): Promise<Event[]> =>
-      fetchJson<Event[]>(`/locations/${id}/activities`),
-    notes: (id: string): Promise<Event[]> =>
-      fetchJson<Event[]>(`/locations/${id}/notes`),
+    activities: (id: string, params?: ListParams): Promise<Page<Event>> =>
+      fetchJson<Page<Event>>(`/locations/${id}/activities${listQuery(params)}`),
+    notes: (id: string, params?: ListParams): Promise<Page<Event>> =>
+      fetchJson<Page<Event>>(`/locations/${id}/notes${listQuery(params)}`),
+    create: (text: string): Promise<Location> => sendText<Location>("POST", "/locations", text),
+    update: (id: string, text: string): Promise<Location> =>
+      sendText<Location>("PATCH", `/locations/${id}`, text),
+    delete: (id: string, mode: RemoveMode = "detach"): Promise<void> =>
+      sendDelete(`/locations/${id}?mode=${mode}`),
   },
   notes: {
-    list: (): Promise<Event[]> => fetchJson<Event[]>("/notes"),
+    list: (params?: ListParams): Promise<Page<Event>> =>
+      fetchJson<Page<Event>>(`/notes${listQuery(params)}`),
+    create: (text: string): Promise<EventResult> => sendText<EventResult>("POST", "/notes", text),
+    update: (id: string, text: string): Promise<EventResult> =>
+      sendText<EventResult>("PATCH", `/notes/${id}`, text),
+    delete: (id: string): Promise<void> => sendDelete(`/notes/${id}`),
   },
   activities: {
-    list: (): Promise<Event[]> => fetchJson<Event[]>("/activities"),
+    list: (params?: ListParams): Promise<Page<Event>> =>
+      fetchJson<Page<Event>>(`/activities${listQuery(params)}`),
+    create: (text: string): Promise<EventResult> =>
+      sendText<EventResult>("POST", "/activities", text),
+    update: (id: string, text: string): Promise<EventResult> =>
+      sendText<EventResult>("PATCH", `/activities/${id}`, text),
+    delete: (id: string): Promise<void> => sendDelete(`/activities/${id}`),
+    history: (id: string): Promise<Revision[]> =>
+      fetchJson<Revision[]>(`/activities/${id}/history`),
   },
   stats: {
     get: (): Promise<Stats> => fetchJson<Stats>("/stats"),
@@ -159,6 +325,7 @@ export const api = {
     status: (): Promise<SyncStatus> => fetchJson<SyncStatus>("/sync/status"),
   },
   feed: {
-    list: (): Promise<FeedItem[]> => fetchJson<FeedItem[]>("/feed"),
+    list: (params?: ListParams): Promise<Page<FeedItem>> =>
+      fetchJson<Page<FeedItem>>(`/feed${listQuery(params)}`),
   },
 };